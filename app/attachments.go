@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// formatFileSize formats a byte count as a short human readable size
+func formatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// completePath completes a partially typed file path, shell style.
+// It returns the input extended to the longest common prefix of the matching entries
+// (with a trailing slash for a single directory match) and the sorted candidate names.
+func completePath(input string) (string, []string) {
+	dir, prefix := filepath.Split(input)
+	listDir := expandHome(dir)
+	if listDir == "" {
+		listDir = "."
+	}
+
+	entries, err := os.ReadDir(listDir)
+	if err != nil {
+		return input, nil
+	}
+
+	var candidates []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// Hidden files only show up when asked for explicitly
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			name += "/"
+		}
+		candidates = append(candidates, name)
+	}
+	sort.Strings(candidates)
+
+	if len(candidates) == 0 {
+		return input, nil
+	}
+	if len(candidates) == 1 {
+		return dir + candidates[0], candidates
+	}
+
+	common := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	return dir + strings.TrimSuffix(common, "/"), candidates
+}
+
+// defaultDownloadPath returns where an attachment is saved unless the user picks another path
+func defaultDownloadPath(attachment Attachment) string {
+	name := filepath.Base(attachment.Name)
+	if name == "." || name == string(filepath.Separator) || name == "" {
+		name = "attachment-" + attachment.ID
+	}
+	return name
+}
+
+// resolveDownloadPath returns the file to write; a directory target keeps the attachment's name
+func resolveDownloadPath(target string, attachment Attachment) string {
+	target = expandHome(strings.TrimSpace(target))
+	if target == "" {
+		return defaultDownloadPath(attachment)
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return filepath.Join(target, defaultDownloadPath(attachment))
+	}
+	return target
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestFormatFileSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatFileSize(tt.size); got != tt.expected {
+			t.Errorf("formatFileSize(%d) = %q, expected %q", tt.size, got, tt.expected)
+		}
+	}
+}
+
+func TestCompletePath(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"build.log", "build.txt", "screenshot.png", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "logs"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name               string
+		input              string
+		expected           string
+		expectedCandidates []string
+	}{
+		{"unique file", dir + "/scr", dir + "/screenshot.png", []string{"screenshot.png"}},
+		{"unique directory gets slash", dir + "/lo", dir + "/logs/", []string{"logs/"}},
+		{"common prefix", dir + "/b", dir + "/build.", []string{"build.log", "build.txt"}},
+		{"hidden files skipped", dir + "/", dir + "/", []string{"build.log", "build.txt", "logs/", "screenshot.png"}},
+		{"hidden files on request", dir + "/.h", dir + "/.hidden", []string{".hidden"}},
+		{"no match", dir + "/zzz", dir + "/zzz", nil},
+		{"missing directory", dir + "/missing/x", dir + "/missing/x", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completed, candidates := completePath(tt.input)
+			if completed != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, completed)
+			}
+			if !reflect.DeepEqual(candidates, tt.expectedCandidates) {
+				t.Errorf("expected candidates %v, got %v", tt.expectedCandidates, candidates)
+			}
+		})
+	}
+}
+
+func TestResolveDownloadPath(t *testing.T) {
+	dir := t.TempDir()
+	attachment := Attachment{ID: "1", Name: "build.log"}
+
+	if got := resolveDownloadPath(dir, attachment); got != filepath.Join(dir, "build.log") {
+		t.Errorf("expected directory target to keep the attachment name, got %q", got)
+	}
+	if got := resolveDownloadPath(filepath.Join(dir, "out.txt"), attachment); got != filepath.Join(dir, "out.txt") {
+		t.Errorf("expected explicit file path, got %q", got)
+	}
+	if got := resolveDownloadPath("", attachment); got != "build.log" {
+		t.Errorf("expected default name, got %q", got)
+	}
+	// Attachment names never escape the target directory
+	if got := defaultDownloadPath(Attachment{Name: "../../etc/passwd"}); got != "passwd" {
+		t.Errorf("expected base name only, got %q", got)
+	}
+}
+
+func TestConvertAttachmentRelation(t *testing.T) {
+	rel := "AttachedFile"
+	url := "https://dev.azure.com/org/_apis/wit/attachments/0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"
+	attributes := map[string]interface{}{
+		"name":                "trace.log",
+		"resourceSize":        float64(2048),
+		"resourceCreatedDate": "2024-01-05T10:00:00Z",
+		"comment":             "Crash trace",
+	}
+
+	attachment := convertAttachmentRelation(workitemtracking.WorkItemRelation{Rel: &rel, Url: &url, Attributes: &attributes})
+
+	expected := Attachment{
+		ID:        "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+		Name:      "trace.log",
+		URL:       url,
+		Size:      2048,
+		AddedDate: formatDate("2024-01-05T10:00:00Z"),
+		Comment:   "Crash trace",
+	}
+	if attachment != expected {
+		t.Errorf("expected %+v, got %+v", expected, attachment)
+	}
+}
+
+func TestDummyBackendAttachments(t *testing.T) {
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "Attachment test", "Task", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attachment, err := db.UploadAttachment(context.Background(), "notes.txt", []byte("hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.AddAttachmentToWorkItem(context.Background(), item.ID, *attachment, "Meeting notes"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	refreshed, err := db.GetWorkItemByID(context.Background(), item.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(refreshed.Attachments) != 1 || refreshed.Attachments[0].Comment != "Meeting notes" {
+		t.Fatalf("expected one attachment with comment, got %+v", refreshed.Attachments)
+	}
+
+	content, err := db.DownloadAttachment(context.Background(), refreshed.Attachments[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("expected downloaded content %q, got %q", "hello", content)
+	}
+
+	if err := db.AddAttachmentToWorkItem(context.Background(), 99999, *attachment, ""); err == nil {
+		t.Error("expected error for unknown work item")
+	}
+	if _, err := db.DownloadAttachment(context.Background(), Attachment{ID: "missing"}); err == nil {
+		t.Error("expected error for unknown attachment")
+	}
+}
+
+func TestAttachmentUploadAndDownloadCommands(t *testing.T) {
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "Upload test", "Task", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(source, []byte("log line"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uploaded, ok := uploadAttachmentFrom(db, item.ID, source)().(attachmentUploadedMsg)
+	if !ok || uploaded.err != nil || uploaded.name != "app.log" {
+		t.Fatalf("unexpected upload result: %+v", uploaded)
+	}
+
+	refreshed, _ := db.GetWorkItemByID(context.Background(), item.ID)
+	target := filepath.Join(dir, "copy.log")
+	downloaded, ok := downloadAttachmentTo(db, refreshed.Attachments[0], target)().(attachmentDownloadedMsg)
+	if !ok || downloaded.err != nil {
+		t.Fatalf("unexpected download result: %+v", downloaded)
+	}
+	content, err := os.ReadFile(target)
+	if err != nil || string(content) != "log line" {
+		t.Errorf("expected downloaded file content, got %q (err: %v)", content, err)
+	}
+
+	missing, _ := uploadAttachmentFrom(db, item.ID, filepath.Join(dir, "missing.log"))().(attachmentUploadedMsg)
+	if missing.err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestHandleAttachmentsViewPrompt(t *testing.T) {
+	m := model{
+		client:       NewDummyBackend(),
+		selectedTask: &WorkItem{ID: 1, Attachments: []Attachment{{ID: "a", Name: "one.log"}, {ID: "b", Name: "two.log"}}},
+	}
+	m, _ = m.openAttachments()
+
+	m, _ = m.handleAttachmentsView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.attachments.cursor != 1 {
+		t.Fatalf("expected cursor on second attachment, got %d", m.attachments.cursor)
+	}
+
+	m, _ = m.handleAttachmentsView(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.attachments.mode != downloadAttachment || m.attachments.pathInput.Value() != "two.log" {
+		t.Fatalf("expected download prompt prefilled with name, got mode %d value %q", m.attachments.mode, m.attachments.pathInput.Value())
+	}
+
+	m, _ = m.handleAttachmentsView(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.attachments.mode != browseAttachments || m.state != attachmentsView {
+		t.Errorf("expected esc to cancel the prompt only")
+	}
+
+	m, _ = m.handleAttachmentsView(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != detailView {
+		t.Errorf("expected esc to return to detail view, got %v", m.state)
+	}
+}
+
+func TestHandleAttachmentUploadedMsg(t *testing.T) {
+	m := model{client: NewDummyBackend()}
+
+	m, cmd := m.handleAttachmentUploadedMsg(attachmentUploadedMsg{workItemID: 1, name: "a.log", err: errors.New("too large")})
+	if cmd != nil || m.statusMessage == "" {
+		t.Errorf("expected error status and no refresh, got status %q", m.statusMessage)
+	}
+
+	m, cmd = m.handleAttachmentUploadedMsg(attachmentUploadedMsg{workItemID: 1, name: "a.log"})
+	if cmd == nil || !m.loading {
+		t.Error("expected refresh of the work item after upload")
+	}
+}
@@ -1,33 +1,123 @@
 package main
 
+import "context"
+
 // Backend defines the interface for work item data sources.
-// Implementations include AzureDevOpsClient (production) and DummyBackend (development).
+// Implementations include AzureDevOpsClient and GitHubClient (production), LocalBackend
+// (files on disk) and DummyBackend (development).
+//
+// List queries return the IDs of every match in display order. List rows are fetched
+// separately with GetWorkItemsByIDs, one page at a time, as the user scrolls. Rows are
+// Partial: descriptions, comments and attachments come from GetWorkItemByID or
+// GetWorkItemDetailsByIDs once an item is opened or exported.
+//
+// UpdateWorkItems applies the same updates to many items in one round trip. Items succeed
+// or fail on their own: the map holds the error of each item that failed, and the error
+// return is for the request as a whole.
 type Backend interface {
 	// Work Item CRUD Operations
-	GetWorkItemByID(id int) (*WorkItem, error)
-	GetWorkItems() ([]WorkItem, error)
-	GetWorkItemsForSprint(sprintPath string, excludeIDs []int, limit int) ([]WorkItem, error)
-	GetWorkItemsExcluding(excludeIDs []int, sprintPath string, limit int) ([]WorkItem, error)
-	GetWorkItemsCount() (int, error)
-	GetWorkItemsCountForSprint(sprintPath string) (int, error)
-	UpdateWorkItemState(workItemID int, newState string) error
-	UpdateWorkItem(workItemID int, updates map[string]interface{}) error
-	CreateWorkItem(title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error)
-	DeleteWorkItem(workItemID int) error
-	MoveWorkItemToSprint(workItemID int, iterationPath string) error
-	GetWorkItemTypeStates(workItemType string) ([]string, map[string]string, error)
+	GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error)
+	GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error)
+	GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error)
+	GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error)
+	GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error)
+	UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error
+	UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error
+	UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error)
+	CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error)
+	DeleteWorkItem(ctx context.Context, workItemID int) error
+	MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error
+	GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error)
+	GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error)
+	GetStateCategories(ctx context.Context) (map[string]string, error)
+	GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error)
+	GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error)
+
+	// Attachment Operations
+	DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error)
+	UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error)
+	AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error
 
 	// Sprint Operations
-	GetCurrentAndAdjacentSprints() (prev *Sprint, curr *Sprint, next *Sprint, err error)
+	GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error)
+	GetAllSprints(ctx context.Context) ([]Sprint, error)
+	GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error)
 
 	// Backlog Operations
-	GetRecentBacklogItems(limit int) ([]WorkItem, error)
-	GetRecentBacklogItemsExcluding(excludeIDs []int, limit int) ([]WorkItem, error)
-	GetRecentBacklogItemsCount() (int, error)
-	GetAbandonedWorkItems(currentSprintPath string, limit int) ([]WorkItem, error)
-	GetAbandonedWorkItemsExcluding(excludeIDs []int, currentSprintPath string, limit int) ([]WorkItem, error)
-	GetAbandonedWorkItemsCount(currentSprintPath string) (int, error)
+	GetRecentBacklogItemIDs(ctx context.Context) ([]int, error)
+	GetAbandonedWorkItemIDs(ctx context.Context, currentSprintPath string) ([]int, error)
+
+	// User Operations
+	GetCurrentUser(ctx context.Context) (string, error)
+}
+
+// changeWatcher is implemented by backends that notice edits made outside Hippo. Changes
+// returns nil if the backend doesn't watch for edits.
+type changeWatcher interface {
+	Changes() <-chan struct{}
 }
 
 // Compile-time check that AzureDevOpsClient implements Backend
 var _ Backend = (*AzureDevOpsClient)(nil)
+
+// newBackend connects to the backend the config selects, with the request timeout applied
+// to every call
+func newBackend(config *Config) (Backend, error) {
+	var client Backend
+	switch config.Backend {
+	case githubBackend:
+		github, err := NewGitHubClient(config)
+		if err != nil {
+			return nil, err
+		}
+		client = github
+	case localBackend:
+		local, err := NewLocalBackend(config)
+		if err != nil {
+			return nil, err
+		}
+		client = local
+	default:
+		azure, err := NewAzureDevOpsClient(config)
+		if err != nil {
+			return nil, err
+		}
+		client = azure
+	}
+	return withRequestTimeout(client, config.requestTimeout()), nil
+}
+
+// adjacentSprints picks the sprint running today, in sprints ordered by date, and the ones
+// before and after it. Without a running sprint the next one to start is current, or else
+// the last one. Sprints without an end date are never current.
+func adjacentSprints(sprints []Sprint, today string) (prev *Sprint, curr *Sprint, next *Sprint) {
+	if len(sprints) == 0 {
+		return nil, nil, nil
+	}
+
+	currentIdx := -1
+	for i, sprint := range sprints {
+		if sprint.EndDate == "" {
+			continue
+		}
+		if sprint.StartDate <= today && today <= sprint.EndDate {
+			currentIdx = i
+			break
+		}
+		if currentIdx == -1 && sprint.StartDate > today {
+			currentIdx = i
+		}
+	}
+	if currentIdx == -1 {
+		currentIdx = len(sprints) - 1
+	}
+
+	if currentIdx > 0 {
+		prev = &sprints[currentIdx-1]
+	}
+	curr = &sprints[currentIdx]
+	if currentIdx < len(sprints)-1 {
+		next = &sprints[currentIdx+1]
+	}
+	return prev, curr, next
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError reports a backend call that got no answer within the request timeout
+type TimeoutError struct {
+	Timeout time.Duration
+	Err     error // The error of the call, usually a context deadline
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("the server did not respond within %s; check your connection and try again", e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// timeoutBackend bounds every call of the wrapped backend by a timeout and reports calls that
+// run out of time as a TimeoutError. Cancellation by the caller is passed through unchanged.
+// Attachment transfers take as long as the file needs, and calls that send a request per
+// item bound each of those requests instead of the whole call.
+type timeoutBackend struct {
+	backend Backend
+	timeout time.Duration
+}
+
+// Compile-time check that timeoutBackend implements Backend
+var _ Backend = (*timeoutBackend)(nil)
+
+// withRequestTimeout wraps the backend so each call is bounded by the timeout
+func withRequestTimeout(backend Backend, timeout time.Duration) Backend {
+	return &timeoutBackend{backend: backend, timeout: timeout}
+}
+
+// Changes passes on the edits noticed by the wrapped backend, if it watches for them
+func (b *timeoutBackend) Changes() <-chan struct{} {
+	if watcher, ok := b.backend.(changeWatcher); ok {
+		return watcher.Changes()
+	}
+	return nil
+}
+
+// call returns the context of one call
+func (b *timeoutBackend) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, b.timeout)
+}
+
+// explain turns an error caused by the call's own deadline into a TimeoutError
+func (b *timeoutBackend) explain(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{Timeout: b.timeout, Err: err}
+	}
+	return err
+}
+
+// requestTimeoutKey is the context key of the timeout for each request of a multi-request call
+type requestTimeoutKey struct{}
+
+// perRequest passes the timeout down to a call that bounds each of its requests with subRequest
+func (b *timeoutBackend) perRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, b.timeout)
+}
+
+// explainRequest turns an error caused by the deadline of one request into a TimeoutError.
+// The call's context itself has no deadline, so a caller's cancellation is still passed through.
+func (b *timeoutBackend) explainRequest(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return &TimeoutError{Timeout: b.timeout, Err: err}
+	}
+	return err
+}
+
+// subRequest returns the context of one request of a call that sends a request per item,
+// bounded by the request timeout when the call runs under one
+func subRequest(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (b *timeoutBackend) GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	item, err := b.backend.GetWorkItemByID(ctx, id)
+	return item, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	items, err := b.backend.GetWorkItemsByIDs(ctx, ids)
+	return items, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	items, err := b.backend.GetWorkItemDetailsByIDs(ctx, ids)
+	return items, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	ids, err := b.backend.GetSprintWorkItemIDs(ctx, sprintPath)
+	return ids, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	ids, err := b.backend.GetSprintWorkItemIDsInState(ctx, sprintPath, state)
+	return ids, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	return b.explain(ctx, b.backend.UpdateWorkItemState(ctx, workItemID, newState))
+}
+
+func (b *timeoutBackend) UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	return b.explain(ctx, b.backend.UpdateWorkItem(ctx, workItemID, updates))
+}
+
+func (b *timeoutBackend) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	failed, err := b.backend.UpdateWorkItems(ctx, workItemIDs, updates)
+	return failed, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	item, err := b.backend.CreateWorkItem(ctx, title, workItemType, iterationPath, parentID, areaPath)
+	return item, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) DeleteWorkItem(ctx context.Context, workItemID int) error {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	return b.explain(ctx, b.backend.DeleteWorkItem(ctx, workItemID))
+}
+
+func (b *timeoutBackend) MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	return b.explain(ctx, b.backend.MoveWorkItemToSprint(ctx, workItemID, iterationPath))
+}
+
+func (b *timeoutBackend) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	states, categories, err := b.backend.GetWorkItemTypeStates(ctx, workItemType)
+	return states, categories, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	transitions, err := b.backend.GetWorkItemTypeTransitions(ctx, workItemType)
+	return transitions, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetStateCategories(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	categories, err := b.backend.GetStateCategories(ctx)
+	return categories, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	fields, err := b.backend.GetRequiredFields(ctx, workItemID, workItemType, newState)
+	return fields, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	updates, err := b.backend.GetWorkItemHistory(ctx, workItemID)
+	return updates, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	return b.backend.DownloadAttachment(ctx, attachment)
+}
+
+func (b *timeoutBackend) UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error) {
+	return b.backend.UploadAttachment(ctx, fileName, content)
+}
+
+func (b *timeoutBackend) AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	return b.explain(ctx, b.backend.AddAttachmentToWorkItem(ctx, workItemID, attachment, comment))
+}
+
+func (b *timeoutBackend) GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	prev, curr, next, err = b.backend.GetCurrentAndAdjacentSprints(ctx)
+	return prev, curr, next, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetAllSprints(ctx context.Context) ([]Sprint, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	sprints, err := b.backend.GetAllSprints(ctx)
+	return sprints, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	history, err := b.backend.GetSprintWorkItemRevisions(b.perRequest(ctx), sprintPath)
+	return history, b.explainRequest(ctx, err)
+}
+
+func (b *timeoutBackend) GetRecentBacklogItemIDs(ctx context.Context) ([]int, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	ids, err := b.backend.GetRecentBacklogItemIDs(ctx)
+	return ids, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetAbandonedWorkItemIDs(ctx context.Context, currentSprintPath string) ([]int, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	ids, err := b.backend.GetAbandonedWorkItemIDs(ctx, currentSprintPath)
+	return ids, b.explain(ctx, err)
+}
+
+func (b *timeoutBackend) GetCurrentUser(ctx context.Context) (string, error) {
+	ctx, cancel := b.call(ctx)
+	defer cancel()
+	name, err := b.backend.GetCurrentUser(ctx)
+	return name, b.explain(ctx, err)
+}
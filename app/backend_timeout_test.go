@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// stalledBackend is a dummy backend whose sprint queries never answer, whose downloads take
+// a while and whose sprint history sends three requests, slower the longer the sprint path
+type stalledBackend struct {
+	*DummyBackend
+}
+
+func (b *stalledBackend) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	time.Sleep(30 * time.Millisecond)
+	return []byte("large file"), ctx.Err()
+}
+
+func (b *stalledBackend) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	history := make(map[int][]WorkItemRevision)
+	for id := 1; id <= 3; id++ {
+		requestCtx, cancel := subRequest(ctx)
+		select {
+		case <-requestCtx.Done():
+		case <-time.After(20 * time.Millisecond * time.Duration(len(sprintPath)+1)):
+		}
+		err := requestCtx.Err()
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get revisions for #%d: %w", id, err)
+		}
+		history[id] = nil
+	}
+	return history, nil
+}
+
+func (b *stalledBackend) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTimeoutBackend(t *testing.T) {
+	client := withRequestTimeout(&stalledBackend{DummyBackend: NewDummyBackend()}, 10*time.Millisecond)
+
+	t.Run("slow call times out", func(t *testing.T) {
+		_, err := client.GetSprintWorkItemIDs(context.Background(), "")
+		var timeout *TimeoutError
+		if !errors.As(err, &timeout) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error = %v, want a TimeoutError wrapping the deadline", err)
+		}
+		if got, want := err.Error(), "the server did not respond within 10ms; check your connection and try again"; got != want {
+			t.Errorf("error text = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("cancelled call is not a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.GetSprintWorkItemIDs(ctx, "")
+		var timeout *TimeoutError
+		if errors.As(err, &timeout) || !isCancelled(err) {
+			t.Errorf("error = %v, want the cancellation unchanged", err)
+		}
+	})
+
+	t.Run("transfers are not bounded", func(t *testing.T) {
+		if content, err := client.DownloadAttachment(context.Background(), Attachment{}); err != nil || string(content) != "large file" {
+			t.Errorf("DownloadAttachment() = %q, %v; want the whole file", content, err)
+		}
+	})
+
+	t.Run("each request of a sprint history is bounded on its own", func(t *testing.T) {
+		client := withRequestTimeout(&stalledBackend{DummyBackend: NewDummyBackend()}, 30*time.Millisecond)
+
+		// Three requests take longer than the timeout together but not one by one
+		history, err := client.GetSprintWorkItemRevisions(context.Background(), "")
+		if err != nil || len(history) != 3 {
+			t.Fatalf("GetSprintWorkItemRevisions() = %v, %v; want every item", history, err)
+		}
+		var timeout *TimeoutError
+		if _, err := client.GetSprintWorkItemRevisions(context.Background(), "slow"); !errors.As(err, &timeout) {
+			t.Errorf("error = %v, want a TimeoutError for the slow request", err)
+		}
+	})
+
+	t.Run("fast call passes through", func(t *testing.T) {
+		ids, err := client.GetRecentBacklogItemIDs(context.Background())
+		if err != nil || len(ids) == 0 {
+			t.Errorf("GetRecentBacklogItemIDs() = %d items, error %v", len(ids), err)
+		}
+	})
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// batchRetryDelay is the wait before the first retry of a throttled or failed request;
+// it doubles with every further attempt
+var batchRetryDelay = 500 * time.Millisecond
+
+// batchJob is one operation applied to every item of a batch. Jobs with applyAll send up to
+// maxWorkItemsPerRequest items per request; the others send one request per item.
+type batchJob struct {
+	progress string // Shown while running, e.g. "Deleting"
+	done     string // Shown in the summary, e.g. "Deleted"
+	apply    func(ctx context.Context, client Backend, workItemID int) error
+	applyAll func(ctx context.Context, client Backend, workItemIDs []int) (map[int]error, error)
+}
+
+// batchFailure is an item the batch could not update and the reason
+type batchFailure struct {
+	workItemID int
+	err        error
+}
+
+// batchItemResult is the outcome of one item of a running batch
+type batchItemResult struct {
+	workItemID int
+	err        error
+}
+
+// deleteBatchJob deletes every item of the batch
+func deleteBatchJob() batchJob {
+	return batchJob{
+		progress: "Deleting",
+		done:     "Deleted",
+		apply: func(ctx context.Context, client Backend, workItemID int) error {
+			return client.DeleteWorkItem(ctx, workItemID)
+		},
+	}
+}
+
+// moveToSprintBatchJob moves every item of the batch to the sprint
+func moveToSprintBatchJob(iterationPath, sprintName string) batchJob {
+	return batchJob{
+		progress: "Moving to " + sprintName,
+		done:     "Moved to " + sprintName,
+		applyAll: func(ctx context.Context, client Backend, workItemIDs []int) (map[int]error, error) {
+			return client.UpdateWorkItems(ctx, workItemIDs, map[string]interface{}{"iterationPath": iterationPath})
+		},
+	}
+}
+
+// setStateBatchJob changes every item of the batch to the state, with the fields its work
+// item type requires. Items of each type are sent together with that type's field values.
+func setStateBatchJob(newState string, fieldValues map[string]map[string]string, itemTypes map[int]string) batchJob {
+	return batchJob{
+		progress: "Updating to " + newState,
+		done:     "Updated to " + newState,
+		applyAll: func(ctx context.Context, client Backend, workItemIDs []int) (map[int]error, error) {
+			var types []string
+			idsByType := make(map[string][]int)
+			for _, id := range workItemIDs {
+				workItemType := itemTypes[id]
+				if _, ok := idsByType[workItemType]; !ok {
+					types = append(types, workItemType)
+				}
+				idsByType[workItemType] = append(idsByType[workItemType], id)
+			}
+
+			failed := make(map[int]error)
+			for _, workItemType := range types {
+				ids := idsByType[workItemType]
+				errs, err := client.UpdateWorkItems(ctx, ids, stateUpdates(newState, fieldValues[workItemType]))
+				for _, id := range ids {
+					if err != nil {
+						failed[id] = err
+					} else if errs[id] != nil {
+						failed[id] = errs[id]
+					}
+				}
+			}
+			return failed, nil
+		},
+	}
+}
+
+// isRetryableError reports whether the server asked to slow down (429, or a spent GitHub rate
+// limit) or failed on its side (5xx)
+func isRetryableError(err error) bool {
+	var githubErr *GitHubError
+	if errors.As(err, &githubErr) {
+		return githubErr.RateLimited || githubErr.StatusCode >= http.StatusInternalServerError
+	}
+	wrapped, ok := asWrappedError(err)
+	if !ok || wrapped.StatusCode == nil {
+		return false
+	}
+	status := *wrapped.StatusCode
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryDelay is the backoff delay, or the wait the server asked for when that is longer
+func retryDelay(err error, delay time.Duration) time.Duration {
+	var githubErr *GitHubError
+	if errors.As(err, &githubErr) && githubErr.RetryAfter > delay {
+		return githubErr.RetryAfter
+	}
+	return delay
+}
+
+// retryWait waits out the backoff delay before a retry. Jitter keeps the workers from
+// retrying in lockstep. It returns early with the error when ctx is cancelled.
+func retryWait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay + time.Duration(rand.Int63n(int64(delay)/2+1)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// applyWithRetry applies the job to one item, retrying throttled and server errors with
+// exponential backoff
+func applyWithRetry(ctx context.Context, client Backend, job batchJob, workItemID int) error {
+	delay := batchRetryDelay
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := job.apply(ctx, client, workItemID)
+		if err == nil || attempt == batchMaxAttempts || !isRetryableError(err) {
+			return err
+		}
+		if waitErr := retryWait(ctx, retryDelay(err, delay)); waitErr != nil {
+			return waitErr
+		}
+		delay *= 2
+	}
+}
+
+// applyAllWithRetry applies the job to a group of items in one request, resending the items
+// that were throttled or hit a server error with exponential backoff
+func applyAllWithRetry(ctx context.Context, client Backend, job batchJob, workItemIDs []int) map[int]error {
+	errs := make(map[int]error, len(workItemIDs))
+	pending := workItemIDs
+	delay := batchRetryDelay
+	for attempt := 1; len(pending) > 0; attempt++ {
+		var failed map[int]error
+		err := ctx.Err()
+		if err == nil {
+			failed, err = job.applyAll(ctx, client, pending)
+		}
+
+		var retry []int
+		wait := delay
+		for _, id := range pending {
+			itemErr := err
+			if itemErr == nil {
+				itemErr = failed[id]
+			}
+			if itemErr != nil && attempt < batchMaxAttempts && isRetryableError(itemErr) && ctx.Err() == nil {
+				retry = append(retry, id)
+				wait = retryDelay(itemErr, wait)
+				continue
+			}
+			errs[id] = itemErr
+		}
+
+		pending = retry
+		if len(pending) > 0 {
+			if waitErr := retryWait(ctx, wait); waitErr != nil {
+				for _, id := range pending {
+					errs[id] = waitErr
+				}
+				break
+			}
+			delay *= 2
+		}
+	}
+	return errs
+}
+
+// batchGroups splits the items into the groups the job sends together
+func batchGroups(job batchJob, workItemIDs []int) [][]int {
+	size := 1
+	if job.applyAll != nil {
+		size = maxWorkItemsPerRequest
+	}
+	var groups [][]int
+	for start := 0; start < len(workItemIDs); start += size {
+		groups = append(groups, workItemIDs[start:min(start+size, len(workItemIDs))])
+	}
+	return groups
+}
+
+// runBatch applies the job to the items with at most batchWorkers requests in flight,
+// reporting each item as it finishes. Once ctx is cancelled the remaining items are
+// reported as cancelled without being sent.
+func runBatch(ctx context.Context, client Backend, job batchJob, workItemIDs []int) tea.Cmd {
+	return func() tea.Msg {
+		groups := batchGroups(job, workItemIDs)
+		results := make(chan batchItemResult, len(workItemIDs))
+		queue := make(chan []int)
+
+		var wg sync.WaitGroup
+		for i := 0; i < min(batchWorkers, len(groups)); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for group := range queue {
+					if job.applyAll == nil {
+						results <- batchItemResult{workItemID: group[0], err: applyWithRetry(ctx, client, job, group[0])}
+						continue
+					}
+					errs := applyAllWithRetry(ctx, client, job, group)
+					for _, id := range group {
+						results <- batchItemResult{workItemID: id, err: errs[id]}
+					}
+				}
+			}()
+		}
+		go func() {
+			for _, group := range groups {
+				queue <- group
+			}
+			close(queue)
+			wg.Wait()
+			close(results)
+		}()
+
+		return waitForBatchResult(results)()
+	}
+}
+
+// waitForBatchResult waits for the next item of a running batch to finish
+func waitForBatchResult(results <-chan batchItemResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-results
+		if !ok {
+			return batchFinishedMsg{}
+		}
+		return batchProgressMsg{result: result, results: results}
+	}
+}
+
+// batchErrorText describes why an item failed, showing the broken process rule
+// rather than the wrapped server error
+func batchErrorText(err error) string {
+	var violation *RuleViolationError
+	if errors.As(err, &violation) {
+		return violation.Error()
+	}
+	if isCancelled(err) {
+		return "cancelled"
+	}
+	return err.Error()
+}
+
+// batchProgressBar renders a bar of the given width filled to done out of total
+func batchProgressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(width, done*width/total)
+	}
+	bar := make([]rune, width)
+	for i := range bar {
+		bar[i] = '░'
+		if i < filled {
+			bar[i] = '█'
+		}
+	}
+	return fmt.Sprintf("[%s] %d/%d", string(bar), done, total)
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+// serverError builds the error the Azure DevOps SDK returns for an HTTP status
+func serverError(status int) error {
+	message := fmt.Sprintf("Request returned status: %d", status)
+	return &azuredevops.WrappedError{Message: &message, StatusCode: &status}
+}
+
+// withoutRetryDelay makes retries immediate for the duration of the test
+func withoutRetryDelay(t *testing.T) {
+	t.Helper()
+	saved := batchRetryDelay
+	batchRetryDelay = 0
+	t.Cleanup(func() { batchRetryDelay = saved })
+}
+
+// drainBatch feeds the messages of a running batch to the model until it finishes
+func drainBatch(t *testing.T, m model, cmd tea.Cmd) model {
+	t.Helper()
+	for msg := cmd(); ; {
+		switch msg := msg.(type) {
+		case batchProgressMsg:
+			m, cmd = m.handleBatchProgressMsg(msg)
+		case batchFinishedMsg:
+			m, _ = m.handleBatchFinishedMsg(msg)
+			return m
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+		msg = cmd()
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"throttled", serverError(429), true},
+		{"server error", serverError(503), true},
+		{"wrapped server error", fmt.Errorf("failed to delete work item: %w", serverError(500)), true},
+		{"server error by value", azuredevops.WrappedError{StatusCode: func() *int { s := 502; return &s }()}, true},
+		{"github rate limit", fmt.Errorf("failed to update issue #3: %w", &GitHubError{StatusCode: 403, RateLimited: true}), true},
+		{"github server error", &GitHubError{StatusCode: 502}, true},
+		{"github validation failed", &GitHubError{StatusCode: 422}, false},
+		{"not found", serverError(404), false},
+		{"rule violation", serverError(400), false},
+		{"plain error", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableError(tt.err); got != tt.want {
+				t.Errorf("isRetryableError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"backoff", serverError(429), time.Second},
+		{"longer Retry-After", fmt.Errorf("failed to update issue #3: %w", &GitHubError{StatusCode: 429, RateLimited: true, RetryAfter: time.Minute}), time.Minute},
+		{"shorter Retry-After", &GitHubError{StatusCode: 429, RateLimited: true, RetryAfter: time.Millisecond}, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.err, time.Second); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyWithRetry(t *testing.T) {
+	withoutRetryDelay(t)
+
+	tests := []struct {
+		name         string
+		errs         []error // Returned by successive attempts; nil afterwards
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"succeeds first time", nil, 1, false},
+		{"retries throttling until it succeeds", []error{serverError(429), serverError(503)}, 3, false},
+		{"gives up after max attempts", []error{serverError(429), serverError(429), serverError(429), serverError(429), serverError(429)}, batchMaxAttempts, true},
+		{"does not retry client errors", []error{serverError(400)}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			job := batchJob{apply: func(ctx context.Context, client Backend, workItemID int) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			}}
+
+			err := applyWithRetry(context.Background(), nil, job, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRunBatchLimitsWorkers(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	job := batchJob{apply: func(ctx context.Context, client Backend, workItemID int) error {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if workItemID%5 == 0 {
+			return errors.New("gone")
+		}
+		return nil
+	}}
+
+	var ids []int
+	for id := 1; id <= 20; id++ {
+		ids = append(ids, id)
+	}
+	m := model{batch: BatchState{running: true, total: len(ids), job: job}}
+	m = drainBatch(t, m, runBatch(context.Background(), nil, job, ids))
+
+	if maxInFlight > batchWorkers {
+		t.Errorf("%d items ran at once, want at most %d", maxInFlight, batchWorkers)
+	}
+	if m.batch.done != len(ids) {
+		t.Errorf("done = %d, want %d", m.batch.done, len(ids))
+	}
+	var failed []int
+	for _, failure := range m.batch.failures {
+		failed = append(failed, failure.workItemID)
+	}
+	if want := []int{5, 10, 15, 20}; !slices.Equal(failed, want) {
+		t.Errorf("failed IDs = %v, want %v", failed, want)
+	}
+	if m.state != batchSummaryView {
+		t.Errorf("state = %v, want the batch summary", m.state)
+	}
+}
+
+func TestRunBatchStopsWhenCancelled(t *testing.T) {
+	var mu sync.Mutex
+	sent := 0
+	job := batchJob{}
+	m := model{}
+	ctx := m.work.context()
+	job.apply = func(ctx context.Context, client Backend, workItemID int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent++
+		// esc is pressed while the first items are in flight
+		m.cancelWork()
+		return nil
+	}
+
+	var ids []int
+	for id := 1; id <= 20; id++ {
+		ids = append(ids, id)
+	}
+	m.batch = BatchState{running: true, total: len(ids), job: job}
+	m = drainBatch(t, m, runBatch(ctx, nil, job, ids))
+
+	if sent > batchWorkers {
+		t.Errorf("%d items were sent after the cancellation, want at most the %d in flight", sent, batchWorkers)
+	}
+	if got := len(m.batch.failures); got != len(ids)-sent {
+		t.Fatalf("%d items reported as failed, want the %d not sent", got, len(ids)-sent)
+	}
+	if got := batchErrorText(m.batch.failures[0].err); got != "cancelled" {
+		t.Errorf("failure reason = %q, want cancelled", got)
+	}
+}
+
+func TestBatchSummaryRetriesOnlyFailures(t *testing.T) {
+	db := NewDummyBackend()
+	ids, _ := db.GetSprintWorkItemIDs(context.Background(), "")
+	if len(ids) < 4 {
+		t.Fatalf("expected at least 4 items, got %d", len(ids))
+	}
+	ids = ids[:4]
+
+	// The second and fourth items fail on the first run only
+	var mu sync.Mutex
+	var attempted []int
+	flaky := map[int]bool{ids[1]: true, ids[3]: true}
+	job := deleteBatchJob()
+	deleteItem := job.apply
+	job.apply = func(ctx context.Context, client Backend, workItemID int) error {
+		mu.Lock()
+		attempted = append(attempted, workItemID)
+		fail := flaky[workItemID]
+		delete(flaky, workItemID)
+		mu.Unlock()
+		if fail {
+			return fmt.Errorf("failed to delete work item: %w", serverError(409))
+		}
+		return deleteItem(ctx, client, workItemID)
+	}
+
+	m := model{
+		client:      db,
+		currentMode: sprintMode,
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		batch:       BatchState{selectedItems: map[int]WorkItem{ids[0]: {ID: ids[0]}, ids[1]: {ID: ids[1]}, ids[2]: {ID: ids[2]}, ids[3]: {ID: ids[3]}}},
+	}
+	m, _ = m.startBatch(job, m.selectedIDs())
+	if !m.batch.running || len(m.batch.selectedItems) != 0 {
+		t.Fatal("expected the batch to start and clear the selection")
+	}
+	m = drainBatch(t, m, runBatch(context.Background(), db, m.batch.job, ids))
+
+	if m.state != batchSummaryView || len(m.batch.failures) != 2 {
+		t.Fatalf("state = %v with %d failures, want the summary with 2", m.state, len(m.batch.failures))
+	}
+	if got := m.batch.failures[0].workItemID; got != ids[1] {
+		t.Errorf("first failure = #%d, want #%d", got, ids[1])
+	}
+
+	attempted = nil
+	m, cmd := m.handleBatchSummaryView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil || m.batch.total != 2 {
+		t.Fatalf("retry started %d items, want 2", m.batch.total)
+	}
+	m = drainBatch(t, m, runBatch(context.Background(), db, m.batch.job, []int{ids[1], ids[3]}))
+
+	slices.Sort(attempted)
+	if want := []int{ids[1], ids[3]}; !slices.Equal(attempted, want) {
+		t.Errorf("retry attempted %v, want only %v", attempted, want)
+	}
+	if len(m.batch.failures) != 0 || m.state != listView || !m.loading {
+		t.Errorf("expected the retry to succeed and refresh the list, state = %v, failures = %d", m.state, len(m.batch.failures))
+	}
+	if remaining, _ := db.GetWorkItemsByIDs(context.Background(), ids); len(remaining) != 0 {
+		t.Errorf("%d items were not deleted", len(remaining))
+	}
+}
+
+func TestBatchProgressBar(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{0, 4, "[░░░░░░░░] 0/4"},
+		{1, 4, "[██░░░░░░] 1/4"},
+		{4, 4, "[████████] 4/4"},
+		{0, 0, "[░░░░░░░░] 0/0"},
+	}
+
+	for _, tt := range tests {
+		if got := batchProgressBar(tt.done, tt.total, 8); got != tt.want {
+			t.Errorf("batchProgressBar(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+// countingBackend records the UpdateWorkItems requests sent to the dummy backend and
+// throttles the items of throttled once
+type countingBackend struct {
+	*DummyBackend
+	mu        sync.Mutex
+	requests  [][]int
+	throttled map[int]bool
+}
+
+func (b *countingBackend) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	b.mu.Lock()
+	b.requests = append(b.requests, slices.Clone(workItemIDs))
+	var retry []int
+	for _, id := range workItemIDs {
+		if b.throttled[id] {
+			delete(b.throttled, id)
+			retry = append(retry, id)
+		}
+	}
+	b.mu.Unlock()
+
+	failed, err := b.DummyBackend.UpdateWorkItems(ctx, slices.DeleteFunc(slices.Clone(workItemIDs), func(id int) bool {
+		return slices.Contains(retry, id)
+	}), updates)
+	for _, id := range retry {
+		failed[id] = serverError(429)
+	}
+	return failed, err
+}
+
+func TestBatchUpdatesSendOneRequestPerGroup(t *testing.T) {
+	withoutRetryDelay(t)
+
+	db := NewDummyBackend()
+	_, _, next, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	ids, _ := db.GetSprintWorkItemIDs(context.Background(), "")
+	if len(ids) < 3 {
+		t.Fatalf("expected at least 3 items, got %d", len(ids))
+	}
+	client := &countingBackend{DummyBackend: db, throttled: map[int]bool{ids[1]: true}}
+
+	job := moveToSprintBatchJob(next.Path, next.Name)
+	m := model{client: client, batch: BatchState{running: true, total: len(ids), job: job}}
+	m = drainBatch(t, m, runBatch(context.Background(), client, job, ids))
+
+	if len(m.batch.failures) != 0 || m.batch.done != len(ids) {
+		t.Fatalf("done = %d with failures %v, want every item moved", m.batch.done, m.batch.failures)
+	}
+	if len(client.requests) != 2 || len(client.requests[0]) != len(ids) {
+		t.Errorf("requests = %v, want all items in one request and a retry of the throttled one", client.requests)
+	}
+	if len(client.requests) == 2 && !slices.Equal(client.requests[1], []int{ids[1]}) {
+		t.Errorf("retry = %v, want only the throttled item %d", client.requests[1], ids[1])
+	}
+	for _, item := range mustGetWorkItems(t, db, ids) {
+		if item.IterationPath != next.Path {
+			t.Errorf("item %d is in %q, want %q", item.ID, item.IterationPath, next.Path)
+		}
+	}
+}
+
+func TestStateBatchReportsRuleViolationsPerItem(t *testing.T) {
+	db := NewDummyBackend()
+	ids, _ := db.GetSprintWorkItemIDs(context.Background(), "")
+	ids = ids[:2]
+
+	// Removing without a reason breaks the demo process rules for every item
+	job := setStateBatchJob("Removed", nil, nil)
+	m := model{client: db, batch: BatchState{running: true, total: len(ids), job: job}}
+	m = drainBatch(t, m, runBatch(context.Background(), db, job, ids))
+
+	if len(m.batch.failures) != len(ids) {
+		t.Fatalf("failures = %v, want one per item", m.batch.failures)
+	}
+	if got := batchErrorText(m.batch.failures[0].err); got != "rule violation: Reason is required" {
+		t.Errorf("failure text = %q, want the broken rule", got)
+	}
+
+	// With the reason the same batch goes through
+	itemTypes := make(map[int]string)
+	for _, item := range mustGetWorkItems(t, db, ids) {
+		itemTypes[item.ID] = item.WorkItemType
+	}
+	job = setStateBatchJob("Removed", typeFieldValues(map[string]string{"System.Reason": "Obsolete"},
+		map[string][]string{"System.Reason": {"Task", "User Story", "Bug"}}), itemTypes)
+	m = model{client: db, batch: BatchState{running: true, total: len(ids), job: job}}
+	m = drainBatch(t, m, runBatch(context.Background(), db, job, ids))
+	if len(m.batch.failures) != 0 {
+		t.Errorf("failures = %v, want none", m.batch.failures)
+	}
+}
+
+// mustGetWorkItems returns the complete work items with the given IDs
+func mustGetWorkItems(t *testing.T, client Backend, ids []int) []WorkItem {
+	t.Helper()
+	items, err := client.GetWorkItemDetailsByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetWorkItemDetailsByIDs() error: %v", err)
+	}
+	return items
+}
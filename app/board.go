@@ -0,0 +1,116 @@
+package main
+
+import (
+	"sort"
+)
+
+// stateCategoryOrder is the left-to-right order of state categories on the board
+var stateCategoryOrder = []string{"Proposed", "InProgress", "Resolved", "Completed", "Removed"}
+
+// boardColumn is a single state column on the board
+type boardColumn struct {
+	state    string
+	category string
+	cards    []WorkItem
+}
+
+// categoryRank returns the position of a category in stateCategoryOrder (unknown categories sort before Completed)
+func categoryRank(category string) int {
+	for i, c := range stateCategoryOrder {
+		if c == category {
+			return i
+		}
+	}
+	return 2
+}
+
+// buildBoardColumns groups work items into one column per state.
+// Columns are ordered by state category, then by the order the states are defined on their work item types.
+// Removed states only get a column when an item is in them.
+func buildBoardColumns(tasks []WorkItem, typeStates map[string][]string, categories map[string]string) []boardColumn {
+	// Collect states in definition order across types, so e.g. New comes before Approved
+	position := make(map[string]int)
+	var states []string
+	addState := func(state string) {
+		if _, ok := position[state]; !ok {
+			position[state] = len(states)
+			states = append(states, state)
+		}
+	}
+
+	types := make([]string, 0, len(typeStates))
+	for workItemType := range typeStates {
+		types = append(types, workItemType)
+	}
+	sort.Strings(types)
+	for _, workItemType := range types {
+		for _, state := range typeStates[workItemType] {
+			if categories[state] != "Removed" {
+				addState(state)
+			}
+		}
+	}
+	for _, task := range tasks {
+		addState(task.State)
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		ri, rj := categoryRank(categories[states[i]]), categoryRank(categories[states[j]])
+		if ri != rj {
+			return ri < rj
+		}
+		return position[states[i]] < position[states[j]]
+	})
+
+	columns := make([]boardColumn, len(states))
+	index := make(map[string]int, len(states))
+	for i, state := range states {
+		columns[i] = boardColumn{state: state, category: categories[state]}
+		index[state] = i
+	}
+	for _, task := range tasks {
+		col := &columns[index[task.State]]
+		col.cards = append(col.cards, task)
+	}
+
+	// Highest priority first; items without a priority go last
+	for i := range columns {
+		cards := columns[i].cards
+		sort.SliceStable(cards, func(a, b int) bool {
+			pa, pb := cards[a].Priority, cards[b].Priority
+			if pa == 0 || pb == 0 {
+				return pa != 0 && pb == 0
+			}
+			return pa < pb
+		})
+	}
+
+	return columns
+}
+
+// adjacentBoardState returns the nearest state left (direction -1) or right (direction 1) of the
+// item's current column that is valid for its work item type, or "" if there is none
+func adjacentBoardState(columns []boardColumn, item WorkItem, direction int, typeStates map[string][]string) string {
+	current := -1
+	for i, col := range columns {
+		if col.state == item.State {
+			current = i
+			break
+		}
+	}
+	if current == -1 {
+		return ""
+	}
+
+	valid := make(map[string]bool)
+	for _, state := range typeStates[item.WorkItemType] {
+		valid[state] = true
+	}
+
+	for i := current + direction; i >= 0 && i < len(columns); i += direction {
+		if valid[columns[i].state] {
+			return columns[i].state
+		}
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// boardTestCategories are the state categories used in board tests
+var boardTestCategories = map[string]string{
+	"New":      "Proposed",
+	"Approved": "Proposed",
+	"Active":   "InProgress",
+	"Resolved": "Resolved",
+	"Closed":   "Completed",
+	"Removed":  "Removed",
+}
+
+// boardTestTypeStates are the valid states per work item type used in board tests
+var boardTestTypeStates = map[string][]string{
+	"Task": {"New", "Active", "Closed", "Removed"},
+	"Bug":  {"New", "Approved", "Active", "Resolved", "Closed"},
+}
+
+func createBoardTestItem(id int, workItemType, state string, priority int) WorkItem {
+	item := createTestWorkItemWithState(id, "Item", state)
+	item.WorkItemType = workItemType
+	item.Priority = priority
+	return item
+}
+
+func TestBuildBoardColumns_Order(t *testing.T) {
+	tasks := []WorkItem{
+		createBoardTestItem(1, "Task", "Active", 2),
+		createBoardTestItem(2, "Bug", "New", 1),
+	}
+
+	columns := buildBoardColumns(tasks, boardTestTypeStates, boardTestCategories)
+
+	var states []string
+	for _, col := range columns {
+		states = append(states, col.state)
+	}
+	// Categories left to right; Removed is hidden because no item is in it
+	expected := []string{"New", "Approved", "Active", "Resolved", "Closed"}
+	if strings.Join(states, ",") != strings.Join(expected, ",") {
+		t.Errorf("columns = %v, want %v", states, expected)
+	}
+}
+
+func TestBuildBoardColumns_Cards(t *testing.T) {
+	tasks := []WorkItem{
+		createBoardTestItem(1, "Task", "Active", 0),
+		createBoardTestItem(2, "Task", "Active", 3),
+		createBoardTestItem(3, "Task", "Active", 1),
+		createBoardTestItem(4, "Task", "Removed", 2),
+	}
+
+	columns := buildBoardColumns(tasks, boardTestTypeStates, boardTestCategories)
+
+	var active, removed *boardColumn
+	for i := range columns {
+		switch columns[i].state {
+		case "Active":
+			active = &columns[i]
+		case "Removed":
+			removed = &columns[i]
+		}
+	}
+	if active == nil || removed == nil {
+		t.Fatalf("expected Active and Removed columns, got %+v", columns)
+	}
+
+	// Highest priority first, unset priority last
+	var ids []int
+	for _, card := range active.cards {
+		ids = append(ids, card.ID)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 2 || ids[2] != 1 {
+		t.Errorf("active cards = %v, want [3 2 1]", ids)
+	}
+	if len(removed.cards) != 1 {
+		t.Errorf("expected removed item on the board, got %d cards", len(removed.cards))
+	}
+}
+
+func TestAdjacentBoardState(t *testing.T) {
+	tasks := []WorkItem{createBoardTestItem(2, "Bug", "New", 1)}
+	columns := buildBoardColumns(tasks, boardTestTypeStates, boardTestCategories)
+
+	tests := []struct {
+		name      string
+		item      WorkItem
+		direction int
+		expected  string
+	}{
+		{"bug moves right to next bug state", createBoardTestItem(2, "Bug", "New", 1), 1, "Approved"},
+		{"task skips bug-only states", createBoardTestItem(1, "Task", "New", 1), 1, "Active"},
+		{"task skips resolved to closed", createBoardTestItem(1, "Task", "Active", 1), 1, "Closed"},
+		{"nothing left of the first column", createBoardTestItem(1, "Task", "New", 1), -1, ""},
+		{"nothing right of the last column", createBoardTestItem(1, "Task", "Closed", 1), 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adjacentBoardState(columns, tt.item, tt.direction, boardTestTypeStates); got != tt.expected {
+				t.Errorf("adjacentBoardState() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// createBoardTestModel creates a model in board view with the given tasks in the current sprint
+func createBoardTestModel(tasks []WorkItem) model {
+	return model{
+		state:           boardView,
+		currentMode:     sprintMode,
+		currentTab:      currentSprint,
+		sprints:         make(map[sprintTab]*Sprint),
+		sprintLists:     map[sprintTab]*WorkItemList{currentSprint: {tasks: tasks, loaded: len(tasks), totalCount: len(tasks)}},
+		stateCategories: boardTestCategories,
+		board:           BoardState{typeStates: boardTestTypeStates},
+		batch:           BatchState{selectedItems: make(map[int]WorkItem)},
+		client:          NewDummyBackend(),
+		ui:              UIState{width: 120, height: 40},
+	}
+}
+
+func TestHandleBoardView_MoveCard(t *testing.T) {
+	m := createBoardTestModel([]WorkItem{createBoardTestItem(1, "Task", "New", 1)})
+
+	m, cmd := m.handleBoardView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if cmd == nil || !m.loading {
+		t.Fatal("expected a state update command")
+	}
+
+	m, _ = m.handleBoardCardMovedMsg(boardCardMovedMsg{workItemID: 1, oldState: "New", newState: "Active"})
+	if m.loading {
+		t.Error("expected loading to be cleared")
+	}
+	if got := m.getCurrentList().tasks[0].State; got != "Active" {
+		t.Errorf("expected item state Active, got %q", got)
+	}
+	columns := m.getBoardColumns()
+	if columns[m.board.column].state != "Active" {
+		t.Errorf("expected cursor to follow the card to Active, got %q", columns[m.board.column].state)
+	}
+}
+
+func TestHandleBoardCardMovedMsg_Error(t *testing.T) {
+	m := createBoardTestModel([]WorkItem{createBoardTestItem(1, "Task", "New", 1)})
+	m.loading = true
+
+	m, _ = m.handleBoardCardMovedMsg(boardCardMovedMsg{workItemID: 1, oldState: "New", newState: "Active", err: errors.New("rule violation")})
+
+	if m.loading {
+		t.Error("expected loading to be cleared")
+	}
+	if !strings.Contains(m.statusMessage, "rule violation") {
+		t.Errorf("expected error in status message, got %q", m.statusMessage)
+	}
+	if got := m.getCurrentList().tasks[0].State; got != "New" {
+		t.Errorf("expected item state to stay New, got %q", got)
+	}
+}
+
+func TestHandleBoardView_DetailReturnsToBoard(t *testing.T) {
+	m := createBoardTestModel([]WorkItem{createBoardTestItem(1, "Task", "New", 1)})
+
+	m, _ = m.handleBoardView(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != detailView || m.selectedTask == nil || m.selectedTask.ID != 1 {
+		t.Fatalf("expected detail view for #1, got state %v", m.state)
+	}
+
+	m, _ = m.handleDetailViewNav(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != boardView {
+		t.Errorf("expected to return to board view, got %v", m.state)
+	}
+}
+
+func TestRenderBoardView(t *testing.T) {
+	m := createBoardTestModel([]WorkItem{
+		createBoardTestItem(1234, "Task", "New", 2),
+		createBoardTestItem(5678, "Bug", "Active", 1),
+	})
+
+	output := m.renderBoardView()
+	for _, want := range []string{"New (1)", "Active (1)", "#1234", "P2", "#5678"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected board to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// burndownMetric selects which series the burndown chart plots
+type burndownMetric int
+
+const (
+	remainingItemsMetric burndownMetric = iota
+	remainingWorkMetric
+	completedItemsMetric
+)
+
+// burndownMetricCount is the number of metrics the chart can cycle through
+const burndownMetricCount = 3
+
+// String returns the display name of the metric
+func (bm burndownMetric) String() string {
+	switch bm {
+	case remainingItemsMetric:
+		return "Remaining Items"
+	case remainingWorkMetric:
+		return "Remaining Work"
+	case completedItemsMetric:
+		return "Completed Items"
+	default:
+		return ""
+	}
+}
+
+// isBurnup returns true if the metric grows over the sprint instead of shrinking
+func (bm burndownMetric) isBurnup() bool {
+	return bm == completedItemsMetric
+}
+
+// burndownPoint is the value of the chart at the end of a single sprint day
+type burndownPoint struct {
+	Date  time.Time
+	Value float64 // Plotted value for the selected metric
+	Scope float64 // Total items (or work) in the sprint on that day
+}
+
+// burndownBlocks are the partial block characters used for the top of each bar (eighths)
+var burndownBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// parseSprintDate parses a sprint start/end date (format: 2006-01-02)
+func parseSprintDate(dateStr string) (time.Time, error) {
+	return time.Parse("2006-01-02", dateStr)
+}
+
+// parseRevisionDate parses a revision changed date (format: 2006-01-02T15:04:05)
+func parseRevisionDate(dateStr string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02T15:04:05", dateStr)
+	if err != nil && len(dateStr) >= 10 {
+		return time.Parse("2006-01-02", dateStr[:10])
+	}
+	return t, err
+}
+
+// sprintDayCount returns the number of days in a sprint, inclusive of start and end
+func sprintDayCount(sprint *Sprint) int {
+	if sprint == nil {
+		return 0
+	}
+	start, err := parseSprintDate(sprint.StartDate)
+	if err != nil {
+		return 0
+	}
+	end, err := parseSprintDate(sprint.EndDate)
+	if err != nil || end.Before(start) {
+		return 0
+	}
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// revisionAt returns the latest revision changed before the cutoff, or nil if the item didn't exist yet.
+// Revisions must be sorted by revision number.
+func revisionAt(revisions []WorkItemRevision, cutoff time.Time) *WorkItemRevision {
+	var latest *WorkItemRevision
+	for i := range revisions {
+		changed, err := parseRevisionDate(revisions[i].ChangedDate)
+		if err != nil || !changed.Before(cutoff) {
+			continue
+		}
+		latest = &revisions[i]
+	}
+	return latest
+}
+
+// buildBurndownSeries replays the revision history of the sprint's work items and returns
+// one point per sprint day up to today. Items only count on the days their iteration path
+// was the sprint, so scope changes show up in the chart; removed items leave the scope.
+func buildBurndownSeries(sprint *Sprint, history map[int][]WorkItemRevision, metric burndownMetric, categoryOf func(string) string, now time.Time) ([]burndownPoint, error) {
+	if sprint == nil {
+		return nil, fmt.Errorf("no sprint selected")
+	}
+	start, err := parseSprintDate(sprint.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("sprint %s has no valid start date", sprint.Name)
+	}
+	end, err := parseSprintDate(sprint.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("sprint %s has no valid end date", sprint.Name)
+	}
+
+	// Sort each history by revision number so revisionAt can pick the latest one
+	sorted := make(map[int][]WorkItemRevision, len(history))
+	for id, revisions := range history {
+		revs := append([]WorkItemRevision{}, revisions...)
+		sort.Slice(revs, func(i, j int) bool { return revs[i].Rev < revs[j].Rev })
+		sorted[id] = revs
+	}
+
+	today, _ := parseSprintDate(now.Format("2006-01-02"))
+
+	var points []burndownPoint
+	for day := start; !day.After(end) && !day.After(today); day = day.AddDate(0, 0, 1) {
+		cutoff := day.AddDate(0, 0, 1) // End of day
+		point := burndownPoint{Date: day}
+
+		for _, revisions := range sorted {
+			rev := revisionAt(revisions, cutoff)
+			if rev == nil || rev.IterationPath != sprint.Path {
+				continue
+			}
+
+			category := categoryOf(rev.State)
+			if category == "Removed" {
+				continue
+			}
+			done := category == "Completed"
+
+			switch metric {
+			case remainingItemsMetric:
+				point.Scope++
+				if !done {
+					point.Value++
+				}
+			case remainingWorkMetric:
+				point.Scope += rev.RemainingWork
+				if !done {
+					point.Value += rev.RemainingWork
+				}
+			case completedItemsMetric:
+				point.Scope++
+				if done {
+					point.Value++
+				}
+			}
+		}
+
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// formatChartValue formats a chart value without trailing decimals for whole numbers
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%d", int(v))
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// renderBurndownChart draws the series as a block-character bar chart with one bar per day.
+// Burndown metrics also draw the ideal line (·) from the first day's value down to zero.
+// The returned chart is exactly height+2 lines (plot + axis + date labels) and at most width columns.
+func renderBurndownChart(points []burndownPoint, totalDays int, metric burndownMetric, width, height int) string {
+	if height < 1 {
+		height = 1
+	}
+	if totalDays < len(points) {
+		totalDays = len(points)
+	}
+	if totalDays < 1 {
+		totalDays = 1
+	}
+
+	// Scale to the largest value or scope so the burnup target stays visible
+	maxValue := 0.0
+	for _, p := range points {
+		maxValue = math.Max(maxValue, math.Max(p.Value, p.Scope))
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	// Y-axis labels at the top, middle and bottom rows
+	topLabel := formatChartValue(maxValue)
+	midRow := height / 2
+	midLabel := formatChartValue(maxValue * float64(midRow+1) / float64(height))
+	labelWidth := max(len(topLabel), len(midLabel))
+	plotWidth := width - labelWidth - 1 // Y-axis line takes one column
+	if plotWidth < 1 {
+		plotWidth = 1
+	}
+
+	colWidth := plotWidth / totalDays
+	if colWidth < 1 {
+		colWidth = 1
+	}
+	visibleDays := min(totalDays, plotWidth/colWidth)
+	barWidth := colWidth
+	if colWidth >= 3 {
+		barWidth = colWidth - 1 // Leave a gap between bars when there's room
+	}
+
+	// Ideal line runs from the first day's value to zero on the last day
+	ideal := func(day int) float64 {
+		if len(points) == 0 || metric.isBurnup() {
+			return -1
+		}
+		if totalDays == 1 {
+			return points[0].Value
+		}
+		return points[0].Value * (1 - float64(day)/float64(totalDays-1))
+	}
+
+	var chart strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case height - 1:
+			label = topLabel
+		case midRow:
+			if height > 2 {
+				label = midLabel
+			}
+		case 0:
+			label = "0"
+		}
+		chart.WriteString(fmt.Sprintf("%*s│", labelWidth, label))
+
+		for day := 0; day < visibleDays; day++ {
+			cell := " "
+			if day < len(points) {
+				level := points[day].Value / maxValue * float64(height)
+				if level >= float64(row+1) {
+					cell = burndownBlocks[8]
+				} else if level > float64(row) {
+					cell = burndownBlocks[int((level-float64(row))*8)]
+				}
+			}
+
+			if cell == " " {
+				if idealLevel := ideal(day) / maxValue * float64(height); idealLevel >= 0 && int(idealLevel) == row {
+					cell = "·"
+				}
+			}
+
+			chart.WriteString(strings.Repeat(cell, barWidth))
+			chart.WriteString(strings.Repeat(" ", colWidth-barWidth))
+		}
+		chart.WriteString("\n")
+	}
+
+	// X-axis with the first and last sprint dates
+	chart.WriteString(strings.Repeat(" ", labelWidth) + "└" + strings.Repeat("─", visibleDays*colWidth) + "\n")
+	if len(points) > 0 {
+		startLabel := points[0].Date.Format("Jan 2")
+		endLabel := points[0].Date.AddDate(0, 0, totalDays-1).Format("Jan 2")
+		gap := visibleDays*colWidth - len(startLabel) - len(endLabel)
+		if gap < 1 {
+			// Not enough room for both labels
+			chart.WriteString(strings.Repeat(" ", labelWidth+1) + startLabel)
+		} else {
+			chart.WriteString(strings.Repeat(" ", labelWidth+1) + startLabel + strings.Repeat(" ", gap) + endLabel)
+		}
+	}
+
+	return chart.String()
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testCategoryOf maps the sample states used in burndown tests to their categories
+func testCategoryOf(state string) string {
+	switch state {
+	case "New":
+		return "Proposed"
+	case "Closed":
+		return "Completed"
+	case "Removed":
+		return "Removed"
+	default:
+		return "InProgress"
+	}
+}
+
+func TestSprintDayCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		sprint   *Sprint
+		expected int
+	}{
+		{"nil sprint", nil, 0},
+		{"two weeks", createTestSprint("Sprint 1", "P\\Sprint 1", "2024-01-01", "2024-01-14"), 14},
+		{"single day", createTestSprint("Sprint 1", "P\\Sprint 1", "2024-01-01", "2024-01-01"), 1},
+		{"end before start", createTestSprint("Sprint 1", "P\\Sprint 1", "2024-01-10", "2024-01-01"), 0},
+		{"missing dates", createTestSprint("Sprint 1", "P\\Sprint 1", "", ""), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sprintDayCount(tt.sprint); got != tt.expected {
+				t.Errorf("sprintDayCount() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildBurndownSeries(t *testing.T) {
+	sprint := createTestSprint("Sprint 1", "P\\Sprint 1", "2024-01-01", "2024-01-05")
+	history := map[int][]WorkItemRevision{
+		// In the sprint from day one, closed on day 3
+		1: {
+			{Rev: 1, ChangedDate: "2023-12-28T10:00:00", State: "New", IterationPath: "P\\Sprint 1", RemainingWork: 8},
+			{Rev: 2, ChangedDate: "2024-01-02T09:00:00", State: "Active", IterationPath: "P\\Sprint 1", RemainingWork: 5},
+			{Rev: 3, ChangedDate: "2024-01-03T16:00:00", State: "Closed", IterationPath: "P\\Sprint 1", RemainingWork: 0},
+		},
+		// Added to the sprint on day 2 (scope increase)
+		2: {
+			{Rev: 1, ChangedDate: "2023-12-20T10:00:00", State: "New", IterationPath: "P", RemainingWork: 4},
+			{Rev: 2, ChangedDate: "2024-01-02T11:00:00", State: "New", IterationPath: "P\\Sprint 1", RemainingWork: 4},
+		},
+		// Removed on day 4
+		3: {
+			{Rev: 1, ChangedDate: "2023-12-30T10:00:00", State: "New", IterationPath: "P\\Sprint 1", RemainingWork: 2},
+			{Rev: 2, ChangedDate: "2024-01-04T10:00:00", State: "Removed", IterationPath: "P\\Sprint 1", RemainingWork: 2},
+		},
+	}
+	now := time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		metric         burndownMetric
+		expectedValues []float64
+		expectedScope  []float64
+	}{
+		{
+			name:           "remaining items",
+			metric:         remainingItemsMetric,
+			expectedValues: []float64{2, 3, 2, 1},
+			expectedScope:  []float64{2, 3, 3, 2},
+		},
+		{
+			name:           "remaining work",
+			metric:         remainingWorkMetric,
+			expectedValues: []float64{10, 11, 6, 4},
+			expectedScope:  []float64{10, 11, 6, 4},
+		},
+		{
+			name:           "completed items",
+			metric:         completedItemsMetric,
+			expectedValues: []float64{0, 0, 1, 1},
+			expectedScope:  []float64{2, 3, 3, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := buildBurndownSeries(sprint, history, tt.metric, testCategoryOf, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// Future days are not plotted
+			if len(points) != len(tt.expectedValues) {
+				t.Fatalf("expected %d points, got %d", len(tt.expectedValues), len(points))
+			}
+			for i, p := range points {
+				if p.Value != tt.expectedValues[i] {
+					t.Errorf("day %d: value = %v, want %v", i, p.Value, tt.expectedValues[i])
+				}
+				if p.Scope != tt.expectedScope[i] {
+					t.Errorf("day %d: scope = %v, want %v", i, p.Scope, tt.expectedScope[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildBurndownSeries_Errors(t *testing.T) {
+	now := time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	if _, err := buildBurndownSeries(nil, nil, remainingItemsMetric, testCategoryOf, now); err == nil {
+		t.Error("expected error for nil sprint")
+	}
+
+	sprint := createTestSprint("Sprint 1", "P\\Sprint 1", "", "")
+	if _, err := buildBurndownSeries(sprint, nil, remainingItemsMetric, testCategoryOf, now); err == nil {
+		t.Error("expected error for sprint without dates")
+	}
+
+	// A sprint that hasn't started has no points
+	future := createTestSprint("Sprint 9", "P\\Sprint 9", "2024-02-01", "2024-02-14")
+	points, err := buildBurndownSeries(future, nil, remainingItemsMetric, testCategoryOf, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 0 {
+		t.Errorf("expected no points for future sprint, got %d", len(points))
+	}
+}
+
+func TestRenderBurndownChart_Dimensions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []burndownPoint{
+		{Date: start, Value: 12, Scope: 12},
+		{Date: start.AddDate(0, 0, 1), Value: 9, Scope: 12},
+		{Date: start.AddDate(0, 0, 2), Value: 7.5, Scope: 14},
+	}
+
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		metric burndownMetric
+	}{
+		{"wide chart", 80, 10, remainingItemsMetric},
+		{"narrow chart", 20, 5, remainingWorkMetric},
+		{"burnup", 60, 8, completedItemsMetric},
+		{"tiny terminal", 10, 1, remainingItemsMetric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := renderBurndownChart(points, 14, tt.metric, tt.width, tt.height)
+			lines := strings.Split(chart, "\n")
+
+			if len(lines) != tt.height+2 {
+				t.Errorf("expected %d lines, got %d", tt.height+2, len(lines))
+			}
+			for i, line := range lines {
+				if w := utf8.RuneCountInString(line); w > tt.width {
+					t.Errorf("line %d is %d columns wide, exceeds %d: %q", i, w, tt.width, line)
+				}
+			}
+			if tt.height >= 5 && !strings.Contains(chart, "█") {
+				t.Error("expected chart to contain full blocks")
+			}
+		})
+	}
+}
+
+func TestRenderBurndownChart_IdealLine(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []burndownPoint{{Date: start, Value: 10, Scope: 10}}
+
+	burndown := renderBurndownChart(points, 10, remainingItemsMetric, 60, 8)
+	if !strings.Contains(burndown, "·") {
+		t.Error("expected burndown chart to draw the ideal line")
+	}
+
+	burnup := renderBurndownChart(points, 10, completedItemsMetric, 60, 8)
+	if strings.Contains(burnup, "·") {
+		t.Error("expected burnup chart to omit the ideal line")
+	}
+}
+
+func TestDummyBackend_SprintWorkItemRevisions(t *testing.T) {
+	db := NewDummyBackend()
+	sprint := db.sprints.current
+
+	history, err := db.GetSprintWorkItemRevisions(context.Background(), sprint.Path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(history) == 0 {
+		t.Fatal("expected sample history for the current sprint")
+	}
+
+	// Closed items must be part of the history so the chart can burn down
+	hasClosed := false
+	for id := range history {
+		if db.workItems[id].State == "Closed" {
+			hasClosed = true
+			break
+		}
+	}
+	if !hasClosed {
+		t.Error("expected history to include closed items")
+	}
+
+	// Every change records a new revision
+	var id int
+	for id = range history {
+		break
+	}
+	before := len(history[id])
+	if err := db.UpdateWorkItemState(context.Background(), id, "Resolved"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history, _ = db.GetSprintWorkItemRevisions(context.Background(), sprint.Path)
+	revs := history[id]
+	if len(revs) != before+1 {
+		t.Fatalf("expected %d revisions, got %d", before+1, len(revs))
+	}
+	if last := revs[len(revs)-1]; last.State != "Resolved" || last.Rev != before+1 {
+		t.Errorf("unexpected last revision: %+v", last)
+	}
+}
+
+func TestHandleSprintHistoryLoadedMsg(t *testing.T) {
+	sprint := createTestSprint("Sprint 1", "P\\Sprint 1", "2024-01-01", "2024-01-14")
+	history := map[int][]WorkItemRevision{1: {{Rev: 1, State: "New"}}}
+
+	tests := []struct {
+		name           string
+		msg            sprintHistoryLoadedMsg
+		expectHistory  bool
+		expectLoading  bool
+		expectErrorMsg bool
+	}{
+		{
+			name:          "success",
+			msg:           sprintHistoryLoadedMsg{sprintPath: sprint.Path, history: history},
+			expectHistory: true,
+		},
+		{
+			name:           "error",
+			msg:            sprintHistoryLoadedMsg{sprintPath: sprint.Path, err: errors.New("boom")},
+			expectErrorMsg: true,
+		},
+		{
+			name:          "stale sprint is ignored",
+			msg:           sprintHistoryLoadedMsg{sprintPath: "P\\Sprint 2", history: history},
+			expectLoading: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{
+				state:    burndownView,
+				loading:  true,
+				burndown: BurndownState{sprint: sprint},
+			}
+
+			m, _ = m.handleSprintHistoryLoadedMsg(tt.msg)
+
+			if m.loading != tt.expectLoading {
+				t.Errorf("loading = %v, want %v", m.loading, tt.expectLoading)
+			}
+			if (m.burndown.history != nil) != tt.expectHistory {
+				t.Errorf("history set = %v, want %v", m.burndown.history != nil, tt.expectHistory)
+			}
+			if strings.Contains(m.statusMessage, "Error") != tt.expectErrorMsg {
+				t.Errorf("unexpected status message: %q", m.statusMessage)
+			}
+		})
+	}
+}
+
+func TestHandleBurndownView_Keys(t *testing.T) {
+	m := model{state: burndownView}
+
+	m, _ = m.handleBurndownView(tea.KeyMsg{Type: tea.KeyTab})
+	if m.burndown.metric != remainingWorkMetric {
+		t.Errorf("expected tab to select remaining work, got %v", m.burndown.metric)
+	}
+
+	m.burndown.metric = completedItemsMetric
+	m, _ = m.handleBurndownView(tea.KeyMsg{Type: tea.KeyTab})
+	if m.burndown.metric != remainingItemsMetric {
+		t.Errorf("expected metric to wrap around, got %v", m.burndown.metric)
+	}
+
+	m, _ = m.handleBurndownView(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != listView {
+		t.Errorf("expected esc to return to list view, got %v", m.state)
+	}
+}
@@ -0,0 +1,546 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Exit codes returned by the CLI subcommands. Scripts rely on these, so they must not change.
+const (
+	exitOK          = 0 // Command succeeded
+	exitFailure     = 1 // Backend or I/O error
+	exitUsage       = 2 // Invalid arguments or unknown command
+	exitConfigError = 3 // Configuration missing or invalid
+)
+
+// cliMaxItems bounds how many items `hippo list` fetches
+const cliMaxItems = 1000
+
+// usageError marks errors caused by invalid command-line arguments
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// newUsageError returns a usageError with a formatted message
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// configError marks errors caused by missing or invalid configuration
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configError) Unwrap() error {
+	return e.err
+}
+
+// cliContext is passed to every subcommand
+type cliContext struct {
+	client    Backend
+	config    *Config                 // Loaded configuration, nil if it couldn't be loaded
+	flags     *FlagConfig             // Global flags, forwarded to installed git hooks
+	newClient func() (Backend, error) // Creates the backend on first use
+	dir       string                  // Working directory for git commands, "" for the current one
+	stdout    io.Writer
+	stderr    io.Writer
+	stdin     io.Reader
+	requests  context.Context // Cancelled when the command is interrupted, nil for never
+}
+
+// context returns the context server requests run under
+func (ctx *cliContext) context() context.Context {
+	if ctx.requests == nil {
+		return context.Background()
+	}
+	return ctx.requests
+}
+
+// backend returns the backend, creating it on first use so commands that
+// don't talk to the server (like hook install) work without credentials
+func (ctx *cliContext) backend() (Backend, error) {
+	if ctx.client != nil {
+		return ctx.client, nil
+	}
+	if ctx.newClient == nil {
+		return nil, fmt.Errorf("no backend configured")
+	}
+	client, err := ctx.newClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx.client = client
+	return client, nil
+}
+
+// exportColumns returns the configured CSV columns, or nil for the defaults
+func (ctx *cliContext) exportColumns() []string {
+	if ctx.config == nil {
+		return nil
+	}
+	return ctx.config.ExportColumns
+}
+
+// branchTemplate returns the configured branch template, or "" for the default
+func (ctx *cliContext) branchTemplate() string {
+	if ctx.config == nil {
+		return ""
+	}
+	return ctx.config.BranchTemplate
+}
+
+// cliCommand is a non-interactive subcommand
+type cliCommand struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctx *cliContext, args []string) error
+}
+
+// cliCommands lists the subcommands in the order they appear in help
+var cliCommands = []cliCommand{
+	{"list", "list [--sprint current|previous|next|<name>] [--state <state>] [--limit n]", "List your work items in a sprint", runListCommand},
+	{"show", "show <id>", "Show a single work item", runShowCommand},
+	{"state", "state <id> <state>", "Change the state of a work item", runStateCommand},
+	{"move", "move <id> --to current|previous|next|<name>", "Move a work item to another sprint", runMoveCommand},
+	{"create", "create <title> [--type Task] [--parent id] [--sprint current|...]", "Create a work item", runCreateCommand},
+	{"comment", "comment <id> <text|->", "Add a comment to a work item (- reads stdin)", runCommentCommand},
+	{"hook", "hook install [--force] | hook uninstall", "Install git hooks that reference and transition work items", runHookCommand},
+}
+
+// findCLICommand returns the subcommand with the given name, or nil
+func findCLICommand(name string) *cliCommand {
+	for i := range cliCommands {
+		if cliCommands[i].name == name {
+			return &cliCommands[i]
+		}
+	}
+	return nil
+}
+
+// isCLICommand returns true if name is a known subcommand
+func isCLICommand(name string) bool {
+	return findCLICommand(name) != nil
+}
+
+// runCLI loads the configuration, creates the backend and runs the subcommand from the flags.
+// It returns the process exit code.
+func runCLI(flags *FlagConfig, dummyMode bool) int {
+	command := findCLICommand(flags.Command)
+	if command == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q (run 'hippo --help' for usage)\n", flags.Command)
+		return exitUsage
+	}
+
+	// ctrl+c cancels the requests in flight rather than killing the process mid-write
+	requests, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx := &cliContext{flags: flags, stdout: os.Stdout, stderr: os.Stderr, stdin: os.Stdin, requests: requests}
+
+	// Same precedence as the TUI: flags > environment > config file
+	config, _, configErr := LoadConfig(flags)
+	if configErr == nil {
+		ctx.config = config
+		configErr = ValidateConfig(config)
+	}
+
+	ctx.newClient = func() (Backend, error) {
+		if dummyMode {
+			return NewDummyBackend(), nil
+		}
+		if configErr != nil {
+			return nil, &configError{err: configErr}
+		}
+		return newBackend(config)
+	}
+
+	return executeCLICommand(ctx, command, flags.CommandArgs)
+}
+
+// executeCLICommand runs a subcommand and maps its error to an exit code
+func executeCLICommand(ctx *cliContext, command *cliCommand, args []string) int {
+	err := command.run(ctx, args)
+	if err == nil {
+		return exitOK
+	}
+
+	// -h/--help on a subcommand already printed its usage
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(ctx.stderr, "Error: %v\nUsage: hippo %s\n", err, command.usage)
+		return exitUsage
+	}
+
+	var configErr *configError
+	if errors.As(err, &configErr) {
+		fmt.Fprintf(ctx.stderr, "Configuration error: %v\nRun 'hippo --init' to set up Hippo.\n", err)
+		return exitConfigError
+	}
+
+	fmt.Fprintf(ctx.stderr, "Error: %v\n", err)
+	return exitFailure
+}
+
+// newCommandFlagSet creates a flag set for a subcommand that reports errors instead of exiting
+func newCommandFlagSet(ctx *cliContext, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(ctx.stderr)
+	return fs
+}
+
+// parseCommandArgs parses flags that may appear before, between or after positional arguments
+// and returns the positional arguments
+func parseCommandArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseWorkItemID parses a work item ID argument, accepting an optional leading #
+func parseWorkItemID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || id <= 0 {
+		return 0, newUsageError("invalid work item id %q", arg)
+	}
+	return id, nil
+}
+
+// resolveSprint finds a sprint by relative name (current, previous, next) or by name/path
+func resolveSprint(ctx context.Context, client Backend, name string) (*Sprint, error) {
+	switch strings.ToLower(name) {
+	case "current", "previous", "prev", "next":
+		prev, curr, next, err := client.GetCurrentAndAdjacentSprints(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sprints: %w", err)
+		}
+		sprint := curr
+		switch strings.ToLower(name) {
+		case "previous", "prev":
+			sprint = prev
+		case "next":
+			sprint = next
+		}
+		if sprint == nil {
+			return nil, fmt.Errorf("no %s sprint found", strings.ToLower(name))
+		}
+		return sprint, nil
+	}
+
+	sprints, err := client.GetAllSprints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprints: %w", err)
+	}
+	for i := range sprints {
+		if strings.EqualFold(sprints[i].Name, name) || strings.EqualFold(sprints[i].Path, name) {
+			return &sprints[i], nil
+		}
+	}
+	return nil, fmt.Errorf("sprint %q not found", name)
+}
+
+// fetchSprintItems loads up to limit work items of a sprint, most recently changed first: the
+// unfinished ones, or those in state when given; details adds descriptions and comments
+func fetchSprintItems(ctx context.Context, client Backend, sprintPath string, state string, limit int, details bool) ([]WorkItem, error) {
+	var ids []int
+	var err error
+	if state == "" {
+		ids, err = client.GetSprintWorkItemIDs(ctx, sprintPath)
+	} else {
+		ids, err = client.GetSprintWorkItemIDsInState(ctx, sprintPath, state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if details {
+		return client.GetWorkItemDetailsByIDs(ctx, ids)
+	}
+	return client.GetWorkItemsByIDs(ctx, ids)
+}
+
+// matchState returns the canonical spelling of state among the valid states
+func matchState(state string, validStates []string) (string, bool) {
+	for _, valid := range validStates {
+		if strings.EqualFold(valid, state) {
+			return valid, true
+		}
+	}
+	return "", false
+}
+
+// runListCommand implements `hippo list`
+func runListCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "list")
+	sprintName := fs.String("sprint", "current", "Sprint: current, previous, next, or a sprint name")
+	state := fs.String("state", "", "Only show items in this state")
+	limit := fs.Int("limit", cliMaxItems, "Maximum number of items")
+	output := addOutputFlag(fs)
+
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return newUsageError("unexpected argument %q", positional[0])
+	}
+	if *limit <= 0 {
+		return newUsageError("--limit must be positive")
+	}
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := resolveSprint(ctx.context(), client, *sprintName)
+	if err != nil {
+		return err
+	}
+
+	// Only a CSV description column needs more than the list fields
+	columns := ctx.exportColumns()
+	details := format == csvOutput && slices.Contains(columns, "description")
+	items, err := fetchSprintItems(ctx.context(), client, sprint.Path, *state, *limit, details)
+	if err != nil {
+		return err
+	}
+	return writeWorkItems(ctx.stdout, items, format, columns)
+}
+
+// runShowCommand implements `hippo show`
+func runShowCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "show")
+	output := addOutputFlag(fs)
+
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("expected exactly one work item id")
+	}
+	id, err := parseWorkItemID(positional[0])
+	if err != nil {
+		return err
+	}
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	item, err := client.GetWorkItemByID(ctx.context(), id)
+	if err != nil {
+		return err
+	}
+	return writeWorkItemDetail(ctx.stdout, item, format, ctx.exportColumns())
+}
+
+// runStateCommand implements `hippo state`
+func runStateCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "state")
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return newUsageError("expected a work item id and a state")
+	}
+	id, err := parseWorkItemID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	item, err := client.GetWorkItemByID(ctx.context(), id)
+	if err != nil {
+		return err
+	}
+
+	// Validate against the states of the item's type so typos fail before the update
+	newState := positional[1]
+	if validStates, _, err := client.GetWorkItemTypeStates(ctx.context(), item.WorkItemType); err == nil && len(validStates) > 0 {
+		canonical, ok := matchState(newState, validStates)
+		if !ok {
+			return newUsageError("invalid state %q for %s (valid: %s)", newState, item.WorkItemType, strings.Join(validStates, ", "))
+		}
+		newState = canonical
+	}
+
+	if err := client.UpdateWorkItemState(ctx.context(), id, newState); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.stdout, "#%d: %s → %s\n", id, item.State, newState)
+	return nil
+}
+
+// runMoveCommand implements `hippo move`
+func runMoveCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "move")
+	to := fs.String("to", "", "Target sprint: current, previous, next, or a sprint name")
+
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return newUsageError("expected exactly one work item id")
+	}
+	if *to == "" {
+		return newUsageError("--to is required")
+	}
+	id, err := parseWorkItemID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := resolveSprint(ctx.context(), client, *to)
+	if err != nil {
+		return err
+	}
+	if err := client.MoveWorkItemToSprint(ctx.context(), id, sprint.Path); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.stdout, "#%d: moved to %s\n", id, sprint.Name)
+	return nil
+}
+
+// runCreateCommand implements `hippo create`
+func runCreateCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "create")
+	workItemType := fs.String("type", "Task", "Work item type")
+	parent := fs.String("parent", "", "Parent work item id")
+	sprintName := fs.String("sprint", "current", "Sprint: current, previous, next, or a sprint name")
+	output := addOutputFlag(fs)
+
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+		return newUsageError("expected exactly one title (quote titles with spaces)")
+	}
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	var parentID *int
+	if *parent != "" {
+		id, err := parseWorkItemID(*parent)
+		if err != nil {
+			return err
+		}
+		parentID = &id
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := resolveSprint(ctx.context(), client, *sprintName)
+	if err != nil {
+		return err
+	}
+
+	item, err := client.CreateWorkItem(ctx.context(), strings.TrimSpace(positional[0]), *workItemType, sprint.Path, parentID, "")
+	if err != nil {
+		return err
+	}
+	return writeWorkItems(ctx.stdout, []WorkItem{*item}, format, ctx.exportColumns())
+}
+
+// runCommentCommand implements `hippo comment`
+func runCommentCommand(ctx *cliContext, args []string) error {
+	fs := newCommandFlagSet(ctx, "comment")
+	positional, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return newUsageError("expected a work item id and a comment")
+	}
+	id, err := parseWorkItemID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	text := strings.Join(positional[1:], " ")
+	if text == "-" {
+		content, err := io.ReadAll(ctx.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read comment from stdin: %w", err)
+		}
+		text = string(content)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return newUsageError("comment is empty")
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateWorkItem(ctx.context(), id, map[string]interface{}{"comment": text}); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.stdout, "#%d: comment added\n", id)
+	return nil
+}
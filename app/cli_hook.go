@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// runHookCommand implements `hippo hook`: installing the git hooks and the hook entry points git calls
+func runHookCommand(ctx *cliContext, args []string) error {
+	if len(args) == 0 {
+		return newUsageError("expected install, uninstall, or a hook name")
+	}
+
+	switch args[0] {
+	case "install":
+		fs := newCommandFlagSet(ctx, "hook install")
+		force := fs.Bool("force", false, "Replace existing hooks not installed by hippo")
+		positional, err := parseCommandArgs(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(positional) > 0 {
+			return newUsageError("unexpected argument %q", positional[0])
+		}
+
+		installed, err := installHooks(ctx.dir, hookGlobalArgs(ctx.flags), *force)
+		if err != nil {
+			return err
+		}
+		for _, path := range installed {
+			fmt.Fprintf(ctx.stdout, "Installed %s\n", path)
+		}
+		return nil
+
+	case "uninstall":
+		removed, err := uninstallHooks(ctx.dir)
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			fmt.Fprintln(ctx.stdout, "No hippo hooks installed")
+		}
+		for _, path := range removed {
+			fmt.Fprintf(ctx.stdout, "Removed %s\n", path)
+		}
+		return nil
+
+	case "prepare-commit-msg":
+		if len(args) < 2 {
+			return newUsageError("expected the commit message file")
+		}
+		source := ""
+		if len(args) > 2 {
+			source = args[2]
+		}
+		runPrepareCommitMsgHook(ctx, args[1], source)
+		return nil
+
+	case "commit-msg":
+		if len(args) < 2 {
+			return newUsageError("expected the commit message file")
+		}
+		runCommitMsgHook(ctx, args[1])
+		return nil
+
+	case "post-commit":
+		runPostCommitHook(ctx)
+		return nil
+	}
+
+	return newUsageError("unknown hook command %q", args[0])
+}
+
+// The hook entry points below never fail: a commit must not be blocked because
+// the server is unreachable. Problems are reported as warnings on stderr.
+
+// runPrepareCommitMsgHook adds the branch's work item reference to the commit message
+func runPrepareCommitMsgHook(ctx *cliContext, messageFile, source string) {
+	// Merges, squashes and amends already have a message
+	if source == "merge" || source == "squash" || source == "commit" {
+		return
+	}
+
+	branch, err := currentGitBranch(ctx.dir)
+	if err != nil {
+		return
+	}
+	workItemID, ok := workItemIDFromBranch(branch, ctx.branchTemplate())
+	if !ok {
+		return
+	}
+
+	content, err := os.ReadFile(messageFile)
+	if err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: %v\n", err)
+		return
+	}
+	message := insertWorkItemReference(string(content), workItemID)
+	if message == string(content) {
+		return
+	}
+	if err := os.WriteFile(messageFile, []byte(message), 0644); err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: %v\n", err)
+	}
+}
+
+// runCommitMsgHook queues the transitions requested by keywords such as "fixes AB#1234".
+// They're applied by the post-commit hook, so an aborted commit changes nothing.
+func runCommitMsgHook(ctx *cliContext, messageFile string) {
+	content, err := os.ReadFile(messageFile)
+	if err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: %v\n", err)
+		return
+	}
+
+	transitions := parseTransitions(string(content))
+	if err := appendTransitionQueue(ctx.dir, transitions); err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: %v\n", err)
+	}
+}
+
+// runPostCommitHook applies the queued transitions through UpdateWorkItemState
+func runPostCommitHook(ctx *cliContext) {
+	transitions, err := takeTransitionQueue(ctx.dir)
+	if err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: %v\n", err)
+		return
+	}
+	if len(transitions) == 0 {
+		return
+	}
+
+	client, err := ctx.backend()
+	if err != nil {
+		fmt.Fprintf(ctx.stderr, "hippo: work item states not updated: %v\n", err)
+		return
+	}
+
+	for _, transition := range transitions {
+		if err := applyTransition(ctx, client, transition); err != nil {
+			fmt.Fprintf(ctx.stderr, "hippo: #%d not updated: %v\n", transition.WorkItemID, err)
+		}
+	}
+}
+
+// applyTransition moves a work item to the state its commit keyword asks for
+func applyTransition(ctx *cliContext, client Backend, transition workItemTransition) error {
+	item, err := client.GetWorkItemByID(ctx.context(), transition.WorkItemID)
+	if err != nil {
+		return err
+	}
+	states, categories, err := client.GetWorkItemTypeStates(ctx.context(), item.WorkItemType)
+	if err != nil {
+		return err
+	}
+
+	oldState := item.State
+	newState := transitionTargetState(transition.Keyword, oldState, states, categories)
+	if newState == "" {
+		return nil
+	}
+	if err := client.UpdateWorkItemState(ctx.context(), item.ID, newState); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.stdout, "hippo: #%d %s → %s (%s)\n", item.ID, oldState, newState, transition.Keyword)
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// outputFormat is how CLI subcommands print work items
+type outputFormat string
+
+const (
+	tableOutput outputFormat = "table"
+	jsonOutput  outputFormat = "json"
+	csvOutput   outputFormat = "csv"
+)
+
+// addOutputFlag registers the shared -o/--output flag on a subcommand
+func addOutputFlag(fs *flag.FlagSet) *string {
+	output := new(string)
+	fs.StringVar(output, "output", string(tableOutput), "Output format: table, json or csv")
+	fs.StringVar(output, "o", string(tableOutput), "Shorthand for --output")
+	return output
+}
+
+// parseOutputFormat validates an --output value
+func parseOutputFormat(value string) (outputFormat, error) {
+	switch format := outputFormat(strings.ToLower(value)); format {
+	case tableOutput, jsonOutput, csvOutput:
+		return format, nil
+	}
+	return "", newUsageError("unknown output format %q (use table, json or csv)", value)
+}
+
+// cliWorkItem is the stable JSON representation of a work item
+type cliWorkItem struct {
+	ID            int     `json:"id"`
+	Title         string  `json:"title"`
+	Type          string  `json:"type"`
+	State         string  `json:"state"`
+	AssignedTo    string  `json:"assignedTo,omitempty"`
+	Priority      int     `json:"priority,omitempty"`
+	Tags          string  `json:"tags,omitempty"`
+	IterationPath string  `json:"iterationPath,omitempty"`
+	ParentID      *int    `json:"parentId,omitempty"`
+	RemainingWork float64 `json:"remainingWork,omitempty"`
+	CreatedDate   string  `json:"createdDate,omitempty"`
+	ChangedDate   string  `json:"changedDate,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Comments      string  `json:"comments,omitempty"`
+}
+
+// toCLIWorkItem converts a work item; details adds the long text fields
+func toCLIWorkItem(item WorkItem, details bool) cliWorkItem {
+	out := cliWorkItem{
+		ID:            item.ID,
+		Title:         item.Title,
+		Type:          item.WorkItemType,
+		State:         item.State,
+		AssignedTo:    item.AssignedTo,
+		Priority:      item.Priority,
+		Tags:          item.Tags,
+		IterationPath: item.IterationPath,
+		ParentID:      item.ParentID,
+		RemainingWork: item.RemainingWork,
+		CreatedDate:   item.CreatedDate,
+		ChangedDate:   item.ChangedDate,
+	}
+	if details {
+		out.Description = item.Description
+		out.Comments = item.Comments
+	}
+	return out
+}
+
+// writeWorkItems prints a list of work items in the given format; columns select the CSV columns
+func writeWorkItems(w io.Writer, items []WorkItem, format outputFormat, columns []string) error {
+	switch format {
+	case jsonOutput:
+		out := make([]cliWorkItem, 0, len(items))
+		for _, item := range items {
+			out = append(out, toCLIWorkItem(item, false))
+		}
+		return writeJSON(w, out)
+
+	case csvOutput:
+		return writeWorkItemsCSV(w, items, columns)
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tTYPE\tSTATE\tASSIGNED TO\tTITLE")
+		for _, item := range items {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", item.ID, item.WorkItemType, item.State, item.AssignedTo, item.Title)
+		}
+		return tw.Flush()
+	}
+}
+
+// writeWorkItemDetail prints a single work item with its description and comments
+func writeWorkItemDetail(w io.Writer, item *WorkItem, format outputFormat, columns []string) error {
+	switch format {
+	case jsonOutput:
+		return writeJSON(w, toCLIWorkItem(*item, true))
+
+	case csvOutput:
+		return writeWorkItems(w, []WorkItem{*item}, csvOutput, columns)
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "ID:\t%d\n", item.ID)
+		fmt.Fprintf(tw, "Title:\t%s\n", item.Title)
+		fmt.Fprintf(tw, "Type:\t%s\n", item.WorkItemType)
+		fmt.Fprintf(tw, "State:\t%s\n", item.State)
+		if item.AssignedTo != "" {
+			fmt.Fprintf(tw, "Assigned To:\t%s\n", item.AssignedTo)
+		}
+		if item.Priority > 0 {
+			fmt.Fprintf(tw, "Priority:\t%d\n", item.Priority)
+		}
+		if item.Tags != "" {
+			fmt.Fprintf(tw, "Tags:\t%s\n", item.Tags)
+		}
+		if item.IterationPath != "" {
+			fmt.Fprintf(tw, "Sprint:\t%s\n", item.IterationPath)
+		}
+		if item.ParentID != nil {
+			fmt.Fprintf(tw, "Parent:\t#%d\n", *item.ParentID)
+		}
+		if item.ChangedDate != "" {
+			fmt.Fprintf(tw, "Last Updated:\t%s\n", formatDateTime(item.ChangedDate))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if item.Description != "" {
+			fmt.Fprintf(w, "\nDescription:\n%s\n", item.Description)
+		}
+		if item.Comments != "" {
+			fmt.Fprintf(w, "\nComments:\n%s\n", item.Comments)
+		}
+		return nil
+	}
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
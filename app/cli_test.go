@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runTestCommand runs a subcommand against the backend and returns its exit code and output
+func runTestCommand(t *testing.T, client Backend, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	command := findCLICommand(args[0])
+	if command == nil {
+		t.Fatalf("unknown command %q", args[0])
+	}
+	var stdout, stderr bytes.Buffer
+	ctx := &cliContext{client: client, stdout: &stdout, stderr: &stderr, stdin: strings.NewReader(stdin)}
+	code := executeCLICommand(ctx, command, args[1:])
+	return code, stdout.String(), stderr.String()
+}
+
+func TestParseCommandArgs(t *testing.T) {
+	tests := []struct {
+		name               string
+		args               []string
+		expectedPositional []string
+		expectedTo         string
+		expectError        bool
+	}{
+		{"flags after positional", []string{"1234", "--to", "next"}, []string{"1234"}, "next", false},
+		{"flags before positional", []string{"--to", "next", "1234"}, []string{"1234"}, "next", false},
+		{"interspersed", []string{"a", "--to=x", "b"}, []string{"a", "b"}, "x", false},
+		{"double dash stops parsing", []string{"--", "--to", "x"}, []string{"--to", "x"}, "", false},
+		{"unknown flag", []string{"--bogus"}, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			to := fs.String("to", "", "")
+
+			positional, err := parseCommandArgs(fs, tt.args)
+			if tt.expectError {
+				var usageErr *usageError
+				if err == nil || !errors.As(err, &usageErr) {
+					t.Fatalf("expected usage error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(positional, tt.expectedPositional) {
+				t.Errorf("expected positional %v, got %v", tt.expectedPositional, positional)
+			}
+			if *to != tt.expectedTo {
+				t.Errorf("expected --to %q, got %q", tt.expectedTo, *to)
+			}
+		})
+	}
+}
+
+func TestCLIExitCodes(t *testing.T) {
+	db := NewDummyBackend()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"list", []string{"list"}, exitOK},
+		{"help flag", []string{"show", "--help"}, exitOK},
+		{"missing id", []string{"show"}, exitUsage},
+		{"invalid id", []string{"show", "abc"}, exitUsage},
+		{"unknown format", []string{"list", "-o", "yaml"}, exitUsage},
+		{"move without target", []string{"move", "1001"}, exitUsage},
+		{"invalid state", []string{"state", "1001", "Bogus"}, exitUsage},
+		{"unknown item", []string{"show", "99999"}, exitFailure},
+		{"unknown sprint", []string{"list", "--sprint", "Sprint 999"}, exitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runTestCommand(t, db, "", tt.args...)
+			if code != tt.expected {
+				t.Errorf("expected exit code %d, got %d (stderr: %s)", tt.expected, code, stderr)
+			}
+		})
+	}
+}
+
+func TestCLIListOutputFormats(t *testing.T) {
+	db := NewDummyBackend()
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	expected, err := fetchSprintItems(context.Background(), db, curr.Path, "", cliMaxItems, false)
+	if err != nil || len(expected) == 0 {
+		t.Fatalf("expected items in the current sprint, got %d (err: %v)", len(expected), err)
+	}
+
+	code, out, _ := runTestCommand(t, db, "", "list", "--output", "json")
+	if code != exitOK {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	var items []cliWorkItem
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(items) != len(expected) {
+		t.Errorf("expected %d items, got %d", len(expected), len(items))
+	}
+	for i := range items {
+		if i < len(expected) && items[i].ID != expected[i].ID {
+			t.Errorf("expected items most recently changed first, got #%d at %d instead of #%d", items[i].ID, i, expected[i].ID)
+		}
+	}
+
+	_, out, _ = runTestCommand(t, db, "", "list", "-o", "csv")
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(records) != len(expected)+1 || !reflect.DeepEqual(records[0], defaultExportColumns) {
+		t.Errorf("expected header plus %d rows, got %v", len(expected), records)
+	}
+
+	_, out, _ = runTestCommand(t, db, "", "list", "--state", "new")
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n")[1:] {
+		if !strings.Contains(line, "New") {
+			t.Errorf("expected only New items, got %q", line)
+		}
+	}
+}
+
+func TestCLIListByState(t *testing.T) {
+	db := NewDummyBackend()
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	ids, _ := db.GetSprintWorkItemIDs(context.Background(), curr.Path)
+	if len(ids) < 2 {
+		t.Fatalf("expected at least 2 items in the current sprint, got %d", len(ids))
+	}
+	// The most recently changed item is closed and the next one New, without touching their dates
+	db.workItems[ids[0]].State = "Closed"
+	db.workItems[ids[1]].State = "New"
+
+	list := func(args ...string) []int {
+		t.Helper()
+		code, out, stderr := runTestCommand(t, db, "", append([]string{"list", "-o", "json"}, args...)...)
+		if code != exitOK {
+			t.Fatalf("list failed: %s", stderr)
+		}
+		var items []cliWorkItem
+		if err := json.Unmarshal([]byte(out), &items); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		var listed []int
+		for _, item := range items {
+			listed = append(listed, item.ID)
+		}
+		return listed
+	}
+
+	if listed := list("--state", "closed"); !slices.Contains(listed, ids[0]) {
+		t.Errorf("expected the closed item #%d, got %v", ids[0], listed)
+	}
+	if listed := list("--state", "new", "--limit", "1"); len(listed) != 1 || listed[0] != ids[1] {
+		t.Errorf("expected the limit after the state filter to keep #%d, got %v", ids[1], listed)
+	}
+}
+
+func TestCLIStateMoveCreateComment(t *testing.T) {
+	db := NewDummyBackend()
+	parent, err := db.CreateWorkItem(context.Background(), "CLI parent", "User Story", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code, out, stderr := runTestCommand(t, db, "", "create", "CLI child", "--parent", "#"+strconv.Itoa(parent.ID), "--sprint", "next", "-o", "json")
+	if code != exitOK {
+		t.Fatalf("create failed: %s", stderr)
+	}
+	var created []cliWorkItem
+	if err := json.Unmarshal([]byte(out), &created); err != nil || len(created) != 1 {
+		t.Fatalf("invalid create output: %q", out)
+	}
+	child := created[0]
+	_, _, next, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	if child.ParentID == nil || *child.ParentID != parent.ID || child.IterationPath != next.Path {
+		t.Errorf("expected child of #%d in %s, got %+v", parent.ID, next.Path, child)
+	}
+
+	// State names are matched case-insensitively and stored in canonical form
+	if code, _, stderr := runTestCommand(t, db, "", "state", strconv.Itoa(child.ID), "active"); code != exitOK {
+		t.Fatalf("state failed: %s", stderr)
+	}
+	if code, _, stderr := runTestCommand(t, db, "", "move", strconv.Itoa(child.ID), "--to", "current"); code != exitOK {
+		t.Fatalf("move failed: %s", stderr)
+	}
+	if code, _, stderr := runTestCommand(t, db, "piped comment\n", "comment", strconv.Itoa(child.ID), "-"); code != exitOK {
+		t.Fatalf("comment failed: %s", stderr)
+	}
+
+	item, _ := db.GetWorkItemByID(context.Background(), child.ID)
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	if item.State != "Active" || item.IterationPath != curr.Path || item.Comments != "piped comment" {
+		t.Errorf("unexpected item after commands: state %q, sprint %q, comments %q", item.State, item.IterationPath, item.Comments)
+	}
+
+	if code, _, _ := runTestCommand(t, db, "   ", "comment", strconv.Itoa(child.ID), "-"); code != exitUsage {
+		t.Errorf("expected usage error for empty comment, got %d", code)
+	}
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/location"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
@@ -13,15 +15,18 @@ import (
 // Work item operations are in client_workitems.go
 // Sprint operations are in client_sprints.go
 // Backlog operations are in client_backlog.go
+// State metadata caching is in client_states.go
 // Authentication logic is in client_auth.go
 type AzureDevOpsClient struct {
 	connection      *azuredevops.Connection
 	workItemClient  workitemtracking.Client
 	workClient      work.Client
-	ctx             context.Context
 	organizationURL string
 	project         string
 	team            string
+
+	stateMu    sync.Mutex            // Guards stateCache; commands run concurrently
+	stateCache map[string]typeStates // States per work item type, loaded once
 }
 
 // NewAzureDevOpsClient creates a new Azure DevOps client with authentication from Azure CLI
@@ -75,13 +80,24 @@ func NewAzureDevOpsClient(config *Config) (*AzureDevOpsClient, error) {
 		connection:      connection,
 		workItemClient:  workItemClient,
 		workClient:      workClient,
-		ctx:             ctx,
 		organizationURL: organizationURL,
 		project:         project,
 		team:            team,
 	}, nil
 }
 
+// GetCurrentUser returns the display name of the authenticated user
+func (c *AzureDevOpsClient) GetCurrentUser(ctx context.Context) (string, error) {
+	data, err := location.NewClient(ctx, c.connection).GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get connection data: %w", err)
+	}
+	if data.AuthenticatedUser == nil || data.AuthenticatedUser.ProviderDisplayName == nil {
+		return "", fmt.Errorf("connection data has no authenticated user")
+	}
+	return *data.AuthenticatedUser.ProviderDisplayName, nil
+}
+
 // Helper functions
 
 // strPtr is a helper to get pointer to string
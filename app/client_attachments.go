@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// =============================================================================
+// ATTACHMENT OPERATIONS
+// =============================================================================
+
+// DownloadAttachment fetches the content of an attachment
+func (c *AzureDevOpsClient) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	id, err := uuid.Parse(attachment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment id %q: %w", attachment.ID, err)
+	}
+
+	download := true
+	reader, err := c.workItemClient.GetAttachmentContent(ctx, workitemtracking.GetAttachmentContentArgs{
+		Id:       &id,
+		Project:  &c.project,
+		FileName: &attachment.Name,
+		Download: &download,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	return content, nil
+}
+
+// UploadAttachment uploads a file to the project's attachment store.
+// The returned attachment must be linked with AddAttachmentToWorkItem to show up on a work item.
+func (c *AzureDevOpsClient) UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error) {
+	ref, err := c.workItemClient.CreateAttachment(ctx, workitemtracking.CreateAttachmentArgs{
+		UploadStream: bytes.NewReader(content),
+		Project:      &c.project,
+		FileName:     &fileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	attachment := &Attachment{
+		Name: fileName,
+		Size: int64(len(content)),
+	}
+	if ref.Id != nil {
+		attachment.ID = ref.Id.String()
+	}
+	if ref.Url != nil {
+		attachment.URL = *ref.Url
+	}
+
+	return attachment, nil
+}
+
+// AddAttachmentToWorkItem links an uploaded attachment to a work item through an AttachedFile relation
+func (c *AzureDevOpsClient) AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error {
+	if attachment.URL == "" {
+		return fmt.Errorf("attachment %s has no url", attachment.Name)
+	}
+
+	op := webapi.OperationValues.Add
+	relPath := "/relations/-"
+	relation := map[string]interface{}{
+		"rel": "AttachedFile",
+		"url": attachment.URL,
+	}
+	if comment != "" {
+		relation["attributes"] = map[string]interface{}{
+			"comment": comment,
+		}
+	}
+
+	patchDocument := []webapi.JsonPatchOperation{
+		{
+			Op:    &op,
+			Path:  &relPath,
+			Value: relation,
+		},
+	}
+
+	_, err := c.workItemClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:       &workItemID,
+		Document: &patchDocument,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach file to work item: %w", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/work"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
 
 // =============================================================================
@@ -12,8 +14,8 @@ import (
 // =============================================================================
 
 // GetTeamIterations fetches iterations for the team
-func (c *AzureDevOpsClient) GetTeamIterations() ([]work.TeamSettingsIteration, error) {
-	iterations, err := c.workClient.GetTeamIterations(c.ctx, work.GetTeamIterationsArgs{
+func (c *AzureDevOpsClient) GetTeamIterations(ctx context.Context) ([]work.TeamSettingsIteration, error) {
+	iterations, err := c.workClient.GetTeamIterations(ctx, work.GetTeamIterationsArgs{
 		Project: &c.project,
 		Team:    &c.team,
 	})
@@ -30,8 +32,8 @@ func (c *AzureDevOpsClient) GetTeamIterations() ([]work.TeamSettingsIteration, e
 }
 
 // GetCurrentAndAdjacentSprints returns previous, current, and next sprint
-func (c *AzureDevOpsClient) GetCurrentAndAdjacentSprints() (prev *Sprint, curr *Sprint, next *Sprint, err error) {
-	iterations, err := c.GetTeamIterations()
+func (c *AzureDevOpsClient) GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error) {
+	iterations, err := c.GetTeamIterations(ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -93,6 +95,127 @@ func (c *AzureDevOpsClient) GetCurrentAndAdjacentSprints() (prev *Sprint, curr *
 	return prev, curr, next, nil
 }
 
+// GetAllSprints returns every iteration of the team, in the order configured for the team
+func (c *AzureDevOpsClient) GetAllSprints(ctx context.Context) ([]Sprint, error) {
+	iterations, err := c.GetTeamIterations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints := make([]Sprint, 0, len(iterations))
+	for i := range iterations {
+		if sprint := convertIterationToSprint(&iterations[i]); sprint != nil {
+			sprints = append(sprints, *sprint)
+		}
+	}
+
+	return sprints, nil
+}
+
+// GetSprintWorkItemRevisions returns the revision history of every work item that was ever in a sprint, keyed by
+// work item ID. Unlike the list queries, completed and removed items and items moved to another sprint are included
+// so the burndown can count them on the days they were in scope.
+func (c *AzureDevOpsClient) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	query := newWIQLQuery().where(
+		wiqlEq("System.TeamProject", c.project),
+		wiqlEq("System.AssignedTo", wiqlMe),
+		wiqlEver("System.IterationPath", sprintPath),
+	)
+
+	wiql := workitemtracking.Wiql{
+		Query: strPtr(query.String()),
+	}
+
+	queryCtx, cancel := subRequest(ctx)
+	defer cancel()
+	result, err := c.workItemClient.QueryByWiql(queryCtx, workitemtracking.QueryByWiqlArgs{Wiql: &wiql})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sprint work items: %w", err)
+	}
+
+	history := make(map[int][]WorkItemRevision)
+	if result.WorkItems == nil {
+		return history, nil
+	}
+
+	for _, ref := range *result.WorkItems {
+		if ref.Id == nil {
+			continue
+		}
+		id := *ref.Id
+
+		requestCtx, cancel := subRequest(ctx)
+		revisions, err := c.workItemClient.GetRevisions(requestCtx, workitemtracking.GetRevisionsArgs{Id: &id})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get revisions for #%d: %w", id, err)
+		}
+		if revisions == nil {
+			continue
+		}
+
+		for _, rev := range *revisions {
+			history[id] = append(history[id], convertRevision(rev))
+		}
+	}
+
+	return history, nil
+}
+
+// convertRevision converts an Azure DevOps work item revision to our WorkItemRevision struct
+func convertRevision(wi workitemtracking.WorkItem) WorkItemRevision {
+	revision := WorkItemRevision{
+		Rev: getIntField(wi.Rev),
+	}
+
+	if wi.Fields == nil {
+		return revision
+	}
+	fields := *wi.Fields
+
+	if changedBy, ok := fields["System.ChangedBy"].(map[string]interface{}); ok {
+		if displayName, ok := changedBy["displayName"].(string); ok {
+			revision.ChangedBy = displayName
+		}
+	}
+
+	if changedDate, ok := fields["System.ChangedDate"].(string); ok {
+		revision.ChangedDate = formatDate(changedDate)
+	}
+
+	if state, ok := fields["System.State"].(string); ok {
+		revision.State = state
+	}
+
+	if iterationPath, ok := fields["System.IterationPath"].(string); ok {
+		revision.IterationPath = iterationPath
+	}
+
+	if title, ok := fields["System.Title"].(string); ok {
+		revision.Title = title
+	}
+
+	if assignedTo, ok := fields["System.AssignedTo"].(map[string]interface{}); ok {
+		if displayName, ok := assignedTo["displayName"].(string); ok {
+			revision.AssignedTo = displayName
+		}
+	}
+
+	if priority, ok := fields["Microsoft.VSTS.Common.Priority"].(float64); ok {
+		revision.Priority = int(priority)
+	}
+
+	if tags, ok := fields["System.Tags"].(string); ok {
+		revision.Tags = tags
+	}
+
+	if remainingWork, ok := fields["Microsoft.VSTS.Scheduling.RemainingWork"].(float64); ok {
+		revision.RemainingWork = remainingWork
+	}
+
+	return revision
+}
+
 // convertIterationToSprint converts an Azure DevOps TeamSettingsIteration to our Sprint struct
 func convertIterationToSprint(iter *work.TeamSettingsIteration) *Sprint {
 	if iter == nil || iter.Name == nil || iter.Path == nil {
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// =============================================================================
+// STATE METADATA
+// =============================================================================
+
+// typeStates is the cached state metadata of a work item type
+type typeStates struct {
+	names      []string          // States in process order
+	categories map[string]string // State name to category (Proposed, InProgress, Resolved, Completed, Removed)
+}
+
+// loadTypeStates fetches the states of every work item type in the project once.
+// Processes name their states freely, so categories are the only reliable meaning.
+func (c *AzureDevOpsClient) loadTypeStates(ctx context.Context) (map[string]typeStates, error) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	if c.stateCache != nil {
+		return c.stateCache, nil
+	}
+
+	types, err := c.workItemClient.GetWorkItemTypes(ctx, workitemtracking.GetWorkItemTypesArgs{
+		Project: &c.project,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item types: %w", err)
+	}
+
+	cache := make(map[string]typeStates)
+	if types != nil {
+		for _, workItemType := range *types {
+			if workItemType.Name == nil || workItemType.States == nil {
+				continue
+			}
+			cache[*workItemType.Name] = newTypeStates(*workItemType.States)
+		}
+	}
+	c.stateCache = cache
+	return cache, nil
+}
+
+// getTypeStates returns the cached states of a work item type, fetching types the
+// project listing didn't describe
+func (c *AzureDevOpsClient) getTypeStates(ctx context.Context, workItemType string) (typeStates, error) {
+	cache, err := c.loadTypeStates(ctx)
+	if err != nil {
+		return typeStates{}, err
+	}
+	c.stateMu.Lock()
+	states, ok := cache[workItemType]
+	c.stateMu.Unlock()
+	if ok && len(states.names) > 0 {
+		return states, nil
+	}
+
+	colors, err := c.workItemClient.GetWorkItemTypeStates(ctx, workitemtracking.GetWorkItemTypeStatesArgs{
+		Project: &c.project,
+		Type:    &workItemType,
+	})
+	if err != nil {
+		return typeStates{}, fmt.Errorf("failed to get states of %s: %w", workItemType, err)
+	}
+	if colors == nil || len(*colors) == 0 {
+		return typeStates{}, fmt.Errorf("work item type %s has no states", workItemType)
+	}
+
+	states = newTypeStates(*colors)
+	c.stateMu.Lock()
+	cache[workItemType] = states
+	c.stateMu.Unlock()
+	return states, nil
+}
+
+// newTypeStates converts the states of a work item type definition
+func newTypeStates(colors []workitemtracking.WorkItemStateColor) typeStates {
+	states := typeStates{categories: make(map[string]string)}
+	for _, color := range colors {
+		if color.Name == nil || *color.Name == "" {
+			continue
+		}
+		category := "Unknown"
+		if color.Category != nil {
+			category = *color.Category
+		}
+		states.names = append(states.names, *color.Name)
+		states.categories[*color.Name] = category
+	}
+	return states
+}
+
+// GetStateCategories returns the category of every state used by any work item type. A state
+// name used with different categories takes the category of the first type in name order.
+func (c *AzureDevOpsClient) GetStateCategories(ctx context.Context) (map[string]string, error) {
+	cache, err := c.loadTypeStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	categories := make(map[string]string)
+	for _, workItemType := range sortedKeys(cache) {
+		for name, category := range cache[workItemType].categories {
+			if _, seen := categories[name]; !seen {
+				categories[name] = category
+			}
+		}
+	}
+	return categories, nil
+}
+
+// unfinishedCondition matches items that are not in a Completed or Removed state of their own
+// work item type. Types without such states, or missing from the cache, are not restricted.
+func (c *AzureDevOpsClient) unfinishedCondition(ctx context.Context) (wiqlCondition, error) {
+	cache, err := c.loadTypeStates(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	var restricted []string
+	var conditions []wiqlCondition
+	for _, workItemType := range sortedKeys(cache) {
+		var done []string
+		for name, category := range cache[workItemType].categories {
+			if category == "Completed" || category == "Removed" {
+				done = append(done, name)
+			}
+		}
+		if len(done) == 0 {
+			continue
+		}
+		sort.Strings(done)
+		restricted = append(restricted, workItemType)
+		conditions = append(conditions, wiqlAll(
+			wiqlEq("System.WorkItemType", workItemType),
+			wiqlNotIn("System.State", done),
+		))
+	}
+	if len(restricted) == 0 {
+		return "", nil
+	}
+	return wiqlAny(append(conditions, wiqlNotIn("System.WorkItemType", restricted))...), nil
+}
+
+// sortedKeys returns the work item type names of the cache in order
+func sortedKeys(cache map[string]typeStates) []string {
+	names := make([]string, 0, len(cache))
+	for name := range cache {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// customProcessClient returns a client whose state cache describes a custom process
+func customProcessClient() *AzureDevOpsClient {
+	return &AzureDevOpsClient{
+		stateCache: map[string]typeStates{
+			"Bug": {
+				names:      []string{"Triage", "Fixing", "Shipped", "Won't Fix"},
+				categories: map[string]string{"Triage": "Proposed", "Fixing": "InProgress", "Shipped": "Completed", "Won't Fix": "Removed"},
+			},
+			"Task": {
+				names:      []string{"To Do", "Doing", "Shipped"},
+				categories: map[string]string{"To Do": "Proposed", "Doing": "InProgress", "Shipped": "Completed"},
+			},
+		},
+	}
+}
+
+func TestMyWorkItemsQueryExcludesFinishedStates(t *testing.T) {
+	client := customProcessClient()
+	client.project = "O'Brien"
+	query, err := client.myWorkItemsQuery(context.Background(), "O'Brien\\Sprint 1")
+	if err != nil {
+		t.Fatalf("myWorkItemsQuery() error: %v", err)
+	}
+
+	want := "SELECT [System.Id]\nFROM WorkItems\n" +
+		"WHERE [System.TeamProject] = 'O''Brien'\n" +
+		"AND [System.AssignedTo] = @Me\n" +
+		"AND (([System.WorkItemType] = 'Bug' AND [System.State] NOT IN ('Shipped', 'Won''t Fix'))" +
+		" OR ([System.WorkItemType] = 'Task' AND [System.State] NOT IN ('Shipped'))" +
+		" OR [System.WorkItemType] NOT IN ('Bug', 'Task'))\n" +
+		"AND [System.IterationPath] = 'O''Brien\\Sprint 1'"
+	if got := query.String(); got != want {
+		t.Errorf("myWorkItemsQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMyWorkItemsQueryWithoutFinishedStates(t *testing.T) {
+	client := &AzureDevOpsClient{project: "Hippo", stateCache: map[string]typeStates{
+		"Task": {names: []string{"Open"}, categories: map[string]string{"Open": "Proposed"}},
+	}}
+
+	query, err := client.myWorkItemsQuery(context.Background(), "")
+	if err != nil {
+		t.Fatalf("myWorkItemsQuery() error: %v", err)
+	}
+	if strings.Contains(query.String(), "NOT IN") {
+		t.Errorf("myWorkItemsQuery() = %q, want no state condition", query.String())
+	}
+}
+
+func TestGetWorkItemTypeStatesFromCache(t *testing.T) {
+	states, categories, err := customProcessClient().GetWorkItemTypeStates(context.Background(), "Bug")
+	if err != nil {
+		t.Fatalf("GetWorkItemTypeStates() error: %v", err)
+	}
+	if strings.Join(states, ",") != "Triage,Fixing,Shipped,Won't Fix" {
+		t.Errorf("states = %v, want process order", states)
+	}
+	if categories["Won't Fix"] != "Removed" {
+		t.Errorf("category of Won't Fix = %q, want Removed", categories["Won't Fix"])
+	}
+}
+
+func TestGetStateCategoriesIsDeterministic(t *testing.T) {
+	// Resolved is a Completed state of bugs but an InProgress state of stories
+	client := &AzureDevOpsClient{stateCache: map[string]typeStates{
+		"User Story": {names: []string{"Resolved"}, categories: map[string]string{"Resolved": "InProgress"}},
+		"Bug":        {names: []string{"Resolved"}, categories: map[string]string{"Resolved": "Completed"}},
+	}}
+	for i := 0; i < 20; i++ {
+		categories, err := client.GetStateCategories(context.Background())
+		if err != nil {
+			t.Fatalf("GetStateCategories() error: %v", err)
+		}
+		if categories["Resolved"] != "Completed" {
+			t.Fatalf("category of Resolved = %q, want the first type's Completed", categories["Resolved"])
+		}
+	}
+
+	// Only bugs are finished when Resolved
+	condition, err := client.unfinishedCondition(context.Background())
+	if err != nil {
+		t.Fatalf("unfinishedCondition() error: %v", err)
+	}
+	want := "(([System.WorkItemType] = 'Bug' AND [System.State] NOT IN ('Resolved')) OR [System.WorkItemType] NOT IN ('Bug'))"
+	if string(condition) != want {
+		t.Errorf("unfinishedCondition() = %s, want %s", condition, want)
+	}
+}
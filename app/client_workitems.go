@@ -1,10 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
 )
@@ -14,14 +22,14 @@ import (
 // =============================================================================
 
 // GetWorkItemByID fetches a single work item by its ID
-func (c *AzureDevOpsClient) GetWorkItemByID(id int) (*WorkItem, error) {
+func (c *AzureDevOpsClient) GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error) {
 	ids := []int{id}
 	workItemsArgs := workitemtracking.GetWorkItemsArgs{
 		Ids:    &ids,
 		Expand: &workitemtracking.WorkItemExpandValues.All,
 	}
 
-	workItems, err := c.workItemClient.GetWorkItems(c.ctx, workItemsArgs)
+	workItems, err := c.workItemClient.GetWorkItems(ctx, workItemsArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work item: %w", err)
 	}
@@ -34,143 +42,137 @@ func (c *AzureDevOpsClient) GetWorkItemByID(id int) (*WorkItem, error) {
 	return &task, nil
 }
 
-// GetWorkItems returns work items using default parameters
-func (c *AzureDevOpsClient) GetWorkItems() ([]WorkItem, error) {
-	return c.GetWorkItemsExcluding(nil, "", 40)
-}
-
-// GetWorkItemsForSprint returns work items for a specific sprint
-func (c *AzureDevOpsClient) GetWorkItemsForSprint(sprintPath string, excludeIDs []int, limit int) ([]WorkItem, error) {
-	return c.GetWorkItemsExcluding(excludeIDs, sprintPath, limit)
+// GetSprintWorkItemIDs returns the IDs of my unfinished work items, most recently changed first,
+// optionally limited to a sprint
+func (c *AzureDevOpsClient) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	query, err := c.myWorkItemsQuery(ctx, sprintPath)
+	if err != nil {
+		return nil, err
+	}
+	query.orderBy("System.ChangedDate", true)
+	return c.queryWorkItemIDs(ctx, query)
 }
 
-// GetWorkItemsExcluding queries work items with optional exclusions and sprint filter
-func (c *AzureDevOpsClient) GetWorkItemsExcluding(excludeIDs []int, sprintPath string, limit int) ([]WorkItem, error) {
-	// Cap limit at 30
-	if limit > 30 {
-		limit = 30
+// GetSprintWorkItemIDsInState returns the IDs of my work items in a state, finished or not,
+// most recently changed first, optionally limited to a sprint
+func (c *AzureDevOpsClient) GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error) {
+	query := newWIQLQuery().where(
+		wiqlEq("System.TeamProject", c.project),
+		wiqlEq("System.AssignedTo", wiqlMe),
+		wiqlEq("System.State", state),
+	)
+	if sprintPath != "" {
+		query.where(wiqlEq("System.IterationPath", sprintPath))
 	}
+	query.orderBy("System.ChangedDate", true)
+	return c.queryWorkItemIDs(ctx, query)
+}
 
-	// Build the query
-	query := fmt.Sprintf(`
-		SELECT [System.Id]
-		FROM WorkItems
-		WHERE [System.TeamProject] = '%s'
-		AND [System.AssignedTo] = @Me
-		AND [System.State] <> 'Closed'
-		AND [System.State] <> 'Removed'`, c.project)
+// listFields are the fields of list rows: what the list, its columns, filters, the board and
+// burndown use. Descriptions, comments and relations are fetched when an item is opened.
+var listFields = []string{
+	"System.Id", "System.Title", "System.State", "System.WorkItemType", "System.Parent",
+	"System.IterationPath", "Microsoft.VSTS.Common.Priority", "System.ChangedDate",
+	"System.AssignedTo", "System.Tags", "Microsoft.VSTS.Scheduling.RemainingWork",
+	"System.AreaPath", "System.CreatedDate",
+}
 
-	// Add sprint filter if provided
-	if sprintPath != "" {
-		query += fmt.Sprintf("\nAND [System.IterationPath] = '%s'", sprintPath)
+// GetWorkItemsByIDs fetches the list rows of work items in the order of ids
+func (c *AzureDevOpsClient) GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	tasks, err := c.fetchWorkItems(ctx, ids, func(args *workitemtracking.GetWorkItemsArgs) {
+		args.Fields = &listFields
+	})
+	if err != nil {
+		return nil, err
 	}
+	for i := range tasks {
+		tasks[i].Partial = true
+	}
+	return tasks, nil
+}
 
-	// Add exclusion clause if there are IDs to exclude
-	if len(excludeIDs) > 0 {
-		// Convert IDs to string
-		var idStrs []string
-		for _, id := range excludeIDs {
-			idStrs = append(idStrs, fmt.Sprintf("%d", id))
+// GetWorkItemDetailsByIDs fetches complete work items, with relations, in the order of ids
+func (c *AzureDevOpsClient) GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	return c.fetchWorkItems(ctx, ids, func(args *workitemtracking.GetWorkItemsArgs) {
+		args.Expand = &workitemtracking.WorkItemExpandValues.All
+	})
+}
+
+// fetchWorkItems gets work items in chunks of maxWorkItemsPerRequest, with the fields or expansion
+// set by project. Items deleted since the IDs were queried are skipped.
+func (c *AzureDevOpsClient) fetchWorkItems(ctx context.Context, ids []int, project func(args *workitemtracking.GetWorkItemsArgs)) ([]WorkItem, error) {
+	tasks := make([]WorkItem, 0, len(ids))
+	for start := 0; start < len(ids); start += maxWorkItemsPerRequest {
+		chunk := ids[start:min(start+maxWorkItemsPerRequest, len(ids))]
+		workItemsArgs := workitemtracking.GetWorkItemsArgs{
+			Ids:         &chunk,
+			ErrorPolicy: &workitemtracking.WorkItemErrorPolicyValues.Omit,
 		}
-		query += fmt.Sprintf("\nAND [System.Id] NOT IN (%s)", strings.Join(idStrs, ","))
-	}
+		project(&workItemsArgs)
 
-	query += "\nORDER BY [System.ChangedDate] DESC"
+		workItems, err := c.workItemClient.GetWorkItems(ctx, workItemsArgs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work item details: %w", err)
+		}
+		if workItems == nil {
+			continue
+		}
 
-	wiql := workitemtracking.Wiql{
-		Query: strPtr(query),
+		// Omitted items come back without an ID
+		for _, wi := range *workItems {
+			if wi.Id == nil || wi.Fields == nil {
+				continue
+			}
+			tasks = append(tasks, c.convertWorkItem(wi))
+		}
 	}
+	return tasks, nil
+}
 
-	queryArgs := workitemtracking.QueryByWiqlArgs{
-		Wiql: &wiql,
-		Top:  &limit,
+// queryWorkItemIDs runs a WIQL query and returns the IDs of every match in query order
+func (c *AzureDevOpsClient) queryWorkItemIDs(ctx context.Context, query *wiqlQuery) ([]int, error) {
+	wiql := workitemtracking.Wiql{
+		Query: strPtr(query.String()),
 	}
 
-	result, err := c.workItemClient.QueryByWiql(c.ctx, queryArgs)
+	result, err := c.workItemClient.QueryByWiql(ctx, workitemtracking.QueryByWiqlArgs{Wiql: &wiql})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query work items: %w", err)
 	}
 
-	if result.WorkItems == nil || len(*result.WorkItems) == 0 {
-		return []WorkItem{}, nil
+	if result.WorkItems == nil {
+		return []int{}, nil
 	}
 
-	// Extract work item IDs
-	var ids []int
+	ids := make([]int, 0, len(*result.WorkItems))
 	for _, ref := range *result.WorkItems {
 		if ref.Id != nil {
 			ids = append(ids, *ref.Id)
 		}
 	}
+	return ids, nil
+}
 
-	if len(ids) == 0 {
-		return []WorkItem{}, nil
-	}
-
-	// Get full work item details
-	workItemsArgs := workitemtracking.GetWorkItemsArgs{
-		Ids:    &ids,
-		Expand: &workitemtracking.WorkItemExpandValues.All,
-	}
-
-	workItems, err := c.workItemClient.GetWorkItems(c.ctx, workItemsArgs)
+// myWorkItemsQuery selects my unfinished work items, optionally limited to a sprint
+func (c *AzureDevOpsClient) myWorkItemsQuery(ctx context.Context, sprintPath string) (*wiqlQuery, error) {
+	unfinished, err := c.unfinishedCondition(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get work item details: %w", err)
-	}
-
-	// Convert to our WorkItem struct
-	var tasks []WorkItem
-	for _, wi := range *workItems {
-		task := c.convertWorkItem(wi)
-		tasks = append(tasks, task)
+		return nil, err
 	}
-
-	return tasks, nil
-}
-
-// GetWorkItemsCount returns total count of work items
-func (c *AzureDevOpsClient) GetWorkItemsCount() (int, error) {
-	return c.GetWorkItemsCountForSprint("")
-}
-
-// GetWorkItemsCountForSprint returns count of work items for a specific sprint
-func (c *AzureDevOpsClient) GetWorkItemsCountForSprint(sprintPath string) (int, error) {
-	// Query for total count of work items
-	query := fmt.Sprintf(`
-		SELECT [System.Id]
-		FROM WorkItems
-		WHERE [System.TeamProject] = '%s'
-		AND [System.AssignedTo] = @Me
-		AND [System.State] <> 'Closed'
-		AND [System.State] <> 'Removed'`, c.project)
-
+	query := newWIQLQuery().where(
+		wiqlEq("System.TeamProject", c.project),
+		wiqlEq("System.AssignedTo", wiqlMe),
+		unfinished,
+	)
 	// Add sprint filter if provided
 	if sprintPath != "" {
-		query += fmt.Sprintf("\nAND [System.IterationPath] = '%s'", sprintPath)
-	}
-
-	wiql := workitemtracking.Wiql{
-		Query: strPtr(query),
-	}
-
-	queryArgs := workitemtracking.QueryByWiqlArgs{
-		Wiql: &wiql,
-	}
-
-	result, err := c.workItemClient.QueryByWiql(c.ctx, queryArgs)
-	if err != nil {
-		return 0, fmt.Errorf("failed to query work items count: %w", err)
-	}
-
-	if result.WorkItems == nil {
-		return 0, nil
+		query.where(wiqlEq("System.IterationPath", sprintPath))
 	}
-
-	return len(*result.WorkItems), nil
+	return query, nil
 }
 
 // UpdateWorkItemState updates the state of a work item
-func (c *AzureDevOpsClient) UpdateWorkItemState(workItemID int, newState string) error {
+func (c *AzureDevOpsClient) UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error {
 	// Create a patch document to update the state
 	op := webapi.OperationValues.Add
 	path := "/fields/System.State"
@@ -188,32 +190,60 @@ func (c *AzureDevOpsClient) UpdateWorkItemState(workItemID int, newState string)
 		Document: &patchDocument,
 	}
 
-	_, err := c.workItemClient.UpdateWorkItem(c.ctx, updateArgs)
+	_, err := c.workItemClient.UpdateWorkItem(ctx, updateArgs)
 	if err != nil {
-		return fmt.Errorf("failed to update work item state: %w", err)
+		return fmt.Errorf("failed to update work item state: %w", ruleViolationFromError(err))
 	}
 
 	return nil
 }
 
 // UpdateWorkItem updates multiple fields of a work item
-func (c *AzureDevOpsClient) UpdateWorkItem(workItemID int, updates map[string]interface{}) error {
-	// Create a patch document with multiple operations
+func (c *AzureDevOpsClient) UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error {
+	patchDocument, err := c.patchDocument(updates)
+	if err != nil {
+		return err
+	}
+
+	updateArgs := workitemtracking.UpdateWorkItemArgs{
+		Id:       &workItemID,
+		Document: &patchDocument,
+	}
+
+	_, err = c.workItemClient.UpdateWorkItem(ctx, updateArgs)
+	if err != nil {
+		return fmt.Errorf("failed to update work item: %w", ruleViolationFromError(err))
+	}
+
+	return nil
+}
+
+// patchDocument builds the patch operations for the updates; keys with a dot are field
+// reference names
+func (c *AzureDevOpsClient) patchDocument(updates map[string]interface{}) ([]webapi.JsonPatchOperation, error) {
 	op := webapi.OperationValues.Add
 	var patchDocument []webapi.JsonPatchOperation
 
 	// Map of field keys to their Azure DevOps field paths
 	fieldMap := map[string]string{
-		"title":       "/fields/System.Title",
-		"description": "/fields/System.Description",
-		"tags":        "/fields/System.Tags",
-		"priority":    "/fields/Microsoft.VSTS.Common.Priority",
-		"state":       "/fields/System.State",
+		"title":         "/fields/System.Title",
+		"description":   "/fields/System.Description",
+		"tags":          "/fields/System.Tags",
+		"priority":      "/fields/Microsoft.VSTS.Common.Priority",
+		"state":         "/fields/System.State",
+		"iterationPath": "/fields/System.IterationPath",
+		"comment":       "/fields/System.History", // Writing History adds a discussion comment
 	}
 
-	// Build patch operations for each field
 	for key, value := range updates {
-		if fieldPath, ok := fieldMap[key]; ok {
+		fieldPath, ok := fieldMap[key]
+		if !ok && strings.Contains(key, ".") {
+			fieldPath, ok = "/fields/"+key, true
+		}
+		if key == "iterationPath" && value == "" {
+			value = c.project // Empty path means project root (backlog)
+		}
+		if ok {
 			path := fieldPath
 			patchDocument = append(patchDocument, webapi.JsonPatchOperation{
 				Op:    &op,
@@ -224,29 +254,117 @@ func (c *AzureDevOpsClient) UpdateWorkItem(workItemID int, updates map[string]in
 	}
 
 	if len(patchDocument) == 0 {
-		return fmt.Errorf("no valid fields to update")
+		return nil, fmt.Errorf("no valid fields to update")
 	}
+	return patchDocument, nil
+}
 
-	updateArgs := workitemtracking.UpdateWorkItemArgs{
-		Id:       &workItemID,
-		Document: &patchDocument,
+// workItemBatchRequest is one update sent through the work item $batch endpoint
+type workItemBatchRequest struct {
+	Method  string                      `json:"method"`
+	URI     string                      `json:"uri"`
+	Headers map[string]string           `json:"headers"`
+	Body    []webapi.JsonPatchOperation `json:"body"`
+}
+
+// workItemBatchResponse holds the outcome of each request of a $batch call, in request order
+type workItemBatchResponse struct {
+	Count int `json:"count"`
+	Value []struct {
+		Code int    `json:"code"`
+		Body string `json:"body"` // JSON of the work item, or of the error
+	} `json:"value"`
+}
+
+// workItemBatchAPIVersion is the API version of the $batch endpoint and the updates inside it
+const workItemBatchAPIVersion = "5.0"
+
+// UpdateWorkItems applies the same updates to every work item through the $batch endpoint,
+// one request per maxWorkItemsPerRequest items. The server applies each item on its own,
+// so a rule violation on one item is reported for that item only.
+func (c *AzureDevOpsClient) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	patchDocument, err := c.patchDocument(updates)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make(map[int]error)
+	for start := 0; start < len(workItemIDs); start += maxWorkItemsPerRequest {
+		chunk := workItemIDs[start:min(start+maxWorkItemsPerRequest, len(workItemIDs))]
+		if err := c.sendWorkItemBatch(ctx, chunk, patchDocument, failed); err != nil {
+			return nil, err
+		}
+	}
+	return failed, nil
+}
+
+// sendWorkItemBatch sends one $batch request and records the items that failed
+func (c *AzureDevOpsClient) sendWorkItemBatch(ctx context.Context, workItemIDs []int, patchDocument []webapi.JsonPatchOperation, failed map[int]error) error {
+	requests := make([]workItemBatchRequest, len(workItemIDs))
+	for i, id := range workItemIDs {
+		requests[i] = workItemBatchRequest{
+			Method:  http.MethodPatch,
+			URI:     fmt.Sprintf("/_apis/wit/workitems/%d?api-version=%s", id, workItemBatchAPIVersion),
+			Headers: map[string]string{"Content-Type": "application/json-patch+json"},
+			Body:    patchDocument,
+		}
+	}
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return fmt.Errorf("failed to encode work item batch: %w", err)
 	}
 
-	_, err := c.workItemClient.UpdateWorkItem(c.ctx, updateArgs)
+	client := c.connection.GetClientByUrl(c.organizationURL)
+	batchURL := strings.TrimSuffix(c.organizationURL, "/") + "/_apis/wit/$batch"
+	request, err := client.CreateRequestMessage(ctx, http.MethodPost, batchURL, workItemBatchAPIVersion,
+		bytes.NewReader(body), azuredevops.MediaTypeApplicationJson, azuredevops.MediaTypeApplicationJson, nil)
 	if err != nil {
-		return fmt.Errorf("failed to update work item: %w", err)
+		return fmt.Errorf("failed to create work item batch request: %w", err)
+	}
+	response, err := client.SendRequest(request)
+	if err != nil {
+		return fmt.Errorf("failed to update work items: %w", err)
+	}
+
+	var result workItemBatchResponse
+	if err := client.UnmarshalBody(response, &result); err != nil {
+		return fmt.Errorf("failed to decode work item batch response: %w", err)
 	}
 
+	for i, id := range workItemIDs {
+		if i >= len(result.Value) {
+			failed[id] = fmt.Errorf("failed to update work item: no response for work item %d", id)
+			continue
+		}
+		if code := result.Value[i].Code; code < 200 || code >= 300 {
+			failed[id] = fmt.Errorf("failed to update work item: %w", ruleViolationFromError(batchItemError(code, result.Value[i].Body)))
+		}
+	}
 	return nil
 }
 
+// batchItemError converts the error of one $batch item into the error the SDK returns for a
+// single request, so rule violations and throttling are recognized the same way
+func batchItemError(code int, body string) error {
+	var wrapped azuredevops.WrappedError
+	if err := json.Unmarshal([]byte(body), &wrapped); err != nil || wrapped.Message == nil {
+		message := fmt.Sprintf("Request returned status: %d", code)
+		if body != "" {
+			message = body
+		}
+		wrapped = azuredevops.WrappedError{Message: &message}
+	}
+	wrapped.StatusCode = &code
+	return wrapped
+}
+
 // DeleteWorkItem deletes a work item by ID
-func (c *AzureDevOpsClient) DeleteWorkItem(workItemID int) error {
+func (c *AzureDevOpsClient) DeleteWorkItem(ctx context.Context, workItemID int) error {
 	deleteArgs := workitemtracking.DeleteWorkItemArgs{
 		Id: &workItemID,
 	}
 
-	_, err := c.workItemClient.DeleteWorkItem(c.ctx, deleteArgs)
+	_, err := c.workItemClient.DeleteWorkItem(ctx, deleteArgs)
 	if err != nil {
 		return fmt.Errorf("failed to delete work item: %w", err)
 	}
@@ -255,7 +373,7 @@ func (c *AzureDevOpsClient) DeleteWorkItem(workItemID int) error {
 }
 
 // MoveWorkItemToSprint moves a work item to a specific sprint by updating its iteration path
-func (c *AzureDevOpsClient) MoveWorkItemToSprint(workItemID int, iterationPath string) error {
+func (c *AzureDevOpsClient) MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error {
 	op := webapi.OperationValues.Add
 	var patchDocument []webapi.JsonPatchOperation
 
@@ -278,7 +396,7 @@ func (c *AzureDevOpsClient) MoveWorkItemToSprint(workItemID int, iterationPath s
 		Document: &patchDocument,
 	}
 
-	_, err := c.workItemClient.UpdateWorkItem(c.ctx, updateArgs)
+	_, err := c.workItemClient.UpdateWorkItem(ctx, updateArgs)
 	if err != nil {
 		return fmt.Errorf("failed to move work item to sprint: %w", err)
 	}
@@ -287,7 +405,7 @@ func (c *AzureDevOpsClient) MoveWorkItemToSprint(workItemID int, iterationPath s
 }
 
 // CreateWorkItem creates a new work item in Azure DevOps
-func (c *AzureDevOpsClient) CreateWorkItem(title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+func (c *AzureDevOpsClient) CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
 	// Build patch document
 	op := webapi.OperationValues.Add
 	var patchDoc []webapi.JsonPatchOperation
@@ -348,7 +466,7 @@ func (c *AzureDevOpsClient) CreateWorkItem(title string, workItemType string, it
 		Type:     &workItemType,
 	}
 
-	createdItem, err := c.workItemClient.CreateWorkItem(c.ctx, args)
+	createdItem, err := c.workItemClient.CreateWorkItem(ctx, args)
 	if err != nil {
 		// Provide detailed error information for debugging
 		return nil, fmt.Errorf("failed to create work item (Type: %s, Project: %s, IterationPath: %s, HasParent: %v): %w",
@@ -360,88 +478,198 @@ func (c *AzureDevOpsClient) CreateWorkItem(title string, workItemType string, it
 	return &workItem, nil
 }
 
-// GetWorkItemTypeStates returns valid states for a work item type
-func (c *AzureDevOpsClient) GetWorkItemTypeStates(workItemType string) ([]string, map[string]string, error) {
-	// Get the work item type definition to get valid states with categories
-	getTypeArgs := workitemtracking.GetWorkItemTypeArgs{
-		Project: &c.project,
-		Type:    &workItemType,
+// GetWorkItemHistory returns the field changes of every revision of a work item, oldest first
+func (c *AzureDevOpsClient) GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error) {
+	const pageSize = 200
+
+	var history []WorkItemUpdate
+	for skip := 0; ; skip += pageSize {
+		top := pageSize
+		skipCount := skip
+		updates, err := c.workItemClient.GetUpdates(ctx, workitemtracking.GetUpdatesArgs{
+			Id:      &workItemID,
+			Project: &c.project,
+			Top:     &top,
+			Skip:    &skipCount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history for #%d: %w", workItemID, err)
+		}
+		if updates == nil {
+			break
+		}
+
+		for _, update := range *updates {
+			history = append(history, convertWorkItemUpdate(update))
+		}
+		if len(*updates) < pageSize {
+			break
+		}
 	}
 
-	workItemTypeDef, err := c.workItemClient.GetWorkItemType(c.ctx, getTypeArgs)
+	return history, nil
+}
+
+// GetWorkItemTypeStates returns the states of a work item type in process order, with their categories
+func (c *AzureDevOpsClient) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	states, err := c.getTypeStates(ctx, workItemType)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get work item type: %w", err)
+		return nil, nil, err
 	}
+	return states.names, states.categories, nil
+}
 
-	// Extract states and their categories
-	var states []string
-	stateCategories := make(map[string]string)
-
-	if workItemTypeDef.States != nil && len(*workItemTypeDef.States) > 0 {
-		// Use the States field which includes category information
-		for _, state := range *workItemTypeDef.States {
-			if state.Name != nil && *state.Name != "" {
-				stateName := *state.Name
-				states = append(states, stateName)
+// GetWorkItemTypeTransitions returns the states each state of a work item type may move to.
+// The empty state holds the states a new work item may start in.
+func (c *AzureDevOpsClient) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	workItemTypeDef, err := c.workItemClient.GetWorkItemType(ctx, workitemtracking.GetWorkItemTypeArgs{
+		Project: &c.project,
+		Type:    &workItemType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item type: %w", err)
+	}
 
-				// Map state name to its category
-				if state.Category != nil {
-					stateCategories[stateName] = *state.Category
-				} else {
-					stateCategories[stateName] = "Unknown"
-				}
+	transitions := make(map[string][]string)
+	if workItemTypeDef.Transitions == nil {
+		return transitions, nil
+	}
+	for from, targets := range *workItemTypeDef.Transitions {
+		for _, transition := range targets {
+			if transition.To != nil && *transition.To != "" {
+				transitions[from] = append(transitions[from], *transition.To)
 			}
 		}
-	} else {
-		// Fallback: Extract unique states from transitions if States field is not available
-		stateMap := make(map[string]bool)
+	}
+	return transitions, nil
+}
 
-		if workItemTypeDef.Transitions != nil {
-			transitions := *workItemTypeDef.Transitions
+// GetRequiredFields validates a state change without saving it and returns the
+// fields that must be set for the process to accept it
+func (c *AzureDevOpsClient) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	op := webapi.OperationValues.Add
+	path := "/fields/System.State"
+	validateOnly := true
+	patchDocument := []webapi.JsonPatchOperation{{Op: &op, Path: &path, Value: newState}}
 
-			// Add all "from" states (keys in the map)
-			for fromState := range transitions {
-				if fromState != "" {
-					stateMap[fromState] = true
-				}
-			}
+	_, err := c.workItemClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+		Id:           &workItemID,
+		Document:     &patchDocument,
+		ValidateOnly: &validateOnly,
+	})
+	if err == nil {
+		return nil, nil
+	}
 
-			// Add all "to" states
-			for _, transitionList := range transitions {
-				if transitionList != nil {
-					for _, transition := range transitionList {
-						if transition.To != nil && *transition.To != "" {
-							stateMap[*transition.To] = true
-						}
-					}
-				}
-			}
-		}
+	var violation *RuleViolationError
+	if !errors.As(ruleViolationFromError(err), &violation) {
+		return nil, fmt.Errorf("failed to validate state change: %w", err)
+	}
+	missing, ok := violation.missingFields()
+	if !ok {
+		return nil, violation
+	}
 
-		// Convert map to slice
-		for state := range stateMap {
-			states = append(states, state)
-			// Guess category based on common state names
-			stateCategories[state] = guessStateCategory(state)
+	fields := make([]RequiredField, 0, len(missing))
+	for _, field := range missing {
+		allowedValues, err := c.getFieldAllowedValues(ctx, workItemType, field.ReferenceName)
+		if err != nil {
+			return nil, err
 		}
+		fields = append(fields, RequiredField{
+			ReferenceName: field.ReferenceName,
+			Name:          field.Name,
+			AllowedValues: allowedValues,
+		})
 	}
+	return fields, nil
+}
 
-	// If no states found, return a default set
-	if len(states) == 0 {
-		defaultStates := []string{"New", "Active", "Closed"}
-		for _, state := range defaultStates {
-			stateCategories[state] = guessStateCategory(state)
-		}
-		return defaultStates, stateCategories, nil
+// getFieldAllowedValues returns the values a field of a work item type is limited to
+func (c *AzureDevOpsClient) getFieldAllowedValues(ctx context.Context, workItemType string, referenceName string) ([]string, error) {
+	field, err := c.workItemClient.GetWorkItemTypeFieldWithReferences(ctx, workitemtracking.GetWorkItemTypeFieldWithReferencesArgs{
+		Project: &c.project,
+		Type:    &workItemType,
+		Field:   &referenceName,
+		Expand:  &workitemtracking.WorkItemTypeFieldsExpandLevelValues.AllowedValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed values of %s: %w", referenceName, err)
 	}
 
-	return states, stateCategories, nil
+	var values []string
+	if field.AllowedValues != nil {
+		for _, value := range *field.AllowedValues {
+			if s, ok := value.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values, nil
 }
 
 // =============================================================================
 // HELPER FUNCTIONS
 // =============================================================================
 
+// asWrappedError finds the server error in err; the SDK returns it both by value and by pointer
+func asWrappedError(err error) (azuredevops.WrappedError, bool) {
+	var wrapped azuredevops.WrappedError
+	var wrappedPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedPtr) && wrappedPtr != nil {
+		return *wrappedPtr, true
+	}
+	if errors.As(err, &wrapped) {
+		return wrapped, true
+	}
+	return wrapped, false
+}
+
+// ruleFieldNamePattern extracts the friendly field name from a TF401320 rule error message
+var ruleFieldNamePattern = regexp.MustCompile(`Rule Error for field (.+?)\. Error code`)
+
+// ruleViolationFromError converts a RuleValidationException from the server into a
+// RuleViolationError; other errors are returned unchanged
+func ruleViolationFromError(err error) error {
+	wrapped, ok := asWrappedError(err)
+	if !ok {
+		return err
+	}
+	if wrapped.TypeKey == nil || *wrapped.TypeKey != "RuleValidationException" {
+		return err
+	}
+
+	violation := &RuleViolationError{}
+	if wrapped.Message != nil {
+		violation.Message = *wrapped.Message
+	}
+	if wrapped.CustomProperties != nil {
+		entries, _ := (*wrapped.CustomProperties)["RuleValidationErrors"].([]interface{})
+		for _, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			referenceName, _ := fields["fieldReferenceName"].(string)
+			flags, _ := fields["fieldStatusFlags"].(string)
+			message, _ := fields["errorMessage"].(string)
+
+			name := referenceName[strings.LastIndex(referenceName, ".")+1:]
+			if match := ruleFieldNamePattern.FindStringSubmatch(message); match != nil {
+				name = match[1]
+			}
+			problem, needsValue := describeFieldStatus(referenceName, flags)
+			violation.Fields = append(violation.Fields, FieldViolation{
+				ReferenceName: referenceName,
+				Name:          name,
+				Problem:       problem,
+				NeedsValue:    needsValue,
+			})
+		}
+	}
+	return violation
+}
+
 // convertWorkItem converts Azure DevOps WorkItem to our WorkItem struct
 func (c *AzureDevOpsClient) convertWorkItem(wi workitemtracking.WorkItem) WorkItem {
 	fields := *wi.Fields
@@ -485,6 +713,10 @@ func (c *AzureDevOpsClient) convertWorkItem(wi workitemtracking.WorkItem) WorkIt
 		task.Priority = int(priority)
 	}
 
+	if remainingWork, ok := fields["Microsoft.VSTS.Scheduling.RemainingWork"].(float64); ok {
+		task.RemainingWork = remainingWork
+	}
+
 	if createdDate, ok := fields["System.CreatedDate"].(string); ok {
 		task.CreatedDate = formatDate(createdDate)
 	}
@@ -501,6 +733,21 @@ func (c *AzureDevOpsClient) convertWorkItem(wi workitemtracking.WorkItem) WorkIt
 		task.AreaPath = areaPath
 	}
 
+	// List rows carry the parent as a field instead of a relation
+	if parentID, ok := fields["System.Parent"].(float64); ok {
+		id := int(parentID)
+		task.ParentID = &id
+	}
+
+	// Extract attachments from relations
+	if wi.Relations != nil {
+		for _, relation := range *wi.Relations {
+			if relation.Rel != nil && *relation.Rel == "AttachedFile" {
+				task.Attachments = append(task.Attachments, convertAttachmentRelation(relation))
+			}
+		}
+	}
+
 	// Extract parent relationship from relations
 	if wi.Relations != nil {
 		for _, relation := range *wi.Relations {
@@ -524,6 +771,87 @@ func (c *AzureDevOpsClient) convertWorkItem(wi workitemtracking.WorkItem) WorkIt
 	return task
 }
 
+// convertAttachmentRelation converts an AttachedFile relation to our Attachment struct
+func convertAttachmentRelation(relation workitemtracking.WorkItemRelation) Attachment {
+	var attachment Attachment
+
+	if relation.Url != nil {
+		attachment.URL = *relation.Url
+		// Attachment URL format: .../_apis/wit/attachments/{id}
+		parts := strings.Split(strings.TrimSuffix(*relation.Url, "/"), "/")
+		attachment.ID = parts[len(parts)-1]
+	}
+
+	if relation.Attributes == nil {
+		return attachment
+	}
+	attributes := *relation.Attributes
+
+	if name, ok := attributes["name"].(string); ok {
+		attachment.Name = name
+	}
+	if size, ok := attributes["resourceSize"].(float64); ok {
+		attachment.Size = int64(size)
+	}
+	if added, ok := attributes["resourceCreatedDate"].(string); ok {
+		attachment.AddedDate = formatDate(added)
+	} else if added, ok := attributes["authorizedDate"].(string); ok {
+		attachment.AddedDate = formatDate(added)
+	}
+	if comment, ok := attributes["comment"].(string); ok {
+		attachment.Comment = comment
+	}
+
+	return attachment
+}
+
+// convertWorkItemUpdate converts an Azure DevOps work item update to our WorkItemUpdate struct
+func convertWorkItemUpdate(update workitemtracking.WorkItemUpdate) WorkItemUpdate {
+	result := WorkItemUpdate{
+		Rev: getIntField(update.Rev),
+	}
+
+	if update.RevisedBy != nil && update.RevisedBy.DisplayName != nil {
+		result.ChangedBy = *update.RevisedBy.DisplayName
+	}
+
+	if update.Fields == nil {
+		return result
+	}
+	fields := *update.Fields
+
+	// RevisedDate is when the revision was superseded, so prefer the new ChangedDate
+	if changed, ok := fields["System.ChangedDate"]; ok {
+		if changedDate, ok := changed.NewValue.(string); ok {
+			result.ChangedDate = formatDate(changedDate)
+		}
+	} else if update.RevisedDate != nil {
+		result.ChangedDate = update.RevisedDate.Time.Format("2006-01-02T15:04:05")
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if !ignoredHistoryFields[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := fields[name]
+		change := WorkItemFieldChange{
+			Field:    fieldDisplayName(name),
+			OldValue: formatFieldValue(field.OldValue),
+			NewValue: formatFieldValue(field.NewValue),
+		}
+		if change.OldValue != change.NewValue {
+			result.Changes = append(result.Changes, change)
+		}
+	}
+
+	return result
+}
+
 // stripHTML removes HTML tags from a string
 func stripHTML(html string) string {
 	// Simple HTML tag removal - for production, use a proper HTML parser
@@ -558,29 +886,3 @@ func formatDate(dateStr string) string {
 	}
 	return dateStr
 }
-
-// guessStateCategory attempts to categorize a state based on common naming patterns
-func guessStateCategory(state string) string {
-	stateLower := strings.ToLower(state)
-
-	// Proposed/Pending states
-	if strings.Contains(stateLower, "new") || strings.Contains(stateLower, "proposed") ||
-		strings.Contains(stateLower, "backlog") || strings.Contains(stateLower, "to do") {
-		return "Proposed"
-	}
-
-	// Completed states
-	if strings.Contains(stateLower, "closed") || strings.Contains(stateLower, "done") ||
-		strings.Contains(stateLower, "completed") {
-		return "Completed"
-	}
-
-	// Removed states
-	if strings.Contains(stateLower, "removed") || strings.Contains(stateLower, "cut") ||
-		strings.Contains(stateLower, "cancelled") {
-		return "Removed"
-	}
-
-	// InProgress states (default for anything active)
-	return "InProgress"
-}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+// loadRecordedWorkItems reads a recorded `GET _apis/wit/workitems?$expand=all` response
+func loadRecordedWorkItems(t *testing.T) []workitemtracking.WorkItem {
+	t.Helper()
+	data, err := os.ReadFile("testdata/workitems_expand_all.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var response struct {
+		Value []workitemtracking.WorkItem `json:"value"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+	return response.Value
+}
+
+// projectRecordedWorkItem returns the item as the server sends it when only listFields are requested
+func projectRecordedWorkItem(wi workitemtracking.WorkItem) workitemtracking.WorkItem {
+	fields := make(map[string]interface{})
+	for _, name := range listFields {
+		if value, ok := (*wi.Fields)[name]; ok {
+			fields[name] = value
+		}
+	}
+	return workitemtracking.WorkItem{Id: wi.Id, Rev: wi.Rev, Url: wi.Url, Fields: &fields}
+}
+
+// payloadSize returns the size of a value encoded as compact JSON
+func payloadSize(t *testing.T, v interface{}) int {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	return len(data)
+}
+
+func TestListFieldsPayloadReduction(t *testing.T) {
+	recorded := loadRecordedWorkItems(t)
+	client := &AzureDevOpsClient{}
+
+	projected := make([]workitemtracking.WorkItem, len(recorded))
+	for i, wi := range recorded {
+		projected[i] = projectRecordedWorkItem(wi)
+
+		// List rows hold the same list fields as the complete item, parent included
+		full := client.convertWorkItem(wi)
+		row := client.convertWorkItem(projected[i])
+		row.Partial = true
+		want := full.listProjection()
+		got, _ := json.Marshal(row)
+		wantJSON, _ := json.Marshal(want)
+		if string(got) != string(wantJSON) || (row.ParentID == nil) != (want.ParentID == nil) ||
+			(row.ParentID != nil && *row.ParentID != *want.ParentID) {
+			t.Errorf("item %d: list row = %s, want %s", full.ID, got, wantJSON)
+		}
+	}
+
+	fullSize := payloadSize(t, recorded)
+	listSize := payloadSize(t, projected)
+	t.Logf("recorded fixture: expand=all %d bytes, list fields %d bytes (%.0f%% smaller)",
+		fullSize, listSize, 100*(1-float64(listSize)/float64(fullSize)))
+	if listSize*2 > fullSize {
+		t.Errorf("list fields payload = %d bytes, want under half of %d", listSize, fullSize)
+	}
+}
+
+func TestDummyBackendListRowsPayloadReduction(t *testing.T) {
+	db := NewDummyBackend()
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	ids, err := db.GetSprintWorkItemIDs(context.Background(), curr.Path)
+	if err != nil || len(ids) == 0 {
+		t.Fatalf("expected items in the current sprint, got %d (err: %v)", len(ids), err)
+	}
+
+	rows, _ := db.GetWorkItemsByIDs(context.Background(), ids)
+	details, _ := db.GetWorkItemDetailsByIDs(context.Background(), ids)
+	for i := range rows {
+		if !rows[i].Partial || details[i].Partial {
+			t.Fatalf("item %d: row Partial = %v, details Partial = %v", rows[i].ID, rows[i].Partial, details[i].Partial)
+		}
+		if rows[i].Title != details[i].Title || rows[i].Description != "" {
+			t.Errorf("item %d: list row should keep the title and leave out the description", rows[i].ID)
+		}
+	}
+
+	fullSize := payloadSize(t, details)
+	listSize := payloadSize(t, rows)
+	t.Logf("dummy backend: details %d bytes, list rows %d bytes (%.0f%% smaller)",
+		fullSize, listSize, 100*(1-float64(listSize)/float64(fullSize)))
+	if listSize >= fullSize {
+		t.Errorf("list rows = %d bytes, want fewer than the %d bytes of complete items", listSize, fullSize)
+	}
+}
+
+func TestGetWorkItemsByIDsKeepsOrder(t *testing.T) {
+	db := NewDummyBackend()
+	ids, _ := db.GetSprintWorkItemIDs(context.Background(), "")
+	if len(ids) < 3 {
+		t.Fatalf("expected at least 3 items, got %d", len(ids))
+	}
+	want := []int{ids[2], ids[0], ids[1]}
+
+	rows, err := db.GetWorkItemsByIDs(context.Background(), append(slices.Clone(want), 999999))
+	if err != nil {
+		t.Fatalf("GetWorkItemsByIDs() error: %v", err)
+	}
+	var got []int
+	for _, row := range rows {
+		got = append(got, row.ID)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("GetWorkItemsByIDs() = %v, want %v without the unknown ID", got, want)
+	}
+}
+
+func TestUpdateWorkItemsBatch(t *testing.T) {
+	ruleError := `{"$id":"1","message":"TF401320: Rule Error for field Reason. Error code: Required, InvalidEmpty.","typeKey":"RuleValidationException",` +
+		`"customProperties":{"RuleValidationErrors":[{"fieldReferenceName":"System.Reason","fieldStatusFlags":"required, invalidEmpty",` +
+		`"errorMessage":"TF401320: Rule Error for field Reason. Error code: Required, InvalidEmpty."}]}}`
+
+	var requests []workItemBatchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/_apis/wit/$batch" {
+			t.Errorf("request = %s %s, want POST /_apis/wit/$batch", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			t.Fatalf("failed to decode batch: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"count":3,"value":[{"code":200,"body":"{\"id\":11}"},{"code":400,"body":%q},{"code":429,"body":""}]}`, ruleError)
+	}))
+	defer server.Close()
+
+	client := &AzureDevOpsClient{
+		connection:      azuredevops.NewPatConnection(server.URL, "token"),
+		organizationURL: server.URL,
+		project:         "Demo",
+	}
+	failed, err := client.UpdateWorkItems(context.Background(), []int{11, 12, 13}, map[string]interface{}{"iterationPath": ""})
+	if err != nil {
+		t.Fatalf("UpdateWorkItems() error: %v", err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("batch held %d requests, want one per item", len(requests))
+	}
+	if got, want := requests[1].URI, "/_apis/wit/workitems/12?api-version="+workItemBatchAPIVersion; got != want {
+		t.Errorf("request URI = %q, want %q", got, want)
+	}
+	if body := requests[0].Body; len(body) != 1 || *body[0].Path != "/fields/System.IterationPath" || body[0].Value != "Demo" {
+		t.Errorf("request body = %+v, want the iteration path set to the project root", body)
+	}
+
+	if _, ok := failed[11]; ok || len(failed) != 2 {
+		t.Fatalf("failed = %v, want items 12 and 13", failed)
+	}
+	var violation *RuleViolationError
+	if !errors.As(failed[12], &violation) || len(violation.Fields) != 1 || violation.Fields[0].Name != "Reason" {
+		t.Errorf("item 12 error = %v, want a rule violation on Reason", failed[12])
+	}
+	if !isRetryableError(failed[13]) {
+		t.Errorf("item 13 error = %v, want a retryable throttling error", failed[13])
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,6 +19,83 @@ type Config struct {
 	OrganizationURL string `yaml:"organization_url"`
 	Project         string `yaml:"project"`
 	Team            string `yaml:"team"`
+
+	// Backend is where work items live: azure-devops (default), github or local
+	Backend string `yaml:"backend,omitempty"`
+
+	// GitHub selects the repository of the github backend
+	GitHub GitHubConfig `yaml:"github,omitempty"`
+
+	// Local selects the directory of the local backend
+	Local LocalConfig `yaml:"local,omitempty"`
+
+	// BranchTemplate names git branches created for work items.
+	// Placeholders: {id}, {title}, {type}, {user}
+	BranchTemplate string `yaml:"branch_template,omitempty"`
+
+	// ExportColumns selects the CSV columns for exports and `hippo list -o csv`
+	ExportColumns []string `yaml:"export_columns,omitempty"`
+
+	// Keybindings overrides the keys of named actions (see defaultKeyBindings)
+	Keybindings map[string]KeyList `yaml:"keybindings,omitempty"`
+
+	// ListColumns selects the columns beside the title in the list view, per mode (sprint, backlog)
+	ListColumns map[string][]string `yaml:"list_columns,omitempty"`
+
+	// PageSize is the number of items loaded at a time in each list (default 40)
+	PageSize int `yaml:"page_size,omitempty"`
+
+	// RequestTimeout is how many seconds a call to the server may take (default 30).
+	// Attachment transfers are not bounded, and sprint history bounds each item's request.
+	RequestTimeout int `yaml:"request_timeout,omitempty"`
+
+	// Theme is auto (default), dark, light, high-contrast or a palette from Themes
+	Theme  string                  `yaml:"theme,omitempty"`
+	Themes map[string]ThemePalette `yaml:"themes,omitempty"`
+}
+
+// GitHubConfig holds the settings of the github backend
+type GitHubConfig struct {
+	Owner string `yaml:"owner"`
+	Repo  string `yaml:"repo"`
+
+	// APIURL is the REST API of a GitHub Enterprise Server, e.g. https://github.example.com/api/v3
+	APIURL string `yaml:"api_url,omitempty"`
+}
+
+// LocalConfig holds the settings of the local backend
+type LocalConfig struct {
+	// Path is the directory of the work item files; ~ is the home directory
+	Path string `yaml:"path"`
+
+	// User is the name new items are assigned to (default: $USER)
+	User string `yaml:"user,omitempty"`
+
+	// States are the states items can be in, in process order (default: New, Active,
+	// Closed and Removed)
+	States []LocalState `yaml:"states,omitempty"`
+}
+
+// LocalState is a state of the local backend and its category, which tells finished
+// states apart
+type LocalState struct {
+	Name     string `yaml:"name"`
+	Category string `yaml:"category"` // Proposed, InProgress, Resolved, Completed or Removed
+}
+
+// stateCategoryNames are the state categories a process can use
+var stateCategoryNames = []string{"Proposed", "InProgress", "Resolved", "Completed", "Removed"}
+
+// Backends selectable with the backend setting
+const (
+	azureDevOpsBackend = "azure-devops"
+	githubBackend      = "github"
+	localBackend       = "local"
+)
+
+// usesAzureDevOps reports whether the config selects the Azure DevOps backend
+func (c *Config) usesAzureDevOps() bool {
+	return c.Backend == "" || c.Backend == azureDevOpsBackend
 }
 
 // ConfigSource tracks the source of each configuration value
@@ -92,6 +172,10 @@ func LoadConfig(flags *FlagConfig) (*Config, *ConfigSource, error) {
 		source.Team = "env"
 	}
 
+	if branchTemplate := os.Getenv("HIPPO_BRANCH_TEMPLATE"); branchTemplate != "" {
+		config.BranchTemplate = branchTemplate
+	}
+
 	// 3. Merge with CLI flags (explicit flags override everything)
 	if flags.OrganizationURL != nil {
 		config.OrganizationURL = *flags.OrganizationURL
@@ -200,6 +284,22 @@ func getConfigPath(flags *FlagConfig) (string, error) {
 
 // ValidateConfig validates that all required fields are present
 func ValidateConfig(config *Config) error {
+	switch config.Backend {
+	case "", azureDevOpsBackend:
+	case githubBackend:
+		if config.GitHub.Owner == "" || config.GitHub.Repo == "" {
+			return fmt.Errorf("github.owner and github.repo are required for the github backend")
+		}
+		return nil
+	case localBackend:
+		if config.Local.Path == "" {
+			return fmt.Errorf("local.path is required for the local backend")
+		}
+		return validateLocalStates(config.Local.States)
+	default:
+		return fmt.Errorf("unknown backend %q (use %s, %s or %s)", config.Backend, azureDevOpsBackend, githubBackend, localBackend)
+	}
+
 	if config.OrganizationURL == "" {
 		return fmt.Errorf("organization_url is required")
 	}
@@ -213,6 +313,33 @@ func ValidateConfig(config *Config) error {
 	return nil
 }
 
+// requestTimeout returns the configured request timeout, or the default
+func (c *Config) requestTimeout() time.Duration {
+	if c.RequestTimeout > 0 {
+		return time.Duration(c.RequestTimeout) * time.Second
+	}
+	return defaultRequestTimeout
+}
+
+// validateLocalStates checks that the configured local states are named once each and
+// have a known category
+func validateLocalStates(states []LocalState) error {
+	seen := make(map[string]bool, len(states))
+	for _, state := range states {
+		if state.Name == "" {
+			return fmt.Errorf("local.states: every state needs a name")
+		}
+		if seen[state.Name] {
+			return fmt.Errorf("local.states: %q is listed twice", state.Name)
+		}
+		seen[state.Name] = true
+		if !slices.Contains(stateCategoryNames, state.Category) {
+			return fmt.Errorf("local.states: %q has category %q (use %s)", state.Name, state.Category, strings.Join(stateCategoryNames, ", "))
+		}
+	}
+	return nil
+}
+
 // isConfigVersionCompatible checks if the config version is compatible
 func isConfigVersionCompatible(config *Config) bool {
 	// Version must be non-zero and match current version
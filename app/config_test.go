@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -48,6 +50,70 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "github backend needs no Azure DevOps settings",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       githubBackend,
+				GitHub:        GitHubConfig{Owner: "acme", Repo: "web"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "github backend without repo",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       githubBackend,
+				GitHub:        GitHubConfig{Owner: "acme"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "local backend",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       localBackend,
+				Local:         LocalConfig{Path: "~/tasks"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "local backend with states",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       localBackend,
+				Local:         LocalConfig{Path: "~/tasks", States: []LocalState{{Name: "Todo", Category: "Proposed"}, {Name: "Done", Category: "Completed"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "local state with an unknown category",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       localBackend,
+				Local:         LocalConfig{Path: "~/tasks", States: []LocalState{{Name: "Done", Category: "Finished"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "local backend without path",
+			config: &Config{
+				ConfigVersion: 1,
+				Backend:       localBackend,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown backend",
+			config: &Config{
+				ConfigVersion:   1,
+				OrganizationURL: "https://dev.azure.com/org",
+				Project:         "project",
+				Team:            "team",
+				Backend:         "jira",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -310,3 +376,57 @@ project: "test-project"
 		t.Errorf("LoadConfig() error = %v, want ErrConfigIncompatible", err)
 	}
 }
+
+func TestValidatePageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int
+		wantErr  bool
+	}{
+		{"unset uses the default", 0, false},
+		{"small page", 5, false},
+		{"largest page", maxPageSize, false},
+		{"negative", -1, true},
+		{"too large", maxPageSize + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUISettings(&Config{PageSize: tt.pageSize})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUISettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	m := model{config: &Config{PageSize: 5}}
+	if m.pageSize() != 5 {
+		t.Errorf("pageSize() = %d, want 5", m.pageSize())
+	}
+	if (model{}).pageSize() != defaultLoadLimit {
+		t.Errorf("pageSize() without config = %d, want %d", (model{}).pageSize(), defaultLoadLimit)
+	}
+}
+
+func TestReloadUsesPageSize(t *testing.T) {
+	m := model{
+		state:       findView,
+		config:      &Config{PageSize: 2},
+		client:      NewDummyBackend(),
+		sprintLists: map[sprintTab]*WorkItemList{},
+	}
+
+	_, cmd := m.handleFindView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the list to reload")
+	}
+	for _, next := range cmd().(tea.BatchMsg) {
+		if msg, ok := next().(tasksLoadedMsg); ok {
+			if len(msg.tasks) != 2 || msg.totalCount <= 2 {
+				t.Errorf("expected a first page of 2 out of %d items, got %d", msg.totalCount, len(msg.tasks))
+			}
+			return
+		}
+	}
+	t.Error("expected the reload to load tasks")
+}
@@ -61,13 +61,15 @@ func initialWizardModel(existingConfig *Config) wizardModel {
 		teamInput.SetValue(existingConfig.Team)
 	}
 
+	_, styles := uiSettings(existingConfig)
+
 	return wizardModel{
 		currentField:   wizardFieldOrg,
 		orgInput:       orgInput,
 		projectInput:   projectInput,
 		teamInput:      teamInput,
 		existingConfig: existingConfig,
-		styles:         NewStyles(),
+		styles:         styles,
 	}
 }
 
@@ -116,13 +118,15 @@ func (m wizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 
-				// Create config
-				config := &Config{
-					ConfigVersion:   CurrentConfigVersion,
-					OrganizationURL: orgURL,
-					Project:         project,
-					Team:            team,
+				// Create config, keeping optional settings from the existing config
+				config := &Config{}
+				if m.existingConfig != nil {
+					*config = *m.existingConfig
 				}
+				config.ConfigVersion = CurrentConfigVersion
+				config.OrganizationURL = orgURL
+				config.Project = project
+				config.Team = team
 
 				// Save config
 				if err := SaveConfig(config); err != nil {
@@ -188,8 +192,8 @@ func (m wizardModel) View() string {
 
 	// Title bar
 	titleStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color(ColorPurple)).
-		Foreground(lipgloss.Color(ColorWhite)).
+		Background(m.styles.Theme.Accent).
+		Foreground(m.styles.Theme.AccentText).
 		Bold(true).
 		Padding(0, 2).
 		Width(80)
@@ -200,14 +204,14 @@ func (m wizardModel) View() string {
 	// Show existing config warning if exists
 	if m.existingConfig != nil && m.existingConfig.ConfigVersion > 0 {
 		warningStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorOrange)).
+			Foreground(m.styles.Theme.Warning).
 			Bold(true)
 
 		content.WriteString(warningStyle.Render("⚠ Configuration file already exists"))
 		content.WriteString("\n")
 
 		detailStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorLightGray)).
+			Foreground(m.styles.Theme.Subtle).
 			Italic(true)
 
 		content.WriteString(detailStyle.Render("  Current settings will be overwritten if you continue."))
@@ -215,7 +219,7 @@ func (m wizardModel) View() string {
 
 		// Show current config
 		configStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)).
+			Foreground(m.styles.Theme.Muted).
 			PaddingLeft(2)
 
 		content.WriteString(configStyle.Render(fmt.Sprintf("Organization: %s", m.existingConfig.OrganizationURL)))
@@ -228,7 +232,7 @@ func (m wizardModel) View() string {
 
 	// Instructions
 	instructionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorLightGray))
+		Foreground(m.styles.Theme.Subtle)
 
 	content.WriteString(instructionStyle.Render("Please provide your Azure DevOps configuration:"))
 	content.WriteString("\n\n")
@@ -249,15 +253,15 @@ func (m wizardModel) View() string {
 	content.WriteString("\n")
 	if m.currentField == wizardFieldConfirm {
 		confirmStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color(ColorGreen)).
-			Foreground(lipgloss.Color(ColorWhite)).
+			Background(m.styles.Theme.Success).
+			Foreground(m.styles.Theme.AccentText).
 			Bold(true).
 			Padding(0, 2)
 
 		content.WriteString(confirmStyle.Render("▶ Save Configuration"))
 	} else {
 		confirmStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)).
+			Foreground(m.styles.Theme.Muted).
 			Padding(0, 2)
 
 		content.WriteString(confirmStyle.Render("  Save Configuration"))
@@ -267,7 +271,7 @@ func (m wizardModel) View() string {
 	// Error message
 	if m.err != "" {
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorRed)).
+			Foreground(m.styles.Theme.Error).
 			Bold(true)
 
 		content.WriteString(errorStyle.Render(fmt.Sprintf("✗ %s", m.err)))
@@ -276,13 +280,13 @@ func (m wizardModel) View() string {
 
 	// Footer with help
 	separatorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorGray))
+		Foreground(m.styles.Theme.Muted)
 
 	content.WriteString(separatorStyle.Render(strings.Repeat("─", 80)))
 	content.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorGray))
+		Foreground(m.styles.Theme.Muted)
 
 	helpText := []string{
 		m.styles.Key.Render("↑/↓, tab/shift+tab") + " navigate",
@@ -298,12 +302,12 @@ func (m wizardModel) View() string {
 
 func (m wizardModel) renderField(content *strings.Builder, label string, input textinput.Model, isFocused bool, hint string) {
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorGreen)).
+		Foreground(m.styles.Theme.Success).
 		Bold(true).
 		Width(20)
 
 	if isFocused {
-		labelStyle = labelStyle.Foreground(lipgloss.Color(ColorPurple))
+		labelStyle = labelStyle.Foreground(m.styles.Theme.Accent)
 	}
 
 	content.WriteString(labelStyle.Render(label + ":"))
@@ -313,7 +317,7 @@ func (m wizardModel) renderField(content *strings.Builder, label string, input t
 
 	if isFocused && hint != "" {
 		hintStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)).
+			Foreground(m.styles.Theme.Muted).
 			Italic(true).
 			PaddingLeft(22)
 
@@ -1,7 +1,25 @@
 package main
 
+import "time"
+
 // Version is set via ldflags during build: -X main.Version=v0.3.0
 var Version = "dev"
 
-// defaultLoadLimit is the number of items to load per request
+// defaultLoadLimit is the default number of items shown per page (page_size in config)
 const defaultLoadLimit = 40
+
+// maxPageSize bounds the configurable page size
+const maxPageSize = 1000
+
+// maxWorkItemsPerRequest is the most work items Azure DevOps returns details for in one request
+const maxWorkItemsPerRequest = 200
+
+// batchWorkers is how many items of a batch operation are sent to the server at once
+const batchWorkers = 4
+
+// batchMaxAttempts is how often a batch operation tries an item before reporting it failed
+const batchMaxAttempts = 4
+
+// defaultRequestTimeout bounds each call to the server unless request_timeout is set; calls
+// that take longer fail with a TimeoutError
+const defaultRequestTimeout = 30 * time.Second
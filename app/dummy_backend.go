@@ -1,20 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
 // DummyBackend provides an in-memory mock implementation of Backend for development.
 // All data is stored in memory and resets when the application restarts.
 type DummyBackend struct {
-	workItems map[int]*WorkItem // In-memory storage keyed by ID
-	nextID    int               // Auto-increment ID for new work items
+	mu        sync.Mutex                 // Guards the maps; batch operations call in from several goroutines
+	workItems map[int]*WorkItem          // In-memory storage keyed by ID
+	revisions map[int][]WorkItemRevision // Revision history keyed by work item ID
+	blobs     map[string][]byte          // Uploaded attachment content keyed by attachment ID
+	nextID    int                        // Auto-increment ID for new work items
 	sprints   struct {
 		previous *Sprint
 		current  *Sprint
 		next     *Sprint
+		all      []*Sprint // Every team sprint, oldest first
 	}
 	project string
 }
@@ -26,6 +34,8 @@ var _ Backend = (*DummyBackend)(nil)
 func NewDummyBackend() *DummyBackend {
 	db := &DummyBackend{
 		workItems: make(map[int]*WorkItem),
+		revisions: make(map[int][]WorkItemRevision),
+		blobs:     make(map[string][]byte),
 		nextID:    1000,
 		project:   "DemoProject",
 	}
@@ -34,7 +44,8 @@ func NewDummyBackend() *DummyBackend {
 	return db
 }
 
-// initializeSprints creates three sprints based on current date
+// initializeSprints creates the team's sprints around the current date.
+// Previous, current, and next are surrounded by older and future sprints for the sprint browser.
 func (db *DummyBackend) initializeSprints() {
 	now := time.Now()
 
@@ -67,6 +78,24 @@ func (db *DummyBackend) initializeSprints() {
 		StartDate: nextStart.Format("2006-01-02"),
 		EndDate:   nextEnd.Format("2006-01-02"),
 	}
+
+	// Two-week sprints before and after the adjacent ones
+	sprintAt := func(number int) *Sprint {
+		start := currStart.AddDate(0, 0, (number-24)*14)
+		return &Sprint{
+			Name:      fmt.Sprintf("Sprint %d", number),
+			Path:      fmt.Sprintf("%s\\Sprint %d", db.project, number),
+			StartDate: start.Format("2006-01-02"),
+			EndDate:   start.AddDate(0, 0, 13).Format("2006-01-02"),
+		}
+	}
+	for number := 18; number <= 22; number++ {
+		db.sprints.all = append(db.sprints.all, sprintAt(number))
+	}
+	db.sprints.all = append(db.sprints.all, db.sprints.previous, db.sprints.current, db.sprints.next)
+	for number := 26; number <= 30; number++ {
+		db.sprints.all = append(db.sprints.all, sprintAt(number))
+	}
 }
 
 // initializeSampleData creates sample work items with parent-child relationships
@@ -77,8 +106,21 @@ func (db *DummyBackend) initializeSampleData() {
 	createItem := func(title, workItemType, state, iterPath string, parentID *int, daysAgo int) *WorkItem {
 		id := db.nextID
 		db.nextID++
-		changedDate := now.AddDate(0, 0, -daysAgo).Format("2006-01-02T15:04:05")
-		createdDate := now.AddDate(0, 0, -daysAgo-5).Format("2006-01-02T15:04:05")
+		changed := now.AddDate(0, 0, -daysAgo)
+		created := now.AddDate(0, 0, -daysAgo-5)
+		changedDate := changed.Format("2006-01-02T15:04:05")
+		createdDate := created.Format("2006-01-02T15:04:05")
+
+		// Tasks and bugs carry remaining work that burns down as they progress
+		remainingWork := 0.0
+		if workItemType != "User Story" {
+			switch state {
+			case "New":
+				remainingWork = 8
+			case "Active":
+				remainingWork = 4
+			}
+		}
 
 		item := &WorkItem{
 			ID:            id,
@@ -93,8 +135,10 @@ func (db *DummyBackend) initializeSampleData() {
 			CreatedDate:   createdDate,
 			ChangedDate:   changedDate,
 			Priority:      2,
+			RemainingWork: remainingWork,
 		}
 		db.workItems[id] = item
+		db.seedHistory(item, created, changed)
 		return item
 	}
 
@@ -105,13 +149,20 @@ func (db *DummyBackend) initializeSampleData() {
 
 	// Current Sprint items (mix of states)
 	story2 := createItem("Dashboard improvements", "User Story", "Active", db.sprints.current.Path, nil, 5)
+	createItem("Set up charting library", "Task", "Closed", db.sprints.current.Path, &story2.ID, 5)
 	createItem("Add charts widget", "Task", "Active", db.sprints.current.Path, &story2.ID, 3)
 	createItem("Implement filters", "Task", "New", db.sprints.current.Path, &story2.ID, 2)
-	createItem("Chart rendering issue", "Bug", "Active", db.sprints.current.Path, &story2.ID, 1)
+	bug := createItem("Chart rendering issue", "Bug", "Active", db.sprints.current.Path, &story2.ID, 1)
 
 	story3 := createItem("Performance optimization", "User Story", "Active", db.sprints.current.Path, nil, 4)
 	createItem("Database query caching", "Task", "Active", db.sprints.current.Path, &story3.ID, 2)
 	createItem("Optimize API responses", "Task", "New", db.sprints.current.Path, &story3.ID, 1)
+	createItem("Profile slow dashboard query", "Task", "Closed", db.sprints.current.Path, &story3.ID, 2)
+
+	// Sample attachment so the attachments pane has something to show
+	if attachment, err := db.UploadAttachment(context.Background(), "render-error.log", []byte("TypeError: cannot read properties of undefined (reading 'scale')\n")); err == nil {
+		_ = db.AddAttachmentToWorkItem(context.Background(), bug.ID, *attachment, "Console output")
+	}
 
 	// Next Sprint items (planned)
 	story4 := createItem("Mobile responsive design", "User Story", "New", db.sprints.next.Path, nil, 1)
@@ -127,108 +178,215 @@ func (db *DummyBackend) initializeSampleData() {
 	createItem("Refactor legacy module", "Task", "Active", db.project, nil, 20)
 }
 
+// seedHistory generates a synthetic revision history for a sample work item.
+// Items start as New when created and walk through Active to their current state.
+func (db *DummyBackend) seedHistory(item *WorkItem, created, changed time.Time) {
+	initialWork := item.RemainingWork
+	if item.WorkItemType != "User Story" {
+		initialWork = 8
+	}
+
+	db.revisions[item.ID] = []WorkItemRevision{{
+		Rev:           1,
+		ChangedBy:     item.AssignedTo,
+		ChangedDate:   created.Format("2006-01-02T15:04:05"),
+		State:         "New",
+		IterationPath: item.IterationPath,
+		RemainingWork: initialWork,
+		Title:         item.Title,
+		AssignedTo:    item.AssignedTo,
+		Priority:      item.Priority,
+		Tags:          item.Tags,
+	}}
+
+	if item.State == "New" {
+		return
+	}
+
+	if item.State != "Active" {
+		// Completed items spent the middle of their life in Active
+		activated := created.Add(changed.Sub(created) / 2)
+		db.appendRevision(item.ID, WorkItemRevision{
+			ChangedBy:     item.AssignedTo,
+			ChangedDate:   activated.Format("2006-01-02T15:04:05"),
+			State:         "Active",
+			IterationPath: item.IterationPath,
+			RemainingWork: initialWork / 2,
+			Title:         item.Title,
+			AssignedTo:    item.AssignedTo,
+			Priority:      item.Priority,
+			Tags:          item.Tags,
+		})
+	}
+
+	db.appendRevision(item.ID, WorkItemRevision{
+		ChangedBy:     item.AssignedTo,
+		ChangedDate:   item.ChangedDate,
+		State:         item.State,
+		IterationPath: item.IterationPath,
+		RemainingWork: item.RemainingWork,
+		Title:         item.Title,
+		AssignedTo:    item.AssignedTo,
+		Priority:      item.Priority,
+		Tags:          item.Tags,
+	})
+}
+
+// appendRevision adds a revision to a work item's history, numbering it after the latest one
+func (db *DummyBackend) appendRevision(workItemID int, revision WorkItemRevision) {
+	revision.Rev = len(db.revisions[workItemID]) + 1
+	db.revisions[workItemID] = append(db.revisions[workItemID], revision)
+}
+
+// recordRevision snapshots the current fields of a work item into its revision history
+func (db *DummyBackend) recordRevision(item *WorkItem) {
+	db.appendRevision(item.ID, WorkItemRevision{
+		ChangedBy:     "Demo User",
+		ChangedDate:   item.ChangedDate,
+		State:         item.State,
+		IterationPath: item.IterationPath,
+		RemainingWork: item.RemainingWork,
+		Title:         item.Title,
+		AssignedTo:    item.AssignedTo,
+		Priority:      item.Priority,
+		Tags:          item.Tags,
+	})
+}
+
 // =============================================================================
 // WORK ITEM CRUD OPERATIONS
 // =============================================================================
 
 // GetWorkItemByID fetches a single work item by its ID
-func (db *DummyBackend) GetWorkItemByID(id int) (*WorkItem, error) {
+func (db *DummyBackend) GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	if item, exists := db.workItems[id]; exists {
 		return item, nil
 	}
 	return nil, fmt.Errorf("work item %d not found", id)
 }
 
-// GetWorkItems returns all work items using default parameters
-func (db *DummyBackend) GetWorkItems() ([]WorkItem, error) {
-	return db.GetWorkItemsExcluding(nil, "", 40)
-}
+// GetSprintWorkItemIDs returns the IDs of unfinished work items, most recently changed first,
+// optionally limited to a sprint
+func (db *DummyBackend) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-// GetWorkItemsForSprint returns work items for a specific sprint
-func (db *DummyBackend) GetWorkItemsForSprint(sprintPath string, excludeIDs []int, limit int) ([]WorkItem, error) {
-	return db.GetWorkItemsExcluding(excludeIDs, sprintPath, limit)
+	return db.queryIDs(func(item *WorkItem) bool {
+		// Filter by sprint path if provided
+		return sprintPath == "" || item.IterationPath == sprintPath
+	}, false), nil
 }
 
-// GetWorkItemsExcluding queries work items with optional exclusions and sprint filter
-func (db *DummyBackend) GetWorkItemsExcluding(excludeIDs []int, sprintPath string, limit int) ([]WorkItem, error) {
-	excludeSet := make(map[int]bool)
-	for _, id := range excludeIDs {
-		excludeSet[id] = true
-	}
+// GetSprintWorkItemIDsInState returns the IDs of work items in a state, finished or not,
+// most recently changed first, optionally limited to a sprint
+func (db *DummyBackend) GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-	var result []WorkItem
-	for _, item := range db.workItems {
-		// Skip excluded IDs
-		if excludeSet[item.ID] {
-			continue
-		}
-
-		// Skip closed/removed items
-		if item.State == "Closed" || item.State == "Removed" {
-			continue
-		}
-
-		// Filter by sprint path if provided
-		if sprintPath != "" && item.IterationPath != sprintPath {
-			continue
-		}
+	return db.queryAllIDs(func(item *WorkItem) bool {
+		return strings.EqualFold(item.State, state) && (sprintPath == "" || item.IterationPath == sprintPath)
+	}, false), nil
+}
 
-		result = append(result, *item)
+// GetWorkItemsByIDs returns the list rows of the work items with the given IDs in the same order,
+// skipping deleted ones
+func (db *DummyBackend) GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-		if len(result) >= limit {
-			break
+	items := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if item, exists := db.workItems[id]; exists {
+			items = append(items, item.listProjection())
 		}
 	}
+	return items, nil
+}
 
-	// Sort by changed date (most recent first)
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].ChangedDate > result[j].ChangedDate
-	})
+// GetWorkItemDetailsByIDs returns the complete work items with the given IDs in the same order,
+// skipping deleted ones
+func (db *DummyBackend) GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-	if len(result) > limit {
-		result = result[:limit]
+	items := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if item, exists := db.workItems[id]; exists {
+			items = append(items, *item)
+		}
 	}
-
-	return result, nil
+	return items, nil
 }
 
-// GetWorkItemsCount returns total count of non-closed work items
-func (db *DummyBackend) GetWorkItemsCount() (int, error) {
-	return db.GetWorkItemsCountForSprint("")
+// queryIDs returns the IDs of unfinished items accepted by match, sorted by changed date
+// (most recent first unless oldestFirst) and then by ID
+func (db *DummyBackend) queryIDs(match func(item *WorkItem) bool, oldestFirst bool) []int {
+	// Skip completed/removed items
+	return db.queryAllIDs(func(item *WorkItem) bool {
+		return !isDummyItemDone(item) && match(item)
+	}, oldestFirst)
 }
 
-// GetWorkItemsCountForSprint returns count of work items for a specific sprint
-func (db *DummyBackend) GetWorkItemsCountForSprint(sprintPath string) (int, error) {
-	count := 0
+// queryAllIDs is queryIDs without leaving out finished items
+func (db *DummyBackend) queryAllIDs(match func(item *WorkItem) bool, oldestFirst bool) []int {
+	var result []*WorkItem
 	for _, item := range db.workItems {
-		if item.State == "Closed" || item.State == "Removed" {
+		if !match(item) {
 			continue
 		}
-		if sprintPath != "" && item.IterationPath != sprintPath {
-			continue
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChangedDate != result[j].ChangedDate {
+			return (result[i].ChangedDate > result[j].ChangedDate) != oldestFirst
 		}
-		count++
+		return result[i].ID < result[j].ID
+	})
+
+	ids := make([]int, len(result))
+	for i, item := range result {
+		ids[i] = item.ID
 	}
-	return count, nil
+	return ids
 }
 
 // UpdateWorkItemState updates the state of a work item
-func (db *DummyBackend) UpdateWorkItemState(workItemID int, newState string) error {
+func (db *DummyBackend) UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	if item, exists := db.workItems[workItemID]; exists {
+		if err := db.checkStateRules(item, newState, nil); err != nil {
+			return err
+		}
 		item.State = newState
 		item.ChangedDate = time.Now().Format("2006-01-02T15:04:05")
+		db.recordRevision(item)
 		return nil
 	}
 	return fmt.Errorf("work item %d not found", workItemID)
 }
 
 // UpdateWorkItem updates multiple fields of a work item
-func (db *DummyBackend) UpdateWorkItem(workItemID int, updates map[string]interface{}) error {
+func (db *DummyBackend) UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	item, exists := db.workItems[workItemID]
 	if !exists {
 		return fmt.Errorf("work item %d not found", workItemID)
 	}
 
+	if newState, ok := updates["state"].(string); ok {
+		if err := db.checkStateRules(item, newState, updates); err != nil {
+			return err
+		}
+	}
+
 	for key, value := range updates {
 		switch key {
 		case "title":
@@ -251,15 +409,44 @@ func (db *DummyBackend) UpdateWorkItem(workItemID int, updates map[string]interf
 			if v, ok := value.(int); ok {
 				item.Priority = v
 			}
+		case "iterationPath":
+			if v, ok := value.(string); ok {
+				if v == "" {
+					v = db.project // Move to backlog
+				}
+				item.IterationPath = v
+			}
+		case "comment":
+			if v, ok := value.(string); ok {
+				if item.Comments != "" {
+					item.Comments += "\n\n"
+				}
+				item.Comments += v
+			}
 		}
 	}
 
 	item.ChangedDate = time.Now().Format("2006-01-02T15:04:05")
+	db.recordRevision(item)
 	return nil
 }
 
+// UpdateWorkItems updates the fields of each work item, collecting the items that failed
+func (db *DummyBackend) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	failed := make(map[int]error)
+	for _, id := range workItemIDs {
+		if err := db.UpdateWorkItem(ctx, id, updates); err != nil {
+			failed[id] = err
+		}
+	}
+	return failed, nil
+}
+
 // CreateWorkItem creates a new work item
-func (db *DummyBackend) CreateWorkItem(title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+func (db *DummyBackend) CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	id := db.nextID
 	db.nextID++
 
@@ -288,20 +475,28 @@ func (db *DummyBackend) CreateWorkItem(title string, workItemType string, iterat
 	}
 
 	db.workItems[id] = item
+	db.recordRevision(item)
 	return item, nil
 }
 
 // DeleteWorkItem deletes a work item by ID
-func (db *DummyBackend) DeleteWorkItem(workItemID int) error {
+func (db *DummyBackend) DeleteWorkItem(ctx context.Context, workItemID int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	if _, exists := db.workItems[workItemID]; exists {
 		delete(db.workItems, workItemID)
+		delete(db.revisions, workItemID)
 		return nil
 	}
 	return fmt.Errorf("work item %d not found", workItemID)
 }
 
 // MoveWorkItemToSprint moves a work item to a specific sprint
-func (db *DummyBackend) MoveWorkItemToSprint(workItemID int, iterationPath string) error {
+func (db *DummyBackend) MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	item, exists := db.workItems[workItemID]
 	if !exists {
 		return fmt.Errorf("work item %d not found", workItemID)
@@ -312,150 +507,228 @@ func (db *DummyBackend) MoveWorkItemToSprint(workItemID int, iterationPath strin
 	}
 	item.IterationPath = iterationPath
 	item.ChangedDate = time.Now().Format("2006-01-02T15:04:05")
+	db.recordRevision(item)
 	return nil
 }
 
-// GetWorkItemTypeStates returns valid states for a work item type
-func (db *DummyBackend) GetWorkItemTypeStates(workItemType string) ([]string, map[string]string, error) {
-	states := []string{"New", "Active", "Closed", "Removed"}
-	categories := map[string]string{
-		"New":     "Proposed",
-		"Active":  "InProgress",
-		"Closed":  "Completed",
-		"Removed": "Removed",
+// GetWorkItemHistory returns the field changes of every revision of a work item, oldest first
+func (db *DummyBackend) GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	revisions, exists := db.revisions[workItemID]
+	if !exists {
+		return nil, fmt.Errorf("work item %d not found", workItemID)
 	}
-	return states, categories, nil
+	return diffRevisions(revisions), nil
 }
 
-// =============================================================================
-// SPRINT OPERATIONS
-// =============================================================================
+// dummyStates are the states shared by every demo work item type, in process order
+var dummyStates = []string{"New", "Active", "Closed", "Removed"}
 
-// GetCurrentAndAdjacentSprints returns previous, current, and next sprint
-func (db *DummyBackend) GetCurrentAndAdjacentSprints() (prev *Sprint, curr *Sprint, next *Sprint, err error) {
-	return db.sprints.previous, db.sprints.current, db.sprints.next, nil
+// dummyStateCategories maps the demo states to their categories
+var dummyStateCategories = map[string]string{
+	"New":     "Proposed",
+	"Active":  "InProgress",
+	"Closed":  "Completed",
+	"Removed": "Removed",
 }
 
-// =============================================================================
-// BACKLOG OPERATIONS
-// =============================================================================
-
-// GetRecentBacklogItems returns work items not assigned to any sprint, recently updated
-func (db *DummyBackend) GetRecentBacklogItems(limit int) ([]WorkItem, error) {
-	return db.GetRecentBacklogItemsExcluding(nil, limit)
+// isDummyItemDone reports whether a demo item is in the Completed or Removed category
+func isDummyItemDone(item *WorkItem) bool {
+	category := dummyStateCategories[item.State]
+	return category == "Completed" || category == "Removed"
 }
 
-// GetRecentBacklogItemsExcluding returns recent backlog items excluding specific IDs
-func (db *DummyBackend) GetRecentBacklogItemsExcluding(excludeIDs []int, limit int) ([]WorkItem, error) {
-	excludeSet := make(map[int]bool)
-	for _, id := range excludeIDs {
-		excludeSet[id] = true
+// GetWorkItemTypeStates returns valid states for a work item type
+func (db *DummyBackend) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	categories := make(map[string]string, len(dummyStateCategories))
+	for state, category := range dummyStateCategories {
+		categories[state] = category
 	}
+	return append([]string(nil), dummyStates...), categories, nil
+}
 
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+// GetStateCategories returns the category of every demo state
+func (db *DummyBackend) GetStateCategories(ctx context.Context) (map[string]string, error) {
+	_, categories, err := db.GetWorkItemTypeStates(ctx, "")
+	return categories, err
+}
 
-	var result []WorkItem
-	for _, item := range db.workItems {
-		if excludeSet[item.ID] {
-			continue
-		}
+// dummyTransitions is the state machine shared by every demo work item type
+var dummyTransitions = map[string][]string{
+	"":        {"New"},
+	"New":     {"Active", "Closed", "Removed"},
+	"Active":  {"New", "Closed", "Removed"},
+	"Closed":  {"Active"},
+	"Removed": {"New"},
+}
 
-		// Must be in backlog (project root iteration path)
-		if item.IterationPath != db.project {
-			continue
-		}
+// dummyRemovedReasons are the reasons a demo work item can be removed for
+var dummyRemovedReasons = []string{"Duplicate", "Obsolete", "Won't Fix"}
 
-		// Must not be closed/removed
-		if item.State == "Closed" || item.State == "Removed" || item.State == "Completed" || item.State == "Done" {
-			continue
-		}
+// GetWorkItemTypeTransitions returns the allowed state transitions for a work item type
+func (db *DummyBackend) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	return dummyTransitions, nil
+}
 
-		// Must be recently updated (within 30 days)
-		changedDate, err := time.Parse("2006-01-02T15:04:05", item.ChangedDate)
-		if err != nil {
-			continue
-		}
-		if changedDate.Before(thirtyDaysAgo) {
-			continue
-		}
+// GetRequiredFields returns the fields needed to move a work item to a state.
+// Removing an item requires a reason, like the Agile process's Resolved Reason.
+func (db *DummyBackend) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
-		result = append(result, *item)
+	item, exists := db.workItems[workItemID]
+	if !exists {
+		return nil, fmt.Errorf("work item %d not found", workItemID)
+	}
+	if newState == "Removed" && item.State != "Removed" {
+		return []RequiredField{{ReferenceName: "System.Reason", Name: "Reason", AllowedValues: dummyRemovedReasons}}, nil
 	}
+	return nil, nil
+}
 
-	// Sort by changed date (most recent first)
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].ChangedDate > result[j].ChangedDate
-	})
+// checkStateRules rejects state changes the demo process doesn't allow, like the server would
+func (db *DummyBackend) checkStateRules(item *WorkItem, newState string, updates map[string]interface{}) error {
+	if newState != "Removed" || item.State == "Removed" {
+		return nil
+	}
+	reason, _ := updates["System.Reason"].(string)
+	if !slices.Contains(dummyRemovedReasons, reason) {
+		return &RuleViolationError{Fields: []FieldViolation{{
+			ReferenceName: "System.Reason",
+			Name:          "Reason",
+			Problem:       "is required",
+			NeedsValue:    true,
+		}}}
+	}
+	return nil
+}
 
-	if len(result) > limit {
-		result = result[:limit]
+// =============================================================================
+// ATTACHMENT OPERATIONS
+// =============================================================================
+
+// DownloadAttachment returns the content of an uploaded attachment
+func (db *DummyBackend) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	content, exists := db.blobs[attachment.ID]
+	if !exists {
+		return nil, fmt.Errorf("attachment %s not found", attachment.ID)
 	}
+	return content, nil
+}
 
-	return result, nil
+// UploadAttachment stores the content in memory and returns a reference to it
+func (db *DummyBackend) UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	id := fmt.Sprintf("dummy-attachment-%d", len(db.blobs)+1)
+	db.blobs[id] = append([]byte{}, content...)
+	return &Attachment{
+		ID:   id,
+		Name: fileName,
+		URL:  "https://dev.azure.com/demo/_apis/wit/attachments/" + id,
+		Size: int64(len(content)),
+	}, nil
+}
+
+// AddAttachmentToWorkItem links an uploaded attachment to a work item
+func (db *DummyBackend) AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	item, exists := db.workItems[workItemID]
+	if !exists {
+		return fmt.Errorf("work item %d not found", workItemID)
+	}
+	if _, uploaded := db.blobs[attachment.ID]; !uploaded {
+		return fmt.Errorf("attachment %s not found", attachment.ID)
+	}
+
+	attachment.Comment = comment
+	attachment.AddedDate = time.Now().Format("2006-01-02T15:04:05")
+	item.Attachments = append(item.Attachments, attachment)
+	return nil
 }
 
-// GetRecentBacklogItemsCount returns the count of recent backlog items
-func (db *DummyBackend) GetRecentBacklogItemsCount() (int, error) {
-	items, err := db.GetRecentBacklogItemsExcluding(nil, 1000)
-	return len(items), err
+// =============================================================================
+// SPRINT OPERATIONS
+// =============================================================================
+
+// GetCurrentAndAdjacentSprints returns previous, current, and next sprint
+func (db *DummyBackend) GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error) {
+	return db.sprints.previous, db.sprints.current, db.sprints.next, nil
 }
 
-// GetAbandonedWorkItems returns work items not updated in 14+ days
-func (db *DummyBackend) GetAbandonedWorkItems(currentSprintPath string, limit int) ([]WorkItem, error) {
-	return db.GetAbandonedWorkItemsExcluding(nil, currentSprintPath, limit)
+// GetAllSprints returns every sprint, oldest first
+func (db *DummyBackend) GetAllSprints(ctx context.Context) ([]Sprint, error) {
+	sprints := make([]Sprint, 0, len(db.sprints.all))
+	for _, sprint := range db.sprints.all {
+		sprints = append(sprints, *sprint)
+	}
+	return sprints, nil
 }
 
-// GetAbandonedWorkItemsExcluding returns abandoned work items excluding specific IDs
-func (db *DummyBackend) GetAbandonedWorkItemsExcluding(excludeIDs []int, currentSprintPath string, limit int) ([]WorkItem, error) {
-	excludeSet := make(map[int]bool)
-	for _, id := range excludeIDs {
-		excludeSet[id] = true
+// GetSprintWorkItemRevisions returns the revision history of every work item that was ever in the sprint
+func (db *DummyBackend) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	history := make(map[int][]WorkItemRevision)
+	for id, revisions := range db.revisions {
+		for _, rev := range revisions {
+			if rev.IterationPath == sprintPath {
+				history[id] = append([]WorkItemRevision{}, revisions...)
+				break
+			}
+		}
 	}
+	return history, nil
+}
 
-	fourteenDaysAgo := time.Now().AddDate(0, 0, -14)
+// =============================================================================
+// BACKLOG OPERATIONS
+// =============================================================================
 
-	var result []WorkItem
-	for _, item := range db.workItems {
-		if excludeSet[item.ID] {
-			continue
+// GetRecentBacklogItemIDs returns the IDs of work items not assigned to any sprint, recently updated
+func (db *DummyBackend) GetRecentBacklogItemIDs(ctx context.Context) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+	return db.queryIDs(func(item *WorkItem) bool {
+		// Must be in backlog (project root iteration path)
+		if item.IterationPath != db.project {
+			return false
 		}
+		// Must be recently updated (within 30 days)
+		changedDate, err := time.Parse("2006-01-02T15:04:05", item.ChangedDate)
+		return err == nil && !changedDate.Before(thirtyDaysAgo)
+	}, false), nil
+}
+
+// GetAbandonedWorkItemIDs returns the IDs of work items not updated in 14+ days, oldest first
+func (db *DummyBackend) GetAbandonedWorkItemIDs(ctx context.Context, currentSprintPath string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 
+	fourteenDaysAgo := time.Now().AddDate(0, 0, -14)
+	return db.queryIDs(func(item *WorkItem) bool {
 		// Must not be in current sprint
 		if currentSprintPath != "" && item.IterationPath == currentSprintPath {
-			continue
+			return false
 		}
-
-		// Must not be closed/removed
-		if item.State == "Closed" || item.State == "Removed" || item.State == "Completed" || item.State == "Done" {
-			continue
-		}
-
 		// Must be stale (not updated in 14+ days)
 		changedDate, err := time.Parse("2006-01-02T15:04:05", item.ChangedDate)
-		if err != nil {
-			continue
-		}
-		if changedDate.After(fourteenDaysAgo) {
-			continue
-		}
-
-		result = append(result, *item)
-	}
-
-	// Sort by changed date (oldest first for abandoned items)
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].ChangedDate < result[j].ChangedDate
-	})
-
-	if len(result) > limit {
-		result = result[:limit]
-	}
-
-	return result, nil
+		return err == nil && !changedDate.After(fourteenDaysAgo)
+	}, true), nil
 }
 
-// GetAbandonedWorkItemsCount returns the count of abandoned work items
-func (db *DummyBackend) GetAbandonedWorkItemsCount(currentSprintPath string) (int, error) {
-	items, err := db.GetAbandonedWorkItemsExcluding(nil, currentSprintPath, 1000)
-	return len(items), err
+// GetCurrentUser returns the demo user every sample item is assigned to
+func (db *DummyBackend) GetCurrentUser(ctx context.Context) (string, error) {
+	return "Demo User", nil
 }
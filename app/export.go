@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// exportFormat is a file format the visible tree can be exported to
+type exportFormat int
+
+const (
+	markdownExport exportFormat = iota
+	csvExport
+	jsonExport
+)
+
+// exportFormats lists the formats in the order they appear in the export menu
+var exportFormats = []exportFormat{markdownExport, csvExport, jsonExport}
+
+// String returns the display name of the format
+func (f exportFormat) String() string {
+	switch f {
+	case markdownExport:
+		return "Markdown checklist"
+	case csvExport:
+		return "CSV"
+	case jsonExport:
+		return "JSON"
+	default:
+		return ""
+	}
+}
+
+// extension returns the file extension for the format
+func (f exportFormat) extension() string {
+	switch f {
+	case csvExport:
+		return ".csv"
+	case jsonExport:
+		return ".json"
+	default:
+		return ".md"
+	}
+}
+
+// defaultExportColumns are the CSV columns used when export_columns isn't configured
+var defaultExportColumns = []string{"id", "type", "state", "title", "assigned_to", "priority", "iteration_path", "parent_id"}
+
+// exportColumns maps CSV column names to the work item value they hold
+var exportColumns = map[string]func(item WorkItem) string{
+	"id":             func(item WorkItem) string { return strconv.Itoa(item.ID) },
+	"title":          func(item WorkItem) string { return item.Title },
+	"type":           func(item WorkItem) string { return item.WorkItemType },
+	"state":          func(item WorkItem) string { return item.State },
+	"assigned_to":    func(item WorkItem) string { return item.AssignedTo },
+	"tags":           func(item WorkItem) string { return item.Tags },
+	"iteration_path": func(item WorkItem) string { return item.IterationPath },
+	"area_path":      func(item WorkItem) string { return item.AreaPath },
+	"description":    func(item WorkItem) string { return item.Description },
+	"created_date":   func(item WorkItem) string { return item.CreatedDate },
+	"changed_date":   func(item WorkItem) string { return item.ChangedDate },
+	"sprint": func(item WorkItem) string {
+		parts := strings.Split(item.IterationPath, "\\")
+		return parts[len(parts)-1]
+	},
+	"priority": func(item WorkItem) string {
+		if item.Priority == 0 {
+			return ""
+		}
+		return strconv.Itoa(item.Priority)
+	},
+	"parent_id": func(item WorkItem) string {
+		if item.ParentID == nil {
+			return ""
+		}
+		return strconv.Itoa(*item.ParentID)
+	},
+	"remaining_work": func(item WorkItem) string {
+		if item.RemainingWork == 0 {
+			return ""
+		}
+		return formatChartValue(item.RemainingWork)
+	},
+}
+
+// validateExportColumns returns an error naming the first unknown column
+func validateExportColumns(columns []string) error {
+	for _, column := range columns {
+		if _, ok := exportColumns[column]; !ok {
+			return fmt.Errorf("unknown export column %q", column)
+		}
+	}
+	return nil
+}
+
+// writeWorkItemsCSV writes a header row and one row per item with the given columns
+func writeWorkItemsCSV(w io.Writer, items []WorkItem, columns []string) error {
+	if len(columns) == 0 {
+		columns = defaultExportColumns
+	}
+	if err := validateExportColumns(columns); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = exportColumns[column](item)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// markdownChecklist renders the tree as a nested Markdown checklist; done items are checked
+func markdownChecklist(title string, treeItems []TreeItem, categoryOf func(string) string) string {
+	var content strings.Builder
+	if title != "" {
+		content.WriteString("## " + title + "\n\n")
+	}
+	for _, treeItem := range treeItems {
+		item := treeItem.WorkItem
+		check := " "
+		if category := categoryOf(item.State); category == "Completed" || category == "Removed" {
+			check = "x"
+		}
+		line := fmt.Sprintf("%s- [%s] #%d %s", strings.Repeat("  ", treeItem.Depth), check, item.ID, item.Title)
+		if item.State != "" {
+			line += fmt.Sprintf(" _(%s)_", item.State)
+		}
+		content.WriteString(line + "\n")
+	}
+	return content.String()
+}
+
+// exportTree renders the visible tree in the given format
+func exportTree(format exportFormat, title string, treeItems []TreeItem, columns []string, categoryOf func(string) string) (string, error) {
+	switch format {
+	case csvExport:
+		items := make([]WorkItem, len(treeItems))
+		for i, treeItem := range treeItems {
+			items[i] = *treeItem.WorkItem
+		}
+		var buf bytes.Buffer
+		if err := writeWorkItemsCSV(&buf, items, columns); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+
+	case jsonExport:
+		// Items are listed in tree order; ParentID carries the hierarchy, so children aren't repeated
+		items := make([]WorkItem, len(treeItems))
+		for i, treeItem := range treeItems {
+			items[i] = *treeItem.WorkItem
+			items[i].Children = nil
+		}
+		data, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode json: %w", err)
+		}
+		return string(data) + "\n", nil
+
+	default:
+		return markdownChecklist(title, treeItems, categoryOf), nil
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportTestTree returns a small tree: a story with a done and an open task, plus a root bug
+func exportTestTree() []TreeItem {
+	parentID := 1
+	items := []WorkItem{
+		{ID: 1, Title: "Login page", WorkItemType: "User Story", State: "Active", IterationPath: "Proj\\Sprint 1"},
+		{ID: 2, Title: "Form, validation", WorkItemType: "Task", State: "Closed", ParentID: &parentID, Priority: 2},
+		{ID: 3, Title: "Styling", WorkItemType: "Task", State: "New", ParentID: &parentID, RemainingWork: 1.5},
+		{ID: 4, Title: "Crash on submit", WorkItemType: "Bug", State: "Active"},
+	}
+	return flattenTree(buildTreeStructure(items))
+}
+
+func TestMarkdownChecklist(t *testing.T) {
+	got := markdownChecklist("Sprint 1", exportTestTree(), testCategoryOf)
+	expected := "## Sprint 1\n\n" +
+		"- [ ] #1 Login page _(Active)_\n" +
+		"  - [x] #2 Form, validation _(Closed)_\n" +
+		"  - [ ] #3 Styling _(New)_\n" +
+		"- [ ] #4 Crash on submit _(Active)_\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestExportTreeCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		columns     []string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "custom columns",
+			columns:  []string{"id", "title", "sprint", "remaining_work"},
+			expected: "id,title,sprint,remaining_work\n1,Login page,Sprint 1,\n2,\"Form, validation\",,\n3,Styling,,1.5\n4,Crash on submit,,\n",
+		},
+		{
+			name:     "default columns",
+			columns:  nil,
+			expected: strings.Join(defaultExportColumns, ",") + "\n",
+		},
+		{
+			name:        "unknown column",
+			columns:     []string{"id", "bogus"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exportTree(csvExport, "", exportTestTree(), tt.columns, testCategoryOf)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExportTreeJSON(t *testing.T) {
+	got, err := exportTree(jsonExport, "", exportTestTree(), nil, testCategoryOf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var items []WorkItem
+	if err := json.Unmarshal([]byte(got), &items); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+	// Tree order, with the hierarchy kept in ParentID instead of nested children
+	if items[1].ID != 2 || items[1].ParentID == nil || *items[1].ParentID != 1 || items[0].Children != nil {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestExportViewWritesFile(t *testing.T) {
+	m := model{
+		currentMode:     sprintMode,
+		currentTab:      currentSprint,
+		sprints:         map[sprintTab]*Sprint{currentSprint: {Name: "Sprint 1", Path: "Proj\\Sprint 1"}},
+		sprintLists:     map[sprintTab]*WorkItemList{currentSprint: createTestList([]WorkItem{{ID: 1, Title: "Only", State: "New", IterationPath: "Proj\\Sprint 1"}})},
+		stateCategories: map[string]string{"New": "Proposed"},
+	}
+
+	m, _ = m.openExport()
+	if m.state != exportView {
+		t.Fatalf("expected export view, got %v", m.state)
+	}
+
+	m, _ = m.handleExportView(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.export.pathMode || m.export.pathInput.Value() != "sprint-1.md" {
+		t.Fatalf("expected path prompt with default name, got %q", m.export.pathInput.Value())
+	}
+
+	path := filepath.Join(t.TempDir(), "report.md")
+	m.export.pathInput.SetValue(path)
+	m, cmd := m.handleExportView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected write command")
+	}
+
+	msg, ok := cmd().(exportDoneMsg)
+	if !ok || msg.err != nil || msg.count != 1 {
+		t.Fatalf("unexpected result: %+v", msg)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "## Sprint 1\n\n- [ ] #1 Only _(New)_\n" {
+		t.Errorf("unexpected file content: %q", content)
+	}
+
+	m, _ = m.handleExportDoneMsg(msg)
+	if m.state != listView {
+		t.Errorf("expected list view after export, got %v", m.state)
+	}
+}
+
+func TestOpenExportEmptyList(t *testing.T) {
+	m := model{sprintLists: map[sprintTab]*WorkItemList{}}
+	m, _ = m.openExport()
+	if m.state == exportView {
+		t.Error("expected export to be skipped for an empty list")
+	}
+}
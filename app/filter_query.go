@@ -0,0 +1,408 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filterQuery is a parsed filter expression such as
+// `state:active tag:backend p:<=2 assignee:me "login page" -tag:blocked`.
+// Every term must match for a work item to match.
+type filterQuery struct {
+	terms []filterTerm
+}
+
+// filterTerm is a single condition of a filter query
+type filterTerm struct {
+	field  string   // Canonical field name, empty for free text
+	op     string   // One of ":", "<", "<=", ">", ">="
+	values []string // Alternatives, any of which may match
+	negate bool     // Whether the term was prefixed with "-"
+}
+
+// filterContext carries the values a query needs besides the work item itself
+type filterContext struct {
+	currentUser string
+	now         time.Time
+}
+
+// filterFieldKind describes how a field's values are compared
+type filterFieldKind int
+
+const (
+	textField filterFieldKind = iota
+	numberField
+	dateField
+)
+
+// filterField describes a field that can be used in a filter query
+type filterField struct {
+	name   string
+	kind   filterFieldKind
+	value  func(*WorkItem) string
+	detail bool // Left out of list rows; matching needs the complete work items
+}
+
+// filterFields lists the queryable fields, in the order shown in error messages
+var filterFields = []filterField{
+	{name: "id", kind: numberField, value: func(w *WorkItem) string { return strconv.Itoa(w.ID) }},
+	{name: "title", kind: textField, value: func(w *WorkItem) string { return w.Title }},
+	{name: "state", kind: textField, value: func(w *WorkItem) string { return w.State }},
+	{name: "type", kind: textField, value: func(w *WorkItem) string { return w.WorkItemType }},
+	{name: "tag", kind: textField, value: func(w *WorkItem) string { return w.Tags }},
+	{name: "p", kind: numberField, value: func(w *WorkItem) string { return strconv.Itoa(w.Priority) }},
+	{name: "assignee", kind: textField, value: func(w *WorkItem) string { return w.AssignedTo }},
+	{name: "iteration", kind: textField, value: func(w *WorkItem) string { return w.IterationPath }},
+	{name: "area", kind: textField, value: func(w *WorkItem) string { return w.AreaPath }},
+	{name: "parent", kind: numberField, value: func(w *WorkItem) string {
+		if w.ParentID == nil {
+			return ""
+		}
+		return strconv.Itoa(*w.ParentID)
+	}},
+	{name: "desc", kind: textField, value: func(w *WorkItem) string { return w.Description }, detail: true},
+	{name: "comment", kind: textField, value: func(w *WorkItem) string { return w.Comments }, detail: true},
+	{name: "remaining", kind: numberField, value: func(w *WorkItem) string {
+		return strconv.FormatFloat(w.RemainingWork, 'f', -1, 64)
+	}},
+	{name: "created", kind: dateField, value: func(w *WorkItem) string { return w.CreatedDate }},
+	{name: "changed", kind: dateField, value: func(w *WorkItem) string { return w.ChangedDate }},
+}
+
+// filterFieldAliases maps alternative spellings to canonical field names
+var filterFieldAliases = map[string]string{
+	"s":           "state",
+	"t":           "type",
+	"tags":        "tag",
+	"priority":    "p",
+	"a":           "assignee",
+	"assigned":    "assignee",
+	"sprint":      "iteration",
+	"description": "desc",
+	"comments":    "comment",
+}
+
+// lookupFilterField returns the field with the given name or alias
+func lookupFilterField(name string) (filterField, bool) {
+	name = strings.ToLower(name)
+	if canonical, ok := filterFieldAliases[name]; ok {
+		name = canonical
+	}
+	for _, field := range filterFields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return filterField{}, false
+}
+
+// filterFieldNames returns the canonical field names for error messages
+func filterFieldNames() string {
+	names := make([]string, len(filterFields))
+	for i, field := range filterFields {
+		names[i] = field.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// tokenizeFilterQuery splits a query on whitespace, keeping quoted text together.
+// Quotes are kept in the tokens so the parser can tell phrases from fields.
+func tokenizeFilterQuery(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuote := false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !inQuote:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote: add a closing \"")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseFilterQuery parses a filter expression.
+// Bare words and quoted phrases match the title or ID; key:value terms match fields.
+func parseFilterQuery(input string) (filterQuery, error) {
+	tokens, err := tokenizeFilterQuery(input)
+	if err != nil {
+		return filterQuery{}, err
+	}
+
+	var query filterQuery
+	for _, token := range tokens {
+		term, err := parseFilterTerm(token)
+		if err != nil {
+			return filterQuery{}, err
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// parseFilterTerm parses a single token into a filter term
+func parseFilterTerm(token string) (filterTerm, error) {
+	var term filterTerm
+	if strings.HasPrefix(token, "-") {
+		term.negate = true
+		token = token[1:]
+		if token == "" {
+			return term, fmt.Errorf("nothing to exclude after \"-\"")
+		}
+	}
+
+	// Quoted phrases and bare words are free text
+	colon := strings.Index(token, ":")
+	if strings.HasPrefix(token, "\"") || colon <= 0 {
+		term.op = ":"
+		term.values = []string{strings.Trim(token, "\"")}
+		return term, nil
+	}
+
+	name := token[:colon]
+	field, ok := lookupFilterField(name)
+	if !ok {
+		return term, fmt.Errorf("unknown field %q (fields: %s)", name, filterFieldNames())
+	}
+	term.field = field.name
+
+	rest := token[colon+1:]
+	term.op = ":"
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(rest, op) {
+			term.op = op
+			rest = rest[len(op):]
+			break
+		}
+	}
+	if term.op != ":" && field.kind == textField {
+		return term, fmt.Errorf("%s:%s: comparisons only work on number and date fields", name, term.op)
+	}
+
+	for _, value := range strings.Split(rest, ",") {
+		value = strings.Trim(value, "\"")
+		if value == "" {
+			continue
+		}
+		if err := validateFilterValue(field, term.op, value); err != nil {
+			return term, fmt.Errorf("%s: %w", name, err)
+		}
+		term.values = append(term.values, value)
+	}
+	if len(term.values) == 0 {
+		return term, fmt.Errorf("missing value for %s: (e.g. %s)", name, filterFieldExample(field))
+	}
+	return term, nil
+}
+
+// validateFilterValue checks a value against the kind of field it is used with
+func validateFilterValue(field filterField, op, value string) error {
+	switch field.kind {
+	case numberField:
+		if field.name == "parent" && strings.EqualFold(value, "none") {
+			if op != ":" {
+				return fmt.Errorf("\"none\" cannot be compared")
+			}
+			return nil
+		}
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("expected a number, got %q", value)
+		}
+	case dateField:
+		if _, ok := parseFilterAge(value); ok {
+			if op != ":" {
+				return fmt.Errorf("ages like %q mean \"within the last\"; use -%s:%s to exclude them", value, field.name, value)
+			}
+			return nil
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("expected a date (2024-01-31) or an age (7d, 2w), got %q", value)
+		}
+	}
+	return nil
+}
+
+// filterFieldExample returns an example term for a field
+func filterFieldExample(field filterField) string {
+	switch field.kind {
+	case numberField:
+		return field.name + ":1"
+	case dateField:
+		return field.name + ":7d"
+	}
+	return field.name + ":value"
+}
+
+// parseFilterAge parses relative ages such as 7d or 2w
+func parseFilterAge(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	switch strings.ToLower(value[len(value)-1:]) {
+	case "d":
+		return time.Duration(n) * 24 * time.Hour, true
+	case "w":
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// needsDetails reports whether the query searches fields that list rows leave out
+func (q filterQuery) needsDetails() bool {
+	for _, term := range q.terms {
+		if field, ok := lookupFilterField(term.field); ok && field.detail {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a work item satisfies every term of the query
+func (q filterQuery) matches(item *WorkItem, ctx filterContext) bool {
+	for _, term := range q.terms {
+		if term.matches(item, ctx) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether a work item satisfies the term, ignoring negation
+func (t filterTerm) matches(item *WorkItem, ctx filterContext) bool {
+	for _, value := range t.values {
+		if t.matchesValue(item, value, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesValue compares a single alternative against the work item
+func (t filterTerm) matchesValue(item *WorkItem, value string, ctx filterContext) bool {
+	if t.field == "" {
+		needle := strings.ToLower(value)
+		return strings.Contains(strings.ToLower(item.Title), needle) ||
+			strings.Contains(strconv.Itoa(item.ID), needle)
+	}
+
+	field, _ := lookupFilterField(t.field)
+	actual := field.value(item)
+
+	switch t.field {
+	case "state", "type":
+		return strings.Contains(normalizeFilterText(actual), normalizeFilterText(value))
+	case "tag":
+		for _, tag := range splitTags(actual) {
+			if strings.EqualFold(tag, value) {
+				return true
+			}
+		}
+		return false
+	case "assignee":
+		switch strings.ToLower(value) {
+		case "me":
+			return ctx.currentUser != "" && strings.EqualFold(actual, ctx.currentUser)
+		case "none":
+			return actual == ""
+		}
+	case "parent":
+		if strings.EqualFold(value, "none") {
+			return actual == ""
+		}
+	}
+
+	switch field.kind {
+	case numberField:
+		if actual == "" {
+			return false
+		}
+		a, _ := strconv.ParseFloat(actual, 64)
+		b, _ := strconv.ParseFloat(value, 64)
+		return compareFilterNumbers(a, b, t.op)
+	case dateField:
+		return matchesFilterDate(actual, value, t.op, ctx.now)
+	}
+	return strings.Contains(strings.ToLower(actual), strings.ToLower(value))
+}
+
+// normalizeFilterText lowercases text and drops spaces so "inprogress" matches "In Progress"
+func normalizeFilterText(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), " ", "")
+}
+
+// splitTags splits a semicolon-separated tag string
+func splitTags(tags string) []string {
+	var result []string
+	for _, tag := range strings.Split(tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// compareFilterNumbers applies a comparison operator to two numbers
+func compareFilterNumbers(a, b float64, op string) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return a == b
+}
+
+// matchesFilterDate compares a work item date against a date or age value
+func matchesFilterDate(actual, value, op string, now time.Time) bool {
+	if len(actual) < 10 {
+		return false
+	}
+	t, err := time.Parse("2006-01-02T15:04:05", actual)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", actual[:10]); err != nil {
+			return false
+		}
+	}
+
+	if age, ok := parseFilterAge(value); ok {
+		return !t.Before(now.Add(-age))
+	}
+
+	// Compare whole days so created:2024-01-31 matches any time that day
+	day := actual[:10]
+	switch op {
+	case "<":
+		return day < value
+	case "<=":
+		return day <= value
+	case ">":
+		return day > value
+	case ">=":
+		return day >= value
+	}
+	return day == value
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func filterQueryTestItems() []WorkItem {
+	parentID := 1
+	return []WorkItem{
+		createTestWorkItemComplete(1, "Login page redesign", "New", "Demo User", "User Story", "", "frontend", 2, "Project\\Sprint 1", nil),
+		createTestWorkItemComplete(2, "Fix login page crash", "Active", "Demo User", "Bug", "Crash on submit", "backend; urgent", 1, "Project\\Sprint 1", &parentID),
+		createTestWorkItemComplete(3, "Write docs", "In Progress", "", "Task", "", "docs; blocked", 3, "Project\\Sprint 2", &parentID),
+		createTestWorkItemComplete(4, "Old cleanup", "Closed", "Someone Else", "Task", "", "backend", 4, "Project\\Sprint 2", nil),
+	}
+}
+
+func TestFilterQueryMatches(t *testing.T) {
+	items := filterQueryTestItems()
+	items[3].ChangedDate = time.Now().AddDate(0, 0, -30).Format("2006-01-02T15:04:05")
+	items[3].CreatedDate = "2024-01-15T10:00:00"
+	items[2].RemainingWork = 4
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"login", []int{1, 2}},
+		{"3", []int{3}},
+		{`"login page"`, []int{1, 2}},
+		{"state:active", []int{2}},
+		{"state:inprogress", []int{3}},
+		{"state:new,closed", []int{1, 4}},
+		{"s:ACTIVE", []int{2}},
+		{"type:bug", []int{2}},
+		{"type:story", []int{1}},
+		{"tag:backend", []int{2, 4}},
+		{"tag:back", nil},
+		{"tag:backend -tag:urgent", []int{4}},
+		{"-tag:blocked", []int{1, 2, 4}},
+		{"p:1", []int{2}},
+		{"p:<=2", []int{1, 2}},
+		{"priority:>2", []int{3, 4}},
+		{"assignee:me", []int{1, 2}},
+		{"assignee:none", []int{3}},
+		{"a:someone", []int{4}},
+		{"sprint:\"sprint 2\"", []int{3, 4}},
+		{"parent:1", []int{2, 3}},
+		{"parent:none", []int{1, 4}},
+		{"desc:crash", []int{2}},
+		{"remaining:>0", []int{3}},
+		{"changed:7d", []int{1, 2, 3}},
+		{"-changed:7d", []int{4}},
+		{"created:2024-01-15", []int{4}},
+		{"created:<2024-02-01", []int{4}},
+		{"state:active tag:backend type:bug p:1 assignee:me \"login page\" -tag:blocked", []int{2}},
+	}
+
+	ctx := filterContext{currentUser: "Demo User", now: time.Now()}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := parseFilterQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseFilterQuery(%q) error: %v", tt.query, err)
+			}
+			var got []int
+			for i := range items {
+				if query.matches(&items[i], ctx) {
+					got = append(got, items[i].ID)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("matched %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterQueryErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{"colour:red", `unknown field "colour"`},
+		{"state:", "missing value for state:"},
+		{"p:high", `expected a number, got "high"`},
+		{"id:>abc", `expected a number, got "abc"`},
+		{`"login page`, "unterminated quote"},
+		{"changed:yesterday", "expected a date (2024-01-31) or an age (7d, 2w)"},
+		{"changed:>7d", "within the last"},
+		{"title:>abc", "comparisons only work on number and date fields"},
+		{"-", "nothing to exclude"},
+		{"parent:<none", `"none" cannot be compared`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parseFilterQuery(tt.query)
+			if err == nil {
+				t.Fatalf("parseFilterQuery(%q) succeeded, want error", tt.query)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterSearchKeepsAncestors(t *testing.T) {
+	storyID := 1
+	taskID := 2
+	tasks := []WorkItem{
+		createTestWorkItem(1, "Story", nil),
+		createTestWorkItem(2, "Task", &storyID),
+		createTestWorkItem(3, "Subtask fix", &taskID),
+		createTestWorkItem(4, "Unrelated", nil),
+	}
+
+	m := model{
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		filter:      FilterState{filterInput: textinput.Model{}},
+	}
+	m.sprintLists[currentSprint] = createTestList(tasks)
+
+	m.filter.filterInput.SetValue("fix")
+	m.filterSearch()
+
+	list := m.getCurrentList()
+	if len(list.filteredTasks) != 3 {
+		t.Fatalf("filteredTasks has %d items, want match plus 2 ancestors", len(list.filteredTasks))
+	}
+	if !m.filter.contextIDs[1] || !m.filter.contextIDs[2] || m.filter.contextIDs[3] {
+		t.Errorf("contextIDs = %v, want ancestors 1 and 2 only", m.filter.contextIDs)
+	}
+	if got := m.filterMatchCount(); got != 1 {
+		t.Errorf("filterMatchCount() = %d, want 1", got)
+	}
+
+	// A parse error keeps the previous results and records the error
+	m.filter.filterInput.SetValue("fix colour:red")
+	m.filterSearch()
+	if m.filter.err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if len(list.filteredTasks) != 3 {
+		t.Errorf("filteredTasks changed to %d items on parse error", len(list.filteredTasks))
+	}
+
+	m.filter.filterInput.SetValue("")
+	m.filterSearch()
+	if m.filter.err != nil || m.filter.contextIDs != nil {
+		t.Error("clearing the filter should reset the error and context")
+	}
+}
@@ -15,6 +15,10 @@ type FlagConfig struct {
 	RunWizard       bool
 	ShowHelp        bool
 	DummyMode       bool // Internal: enable dummy backend for development
+
+	// Non-interactive subcommand (e.g. "list", "show"); empty starts the TUI
+	Command     string
+	CommandArgs []string
 }
 
 // parseFlags parses command-line flags and returns a FlagConfig
@@ -34,8 +38,15 @@ func parseFlags() *FlagConfig {
 	// Undocumented flag for development - not shown in help
 	flag.BoolVar(&flags.DummyMode, "dummy", false, "")
 
+	flag.Usage = printHelp
 	flag.Parse()
 
+	// Global flags come first; the first positional argument selects a subcommand
+	if args := flag.Args(); len(args) > 0 {
+		flags.Command = args[0]
+		flags.CommandArgs = args[1:]
+	}
+
 	// Only set pointers if flags were actually provided
 	flag.Visit(func(f *flag.Flag) {
 		switch f.Name {
@@ -58,15 +69,28 @@ func printHelp() {
 	fmt.Println("Hippo - Azure DevOps Work Item TUI")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  hippo [flags]")
+	fmt.Println("  hippo [flags]                      # Start the TUI")
+	fmt.Println("  hippo [flags] <command> [options]  # Run a command and exit")
 	fmt.Println()
 	fmt.Println("Flags:")
 	flag.PrintDefaults()
 	fmt.Println()
+	fmt.Println("Commands:")
+	for _, command := range cliCommands {
+		fmt.Printf("  %s\n      %s\n", command.usage, command.summary)
+	}
+	fmt.Println()
+	fmt.Println("  list, show and create accept -o/--output table|json|csv")
+	fmt.Printf("  Exit codes: %d ok, %d error, %d invalid usage, %d configuration error\n", exitOK, exitFailure, exitUsage, exitConfigError)
+	fmt.Println()
 	fmt.Println("Examples:")
-	fmt.Println("  hippo                    # Start with config file")
-	fmt.Println("  hippo --init             # Run setup wizard")
-	fmt.Println("  hippo --project MyProj   # Override project for this run")
+	fmt.Println("  hippo                          # Start with config file")
+	fmt.Println("  hippo --init                   # Run setup wizard")
+	fmt.Println("  hippo --project MyProj         # Override project for this run")
+	fmt.Println("  hippo list --sprint next -o json")
+	fmt.Println("  hippo state 1234 Active")
+	fmt.Println("  hippo move 1234 --to next")
+	fmt.Println("  hippo create \"Fix login\" --parent 12")
 	fmt.Println()
 	fmt.Println("Configuration:")
 	fmt.Println("  Config file: ~/.config/hippo/config.yaml")
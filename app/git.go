@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultBranchTemplate is used when branch_template isn't configured
+const defaultBranchTemplate = "users/{user}/{id}-{title}"
+
+// maxBranchTitleLength bounds the {title} part of branch names
+const maxBranchTitleLength = 40
+
+var (
+	nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+	// Fallback for branches not created from the default template: a segment made of the ID
+	// and a slug starting with a letter, e.g. "1234-fix-login" but not "v1.2" or "2024.10"
+	branchIDSegment = regexp.MustCompile(`^(\d+)[-_][a-zA-Z]`)
+	danglingDashes  = regexp.MustCompile(`-+/`)
+	repeatedSlashes = regexp.MustCompile(`/+`)
+)
+
+// runGit runs a git command in dir (the current directory if empty) and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// currentGitBranch returns the branch checked out in dir
+func currentGitBranch(dir string) (string, error) {
+	return runGit(dir, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// gitUserSlug returns a short name for the {user} placeholder:
+// the local part of the git email, the git user name, or the OS user
+func gitUserSlug(dir string) string {
+	if email, err := runGit(dir, "config", "user.email"); err == nil && email != "" {
+		if local, _, found := strings.Cut(email, "@"); found && local != "" {
+			return slugify(local, maxBranchTitleLength)
+		}
+	}
+	if name, err := runGit(dir, "config", "user.name"); err == nil && name != "" {
+		return slugify(name, maxBranchTitleLength)
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return slugify(user, maxBranchTitleLength)
+	}
+	return "me"
+}
+
+// slugify lowercases text and replaces everything but letters and digits with dashes,
+// cutting at a word boundary so it fits in maxLen
+func slugify(text string, maxLen int) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(slug) <= maxLen {
+		return slug
+	}
+	slug = slug[:maxLen]
+	if cut := strings.LastIndex(slug, "-"); cut > 0 {
+		slug = slug[:cut]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// branchNameForWorkItem fills the branch template placeholders {id}, {title}, {type} and {user}
+func branchNameForWorkItem(template string, item WorkItem, user string) string {
+	if template == "" {
+		template = defaultBranchTemplate
+	}
+	replacer := strings.NewReplacer(
+		"{id}", strconv.Itoa(item.ID),
+		"{title}", slugify(item.Title, maxBranchTitleLength),
+		"{type}", slugify(item.WorkItemType, maxBranchTitleLength),
+		"{user}", user,
+	)
+	name := replacer.Replace(template)
+
+	// Empty placeholders must not leave dangling separators behind
+	name = danglingDashes.ReplaceAllString(name, "/")
+	name = repeatedSlashes.ReplaceAllString(name, "/")
+	return strings.Trim(name, "-/")
+}
+
+// branchTemplatePattern turns a branch template into a regexp that captures the {id}
+func branchTemplatePattern(template string) *regexp.Regexp {
+	if template == "" {
+		template = defaultBranchTemplate
+	}
+	if !strings.Contains(template, "{id}") {
+		return nil
+	}
+	pattern := regexp.QuoteMeta(template)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{id}"), `(\d+)`, 1)
+	pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta("{id}"), `\d+`)
+	for _, placeholder := range []string{"{title}", "{type}", "{user}"} {
+		pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(placeholder), `[^/]*`)
+	}
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// workItemIDFromBranch extracts the work item ID from a branch name. A configured template is the
+// only layout trusted; with the default one, other branches fall back to the last "<id>-<slug>" segment.
+func workItemIDFromBranch(branch, template string) (int, bool) {
+	if re := branchTemplatePattern(template); re != nil {
+		if match := re.FindStringSubmatch(branch); match != nil {
+			if id, err := strconv.Atoi(match[1]); err == nil && id > 0 {
+				return id, true
+			}
+		}
+	}
+	if template != "" {
+		return 0, false
+	}
+
+	segments := strings.Split(branch, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if match := branchIDSegment.FindStringSubmatch(segments[i]); match != nil {
+			if id, err := strconv.Atoi(match[1]); err == nil && id > 0 {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// checkoutBranch checks out branch in dir, creating it from HEAD if it doesn't exist yet.
+// It returns true if the branch was created.
+func checkoutBranch(dir, branch string) (bool, error) {
+	if _, err := runGit(dir, "check-ref-format", "--branch", branch); err != nil {
+		return false, fmt.Errorf("invalid branch name %q", branch)
+	}
+
+	if _, err := runGit(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		_, err := runGit(dir, "checkout", branch)
+		return false, err
+	}
+
+	_, err := runGit(dir, "checkout", "-b", branch)
+	return err == nil, err
+}
+
+// branchWorkItemAtStartup returns the work item ID of the branch checked out in the current
+// directory, or 0 when Hippo isn't started inside a git repo on a work item branch
+func branchWorkItemAtStartup(template string) int {
+	branch, err := currentGitBranch("")
+	if err != nil || branch == "HEAD" {
+		return 0
+	}
+	id, _ := workItemIDFromBranch(branch, template)
+	return id
+}
+
+// inProgressState returns the first "In Progress" category state to move an item to,
+// or "" if the item is already in progress or the type has no such state
+func inProgressState(currentState string, states []string, categories map[string]string) string {
+	if categories[currentState] == "InProgress" {
+		return ""
+	}
+	for _, state := range states {
+		if categories[state] == "InProgress" {
+			return state
+		}
+	}
+	return ""
+}
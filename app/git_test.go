@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"testing"
+)
+
+// initTestRepo creates a git repository with one commit in a temporary directory
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "jane.doe@example.com"},
+		{"config", "user.name", "Jane Doe"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		if _, err := runGit(dir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return dir
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxLen   int
+		expected string
+	}{
+		{"Fix login bug", 40, "fix-login-bug"},
+		{"  [UI] Dark mode: toggle!  ", 40, "ui-dark-mode-toggle"},
+		{"Ünïcode & symbols", 40, "n-code-symbols"},
+		{"A very long title that keeps going", 20, "a-very-long-title"},
+		{"", 40, ""},
+	}
+
+	for _, tt := range tests {
+		if got := slugify(tt.input, tt.maxLen); got != tt.expected {
+			t.Errorf("slugify(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestBranchNameForWorkItem(t *testing.T) {
+	item := WorkItem{ID: 1234, Title: "Fix login on Safari", WorkItemType: "User Story"}
+
+	tests := []struct {
+		name     string
+		template string
+		user     string
+		expected string
+	}{
+		{"default template", "", "jane", "users/jane/1234-fix-login-on-safari"},
+		{"type prefix", "{type}/{id}-{title}", "jane", "user-story/1234-fix-login-on-safari"},
+		{"empty user", "users/{user}/{id}", "", "users/1234"},
+		{"id only", "ab#{id}", "jane", "ab#1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := branchNameForWorkItem(tt.template, item, tt.user); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWorkItemIDFromBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		branch   string
+		template string
+		expected int
+	}{
+		{"default template", "users/jane/1234-fix-login", "", 1234},
+		{"custom template", "feature/wi42", "feature/wi{id}", 42},
+		{"fallback id first", "bugfix/987-crash", "", 987},
+		{"fallback underscore", "topic/55_crash", "", 55},
+		{"title with digits", "users/jane/77-upgrade-to-v2", "", 77},
+		{"no id", "main", "", 0},
+		{"no id in feature branch", "feature/dark-mode", "", 0},
+		{"prefixed id", "topic/bug-55", "", 0},
+		{"version", "release/v1.2", "", 0},
+		{"dotted version", "release/2024.10", "", 0},
+		{"date", "hotfix/2024-10-01", "", 0},
+		{"major version", "feature/v2", "", 0},
+		{"template only when configured", "bugfix/987-crash", "feature/wi{id}", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := workItemIDFromBranch(tt.branch, tt.template)
+			if id != tt.expected || ok != (tt.expected != 0) {
+				t.Errorf("expected %d, got %d (ok=%v)", tt.expected, id, ok)
+			}
+		})
+	}
+}
+
+func TestInProgressState(t *testing.T) {
+	states := []string{"New", "Active", "Resolved", "Closed"}
+	categories := map[string]string{"New": "Proposed", "Active": "InProgress", "Resolved": "Resolved", "Closed": "Completed"}
+
+	if got := inProgressState("New", states, categories); got != "Active" {
+		t.Errorf("expected Active, got %q", got)
+	}
+	if got := inProgressState("Active", states, categories); got != "" {
+		t.Errorf("expected no change for an item in progress, got %q", got)
+	}
+	if got := inProgressState("New", []string{"New"}, map[string]string{"New": "Proposed"}); got != "" {
+		t.Errorf("expected no change without an in progress state, got %q", got)
+	}
+}
+
+func TestCheckoutBranch(t *testing.T) {
+	dir := initTestRepo(t)
+
+	created, err := checkoutBranch(dir, "users/jane/1234-fix-login")
+	if err != nil || !created {
+		t.Fatalf("expected branch to be created, got created=%v err=%v", created, err)
+	}
+	if branch, _ := currentGitBranch(dir); branch != "users/jane/1234-fix-login" {
+		t.Errorf("expected new branch checked out, got %q", branch)
+	}
+
+	if _, err := runGit(dir, "checkout", "-q", "main"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, err = checkoutBranch(dir, "users/jane/1234-fix-login")
+	if err != nil || created {
+		t.Fatalf("expected existing branch to be checked out, got created=%v err=%v", created, err)
+	}
+	if branch, _ := currentGitBranch(dir); branch != "users/jane/1234-fix-login" {
+		t.Errorf("expected existing branch checked out, got %q", branch)
+	}
+
+	if _, err := checkoutBranch(dir, "bad..name"); err == nil {
+		t.Error("expected error for invalid branch name")
+	}
+	if _, err := checkoutBranch(t.TempDir(), "users/jane/1"); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
+
+func TestCheckoutWorkItemBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "Branch test", "Task", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	branch := branchNameForWorkItem("", *item, gitUserSlug(dir))
+	if branch != "users/jane-doe/"+strconv.Itoa(item.ID)+"-branch-test" {
+		t.Errorf("unexpected branch name %q", branch)
+	}
+
+	msg, ok := checkoutWorkItemBranch(db, dir, *item, branch)().(branchCheckedOutMsg)
+	if !ok || msg.err != nil || msg.stateErr != nil {
+		t.Fatalf("unexpected result: %+v", msg)
+	}
+	if !msg.created || msg.newState != "Active" {
+		t.Errorf("expected created branch and Active state, got %+v", msg)
+	}
+	if updated, _ := db.GetWorkItemByID(context.Background(), item.ID); updated.State != "Active" {
+		t.Errorf("expected item moved to Active, got %q", updated.State)
+	}
+
+	// The branch is recognized again on the next start
+	current, _ := currentGitBranch(dir)
+	if id, _ := workItemIDFromBranch(current, ""); id != item.ID {
+		t.Errorf("expected branch to map back to #%d, got %d", item.ID, id)
+	}
+}
+
+func TestSelectBranchWorkItem(t *testing.T) {
+	current := createTestList([]WorkItem{createTestWorkItem(1, "One", nil), createTestWorkItem(2, "Two", nil)})
+	next := createTestList([]WorkItem{createTestWorkItem(3, "Three", nil), createTestWorkItem(4, "Four", nil)})
+	m := model{
+		sprintLists:      map[sprintTab]*WorkItemList{currentSprint: current, nextSprint: next},
+		currentTab:       currentSprint,
+		branchWorkItemID: 4,
+	}
+	m.ui.height = 40
+
+	m.selectBranchWorkItem()
+
+	if m.currentTab != nextSprint || m.ui.cursor != 1 {
+		t.Errorf("expected cursor on #4 in next sprint, got tab %v cursor %d", m.currentTab, m.ui.cursor)
+	}
+	if m.branchWorkItemID != 0 {
+		t.Error("expected preselection to run only once")
+	}
+}
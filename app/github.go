@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHubClient is the Backend of a GitHub repository. Issues are work items, milestones are
+// sprints, sub-issues make up the tree and labels are tags.
+//
+// Work items are identified by issue number. Issues have no priority, remaining work or
+// attachments; updates to those fields are ignored.
+//
+// Issue operations are in github_issues.go
+// Milestone and list queries are in github_sprints.go
+type GitHubClient struct {
+	httpClient *http.Client
+	apiURL     string // REST API root, without a trailing slash
+	token      string
+	owner      string
+	repo       string
+
+	mu         sync.Mutex          // Guards the caches below; commands run concurrently
+	issues     map[int]githubIssue // Issues seen by list queries, by number
+	parents    map[int]int         // Parent issue number of each known sub-issue
+	milestones []githubMilestone   // Milestones of the repository, in sprint order
+	login      string              // Login of the authenticated user
+}
+
+// Compile-time check that GitHubClient implements Backend
+var _ Backend = (*GitHubClient)(nil)
+
+// defaultGitHubAPIURL is the REST API of github.com
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// githubPageSize is the most items GitHub returns per page
+const githubPageSize = 100
+
+// githubConcurrency is the most requests one call sends at once. The REST API has no batch
+// endpoints, and many more concurrent requests trip GitHub's secondary rate limits.
+const githubConcurrency = 8
+
+// githubIssue is an issue as the REST API returns it
+type githubIssue struct {
+	ID          int64            `json:"id"`
+	NodeID      string           `json:"node_id"`
+	Number      int              `json:"number"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	State       string           `json:"state"`
+	StateReason string           `json:"state_reason"`
+	Labels      []githubLabel    `json:"labels"`
+	Assignees   []githubUser     `json:"assignees"`
+	Milestone   *githubMilestone `json:"milestone"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+	HTMLURL     string           `json:"html_url"`
+	PullRequest *struct{}        `json:"pull_request"` // Set when the issue is a pull request
+	Type        *struct {
+		Name string `json:"name"`
+	} `json:"type"` // Issue type, in organizations that define them
+	SubIssuesSummary *struct {
+		Total int `json:"total"`
+	} `json:"sub_issues_summary"`
+	RepositoryURL string `json:"repository_url"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubMilestone is a milestone as the REST API returns it
+type githubMilestone struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	DueOn     string `json:"due_on"`
+	CreatedAt string `json:"created_at"`
+}
+
+// githubComment is a comment on an issue
+type githubComment struct {
+	User      githubUser `json:"user"`
+	Body      string     `json:"body"`
+	CreatedAt string     `json:"created_at"`
+}
+
+// GitHubError is a request the GitHub API rejected
+type GitHubError struct {
+	StatusCode  int
+	Message     string
+	RateLimited bool          // The request ran out of rate limit and can be sent again later
+	RetryAfter  time.Duration // How long the server asked to wait first, from Retry-After
+}
+
+func (e *GitHubError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// NewGitHubClient creates a client for the repository of the config, with a token from
+// HIPPO_GITHUB_TOKEN, GITHUB_TOKEN or the GitHub CLI
+func NewGitHubClient(config *Config) (*GitHubClient, error) {
+	if config.GitHub.Owner == "" || config.GitHub.Repo == "" {
+		return nil, fmt.Errorf("github.owner and github.repo are not set")
+	}
+
+	token, err := getGitHubToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub token: %w\nSet GITHUB_TOKEN or run 'gh auth login' first", err)
+	}
+
+	apiURL := config.GitHub.APIURL
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	return newGitHubClient(apiURL, token, config.GitHub.Owner, config.GitHub.Repo), nil
+}
+
+// newGitHubClient creates a client for the repository on the API at apiURL
+func newGitHubClient(apiURL, token, owner, repo string) *GitHubClient {
+	return &GitHubClient{
+		httpClient: &http.Client{},
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		token:      token,
+		owner:      owner,
+		repo:       repo,
+		issues:     make(map[int]githubIssue),
+		parents:    make(map[int]int),
+	}
+}
+
+// getGitHubToken returns the token from the environment, or from the GitHub CLI
+func getGitHubToken() (string, error) {
+	for _, name := range []string{"HIPPO_GITHUB_TOKEN", "GITHUB_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return token, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "gh", "auth", "token").Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("gh cli token acquisition timed out after 10 seconds")
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("gh cli error: %s", string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("failed to execute gh cli: %w", err)
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("received empty token from GitHub CLI")
+	}
+	return token, nil
+}
+
+// repoPath returns the API path of the repository followed by the path elements
+func (c *GitHubClient) repoPath(elements ...string) string {
+	return "/repos/" + url.PathEscape(c.owner) + "/" + url.PathEscape(c.repo) + strings.Join(elements, "")
+}
+
+// do sends a request to the API and decodes the JSON response into out, if not nil
+func (c *GitHubClient) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	return c.send(ctx, method, c.apiURL+path, query, body, out)
+}
+
+// send sends a request to an absolute URL of the API and decodes the JSON response into out
+func (c *GitHubClient) send(ctx context.Context, method, target string, query url.Values, body, out interface{}) error {
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return githubErrorFromResponse(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// concurrently calls fn for 0..n-1 with at most githubConcurrency calls running at once
+func concurrently(n int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(githubConcurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// githubErrorFromResponse builds the error of a failed response
+func githubErrorFromResponse(resp *http.Response) error {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = json.Unmarshal(data, &payload)
+
+	err := &GitHubError{StatusCode: resp.StatusCode, Message: payload.Message, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	// Primary rate limits answer 403 with no requests left; secondary limits answer 429, or
+	// 403 with a Retry-After header
+	err.RateLimited = resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden &&
+			(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""))
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && time.Until(at) > 0 {
+		return time.Until(at)
+	}
+	return 0
+}
+
+// isNotFound reports whether the API answered that the resource does not exist (or no longer does)
+func isNotFound(err error) bool {
+	var githubErr *GitHubError
+	return errors.As(err, &githubErr) && (githubErr.StatusCode == http.StatusNotFound || githubErr.StatusCode == http.StatusGone)
+}
+
+// listAll fetches every page of a list endpoint, passing each page to add
+func listAll[T any](ctx context.Context, c *GitHubClient, path string, query url.Values, add func([]T)) error {
+	query.Set("per_page", strconv.Itoa(githubPageSize))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var items []T
+		if err := c.do(ctx, http.MethodGet, path, query, nil, &items); err != nil {
+			return err
+		}
+		add(items)
+		if len(items) < githubPageSize {
+			return nil
+		}
+	}
+}
+
+// convertIssue converts a GitHub issue to our WorkItem struct
+func (c *GitHubClient) convertIssue(issue githubIssue) WorkItem {
+	task := WorkItem{
+		ID:          issue.Number,
+		Title:       issue.Title,
+		State:       githubIssueState(issue),
+		Description: issue.Body,
+		CreatedDate: formatDate(issue.CreatedAt),
+		ChangedDate: formatDate(issue.UpdatedAt),
+		AreaPath:    c.owner + "/" + c.repo,
+	}
+
+	task.WorkItemType = "Issue"
+	if issue.Type != nil && issue.Type.Name != "" {
+		task.WorkItemType = issue.Type.Name
+	}
+
+	if len(issue.Assignees) > 0 {
+		task.AssignedTo = issue.Assignees[0].Login
+	}
+
+	labels := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		labels[i] = label.Name
+	}
+	task.Tags = strings.Join(labels, "; ")
+
+	if issue.Milestone != nil {
+		task.IterationPath = issue.Milestone.Title
+	}
+
+	c.mu.Lock()
+	if parent, ok := c.parents[issue.Number]; ok {
+		task.ParentID = &parent
+	}
+	c.mu.Unlock()
+
+	return task
+}
+
+// githubWebURL returns the web address of the GitHub instance whose API is at apiURL
+func githubWebURL(apiURL string) string {
+	switch {
+	case apiURL == "" || strings.TrimSuffix(apiURL, "/") == defaultGitHubAPIURL:
+		return "https://github.com"
+	case strings.HasSuffix(strings.TrimSuffix(apiURL, "/"), "/api/v3"):
+		return strings.TrimSuffix(strings.TrimSuffix(apiURL, "/"), "/api/v3")
+	default:
+		return strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// graphQLURL returns the GraphQL endpoint next to the REST API
+func (c *GitHubClient) graphQLURL() string {
+	if strings.HasSuffix(c.apiURL, "/api/v3") {
+		return strings.TrimSuffix(c.apiURL, "/v3") + "/graphql"
+	}
+	return c.apiURL + "/graphql"
+}
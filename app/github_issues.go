@@ -0,0 +1,502 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// ISSUE OPERATIONS
+// =============================================================================
+
+// githubStates are the states of every issue, in process order. GitHub only knows open and
+// closed issues; closed issues keep why they were closed, which tells the last two apart.
+var githubStates = []string{"Open", "Closed", "Not Planned"}
+
+var githubStateCategories = map[string]string{
+	"Open":        "Proposed",
+	"Closed":      "Completed",
+	"Not Planned": "Removed",
+}
+
+var githubTransitions = map[string][]string{
+	"":            {"Open"},
+	"Open":        {"Closed", "Not Planned"},
+	"Closed":      {"Open", "Not Planned"},
+	"Not Planned": {"Open", "Closed"},
+}
+
+// errGitHubAttachments is returned for attachment operations, which the GitHub API does not offer
+var errGitHubAttachments = errors.New("attachments are not supported by the github backend; drag files into the issue on GitHub instead")
+
+// githubIssueState returns the state of an issue as shown in hippo
+func githubIssueState(issue githubIssue) string {
+	return githubState(issue.State, issue.StateReason)
+}
+
+// githubState maps a GitHub state and state reason to a hippo state
+func githubState(state, reason string) string {
+	switch {
+	case state == "open":
+		return "Open"
+	case reason == "not_planned":
+		return "Not Planned"
+	default:
+		return "Closed"
+	}
+}
+
+// githubStateFields returns the issue fields that put an issue in a hippo state
+func githubStateFields(state string) (map[string]interface{}, error) {
+	switch state {
+	case "Open":
+		return map[string]interface{}{"state": "open", "state_reason": "reopened"}, nil
+	case "Closed":
+		return map[string]interface{}{"state": "closed", "state_reason": "completed"}, nil
+	case "Not Planned":
+		return map[string]interface{}{"state": "closed", "state_reason": "not_planned"}, nil
+	}
+	return nil, fmt.Errorf("unknown state %q (GitHub issues are Open, Closed or Not Planned)", state)
+}
+
+// getIssue fetches one issue and remembers it
+func (c *GitHubClient) getIssue(ctx context.Context, number int) (githubIssue, error) {
+	var issue githubIssue
+	if err := c.do(ctx, http.MethodGet, c.repoPath("/issues/", strconv.Itoa(number)), nil, nil, &issue); err != nil {
+		return issue, err
+	}
+	c.remember([]githubIssue{issue})
+	return issue, nil
+}
+
+// remember caches issues so list rows need no request of their own
+func (c *GitHubClient) remember(issues []githubIssue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, issue := range issues {
+		c.issues[issue.Number] = issue
+	}
+}
+
+// issuesByNumbers returns the issues in the order of numbers, from the cache when fresh is
+// false. Issues deleted or transferred since the numbers were queried are skipped.
+func (c *GitHubClient) issuesByNumbers(ctx context.Context, numbers []int, fresh bool) ([]githubIssue, error) {
+	fetched := make([]githubIssue, len(numbers))
+	found := make([]bool, len(numbers))
+	errs := make([]error, len(numbers))
+	var missing []int
+	for i, number := range numbers {
+		c.mu.Lock()
+		fetched[i], found[i] = c.issues[number]
+		c.mu.Unlock()
+		if !found[i] || fresh {
+			missing = append(missing, i)
+		}
+	}
+
+	concurrently(len(missing), func(j int) {
+		i := missing[j]
+		issue, err := c.getIssue(ctx, numbers[i])
+		switch {
+		case isNotFound(err):
+			found[i] = false
+		case err != nil:
+			errs[i] = fmt.Errorf("failed to get issue #%d: %w", numbers[i], err)
+		default:
+			fetched[i], found[i] = issue, true
+		}
+	})
+
+	issues := make([]githubIssue, 0, len(numbers))
+	for i := range numbers {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if found[i] {
+			issues = append(issues, fetched[i])
+		}
+	}
+	return issues, nil
+}
+
+// GetWorkItemByID fetches a complete issue with its comments
+func (c *GitHubClient) GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error) {
+	items, err := c.GetWorkItemDetailsByIDs(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("work item %d not found", id)
+	}
+	return &items[0], nil
+}
+
+// GetWorkItemsByIDs returns the list rows of issues in the order of ids
+func (c *GitHubClient) GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	issues, err := c.issuesByNumbers(ctx, ids, false)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]WorkItem, len(issues))
+	for i, issue := range issues {
+		tasks[i] = c.convertIssue(issue).listProjection()
+	}
+	return tasks, nil
+}
+
+// GetWorkItemDetailsByIDs fetches complete issues, with their comments, in the order of ids
+func (c *GitHubClient) GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	issues, err := c.issuesByNumbers(ctx, ids, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]WorkItem, len(issues))
+	errs := make([]error, len(issues))
+	concurrently(len(issues), func(i int) {
+		tasks[i] = c.convertIssue(issues[i])
+		tasks[i].Comments, errs[i] = c.issueComments(ctx, issues[i].Number)
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// issueComments returns the comments of an issue as the discussion text of a work item
+func (c *GitHubClient) issueComments(ctx context.Context, number int) (string, error) {
+	var text string
+	err := listAll(ctx, c, c.repoPath("/issues/", strconv.Itoa(number), "/comments"), url.Values{}, func(comments []githubComment) {
+		for _, comment := range comments {
+			if text != "" {
+				text += "\n\n"
+			}
+			text += fmt.Sprintf("%s (%s):\n%s", comment.User.Login, formatDateTime(formatDate(comment.CreatedAt)), comment.Body)
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get comments of #%d: %w", number, err)
+	}
+	return text, nil
+}
+
+// UpdateWorkItemState opens or closes an issue
+func (c *GitHubClient) UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error {
+	return c.UpdateWorkItem(ctx, workItemID, map[string]interface{}{"state": newState})
+}
+
+// UpdateWorkItem updates the fields of an issue. Fields issues don't have, such as priority
+// or the fields other processes require for a state change, are ignored.
+func (c *GitHubClient) UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error {
+	fields, err := c.issueFields(ctx, updates)
+	if err != nil {
+		return err
+	}
+	comment, hasComment := updates["comment"].(string)
+	if len(fields) == 0 && !hasComment {
+		return fmt.Errorf("no valid fields to update")
+	}
+
+	number := strconv.Itoa(workItemID)
+	if len(fields) > 0 {
+		var issue githubIssue
+		if err := c.do(ctx, http.MethodPatch, c.repoPath("/issues/", number), nil, fields, &issue); err != nil {
+			return fmt.Errorf("failed to update issue #%d: %w", workItemID, err)
+		}
+		c.remember([]githubIssue{issue})
+	}
+	if hasComment {
+		body := map[string]interface{}{"body": comment}
+		if err := c.do(ctx, http.MethodPost, c.repoPath("/issues/", number, "/comments"), nil, body, nil); err != nil {
+			return fmt.Errorf("failed to comment on issue #%d: %w", workItemID, err)
+		}
+	}
+	return nil
+}
+
+// issueFields maps work item updates to the fields of the issue update request
+func (c *GitHubClient) issueFields(ctx context.Context, updates map[string]interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for key, value := range updates {
+		text, _ := value.(string)
+		switch key {
+		case "title":
+			fields["title"] = text
+		case "description":
+			fields["body"] = text
+		case "tags":
+			labels := splitTags(text)
+			if labels == nil {
+				labels = []string{} // Removes every label
+			}
+			fields["labels"] = labels
+		case "state":
+			stateFields, err := githubStateFields(text)
+			if err != nil {
+				return nil, err
+			}
+			for name, v := range stateFields {
+				fields[name] = v
+			}
+		case "iterationPath":
+			milestone, err := c.milestoneNumber(ctx, text)
+			if err != nil {
+				return nil, err
+			}
+			fields["milestone"] = milestone
+		}
+	}
+	return fields, nil
+}
+
+// UpdateWorkItems updates the fields of each issue, collecting the issues that failed; the
+// GitHub REST API updates one issue per request, so a few are sent at once
+func (c *GitHubClient) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	errs := make([]error, len(workItemIDs))
+	concurrently(len(workItemIDs), func(i int) {
+		errs[i] = c.UpdateWorkItem(ctx, workItemIDs[i], updates)
+	})
+
+	failed := make(map[int]error)
+	for i, id := range workItemIDs {
+		if errs[i] != nil {
+			failed[id] = errs[i]
+		}
+	}
+	return failed, nil
+}
+
+// CreateWorkItem creates an issue assigned to me, as a sub-issue of parentID if set.
+// The issue type and area path have no GitHub equivalent and are ignored.
+func (c *GitHubClient) CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+	login, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{"title": title, "assignees": []string{login}}
+	if iterationPath != "" {
+		milestone, err := c.milestoneNumber(ctx, iterationPath)
+		if err != nil {
+			return nil, err
+		}
+		fields["milestone"] = milestone
+	}
+
+	var issue githubIssue
+	if err := c.do(ctx, http.MethodPost, c.repoPath("/issues"), nil, fields, &issue); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	c.remember([]githubIssue{issue})
+
+	if parentID != nil {
+		// Sub-issues are linked by the issue's ID, not its number
+		body := map[string]interface{}{"sub_issue_id": issue.ID}
+		if err := c.do(ctx, http.MethodPost, c.repoPath("/issues/", strconv.Itoa(*parentID), "/sub_issues"), nil, body, nil); err != nil {
+			return nil, fmt.Errorf("created issue #%d but failed to add it to #%d: %w", issue.Number, *parentID, err)
+		}
+		c.mu.Lock()
+		c.parents[issue.Number] = *parentID
+		c.mu.Unlock()
+	}
+
+	task := c.convertIssue(issue)
+	return &task, nil
+}
+
+// DeleteWorkItem deletes an issue. The REST API cannot delete issues, so this goes through
+// GraphQL and needs admin rights on the repository.
+func (c *GitHubClient) DeleteWorkItem(ctx context.Context, workItemID int) error {
+	issue, err := c.getIssue(ctx, workItemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue #%d: %w", workItemID, err)
+	}
+
+	request := map[string]interface{}{
+		"query":     "mutation($id: ID!) { deleteIssue(input: {issueId: $id}) { clientMutationId } }",
+		"variables": map[string]interface{}{"id": issue.NodeID},
+	}
+	var response struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.send(ctx, http.MethodPost, c.graphQLURL(), nil, request, &response); err != nil {
+		return fmt.Errorf("failed to delete issue #%d: %w", workItemID, err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("failed to delete issue #%d: %s", workItemID, response.Errors[0].Message)
+	}
+
+	c.mu.Lock()
+	delete(c.issues, workItemID)
+	delete(c.parents, workItemID)
+	c.mu.Unlock()
+	return nil
+}
+
+// MoveWorkItemToSprint sets the milestone of an issue; an empty path removes it
+func (c *GitHubClient) MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error {
+	return c.UpdateWorkItem(ctx, workItemID, map[string]interface{}{"iterationPath": iterationPath})
+}
+
+// GetWorkItemTypeStates returns the states of issues, which are the same for every type
+func (c *GitHubClient) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	categories := make(map[string]string, len(githubStateCategories))
+	for state, category := range githubStateCategories {
+		categories[state] = category
+	}
+	return slices.Clone(githubStates), categories, nil
+}
+
+// GetWorkItemTypeTransitions returns the state changes issues allow
+func (c *GitHubClient) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	return githubTransitions, nil
+}
+
+// GetStateCategories returns the category of every issue state
+func (c *GitHubClient) GetStateCategories(ctx context.Context) (map[string]string, error) {
+	_, categories, err := c.GetWorkItemTypeStates(ctx, "")
+	return categories, err
+}
+
+// GetRequiredFields returns nothing: issues need no fields to change state
+func (c *GitHubClient) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	return nil, nil
+}
+
+// githubEvent is an entry of an issue's event log
+type githubEvent struct {
+	Event       string           `json:"event"`
+	Actor       githubUser       `json:"actor"`
+	CreatedAt   string           `json:"created_at"`
+	StateReason string           `json:"state_reason"`
+	Label       githubLabel      `json:"label"`
+	Milestone   *githubMilestone `json:"milestone"`
+	Assignee    *githubUser      `json:"assignee"`
+	Rename      *struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"rename"`
+}
+
+// issueRevisions rebuilds the revisions of an issue from its event log
+func (c *GitHubClient) issueRevisions(ctx context.Context, issue githubIssue) ([]WorkItemRevision, error) {
+	var events []githubEvent
+	err := listAll(ctx, c, c.repoPath("/issues/", strconv.Itoa(issue.Number), "/events"), url.Values{}, func(page []githubEvent) {
+		events = append(events, page...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events of #%d: %w", issue.Number, err)
+	}
+	return githubRevisions(c.convertIssue(issue), issue.CreatedAt, events), nil
+}
+
+// githubRevisions rebuilds the revisions of an issue, starting from its current fields and
+// undoing its events from the latest back. Each tracked event makes one revision; the first
+// revision is the issue as it was created.
+func githubRevisions(current WorkItem, createdAt string, events []githubEvent) []WorkItemRevision {
+	var tracked []githubEvent
+	for _, event := range events {
+		switch event.Event {
+		case "closed", "reopened", "renamed", "milestoned", "demilestoned", "labeled", "unlabeled", "assigned", "unassigned":
+			tracked = append(tracked, event)
+		}
+	}
+
+	revision := WorkItemRevision{
+		State:         current.State,
+		IterationPath: current.IterationPath,
+		Title:         current.Title,
+		AssignedTo:    current.AssignedTo,
+		Tags:          current.Tags,
+	}
+	revisions := make([]WorkItemRevision, len(tracked)+1)
+	for i := len(tracked) - 1; i >= 0; i-- {
+		event := tracked[i]
+		revision.Rev = i + 2
+		revision.ChangedBy = event.Actor.Login
+		revision.ChangedDate = formatDate(event.CreatedAt)
+		revisions[i+1] = revision
+
+		switch event.Event {
+		case "closed":
+			revision.State = "Open"
+		case "reopened":
+			revision.State = reopenedFromState(tracked[:i])
+		case "renamed":
+			if event.Rename != nil {
+				revision.Title = event.Rename.From
+			}
+		case "milestoned":
+			revision.IterationPath = ""
+		case "demilestoned":
+			if event.Milestone != nil {
+				revision.IterationPath = event.Milestone.Title
+			}
+		case "labeled":
+			labels := splitTags(revision.Tags)
+			revision.Tags = strings.Join(slices.DeleteFunc(labels, func(label string) bool { return label == event.Label.Name }), "; ")
+		case "unlabeled":
+			revision.Tags = strings.Join(append(splitTags(revision.Tags), event.Label.Name), "; ")
+		case "assigned":
+			revision.AssignedTo = ""
+		case "unassigned":
+			if event.Assignee != nil {
+				revision.AssignedTo = event.Assignee.Login
+			}
+		}
+	}
+
+	revision.Rev = 1
+	revision.ChangedBy = ""
+	revision.ChangedDate = formatDate(createdAt)
+	revisions[0] = revision
+	return revisions
+}
+
+// reopenedFromState returns the state a reopen undoes: Closed or Not Planned, after the reason
+// of the latest close among the earlier events
+func reopenedFromState(earlier []githubEvent) string {
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if earlier[i].Event == "closed" {
+			return githubState("closed", earlier[i].StateReason)
+		}
+	}
+	return githubState("closed", "")
+}
+
+// GetWorkItemHistory returns the changes of an issue, rebuilt from its event log
+func (c *GitHubClient) GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error) {
+	issue, err := c.getIssue(ctx, workItemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", workItemID, err)
+	}
+	revisions, err := c.issueRevisions(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	return diffRevisions(revisions), nil
+}
+
+// DownloadAttachment is not supported: issues have no attachments in the GitHub API
+func (c *GitHubClient) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	return nil, errGitHubAttachments
+}
+
+// UploadAttachment is not supported: issues have no attachments in the GitHub API
+func (c *GitHubClient) UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error) {
+	return nil, errGitHubAttachments
+}
+
+// AddAttachmentToWorkItem is not supported: issues have no attachments in the GitHub API
+func (c *GitHubClient) AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error {
+	return errGitHubAttachments
+}
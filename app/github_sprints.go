@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// MILESTONE AND LIST OPERATIONS
+// =============================================================================
+
+// loadMilestones fetches every milestone of the repository in sprint order: by due date,
+// with milestones that have no due date last
+func (c *GitHubClient) loadMilestones(ctx context.Context) ([]githubMilestone, error) {
+	var milestones []githubMilestone
+	query := url.Values{"state": {"all"}}
+	err := listAll(ctx, c, c.repoPath("/milestones"), query, func(page []githubMilestone) {
+		milestones = append(milestones, page...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestones: %w", err)
+	}
+
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i], milestones[j]
+		if (a.DueOn == "") != (b.DueOn == "") {
+			return a.DueOn != ""
+		}
+		if a.DueOn != b.DueOn {
+			return a.DueOn < b.DueOn
+		}
+		return a.Number < b.Number
+	})
+
+	c.mu.Lock()
+	c.milestones = milestones
+	c.mu.Unlock()
+	return milestones, nil
+}
+
+// milestoneNumber returns the number of the milestone titled title, or nil for no milestone
+func (c *GitHubClient) milestoneNumber(ctx context.Context, title string) (interface{}, error) {
+	if title == "" {
+		return nil, nil
+	}
+
+	find := func(milestones []githubMilestone) (int, bool) {
+		for _, milestone := range milestones {
+			if milestone.Title == title {
+				return milestone.Number, true
+			}
+		}
+		return 0, false
+	}
+
+	c.mu.Lock()
+	number, ok := find(c.milestones)
+	c.mu.Unlock()
+	if ok {
+		return number, nil
+	}
+
+	// The milestone may be newer than the cached list
+	milestones, err := c.loadMilestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number, ok := find(milestones); ok {
+		return number, nil
+	}
+	return nil, fmt.Errorf("milestone %q not found", title)
+}
+
+// githubSprints converts milestones to sprints. A milestone runs from the day after the
+// previous milestone is due, or from its creation, until its own due date.
+func githubSprints(milestones []githubMilestone) []Sprint {
+	sprints := make([]Sprint, len(milestones))
+	previousDue := ""
+	for i, milestone := range milestones {
+		sprint := Sprint{Name: milestone.Title, Path: milestone.Title}
+		if milestone.DueOn != "" {
+			sprint.EndDate = dateOnly(milestone.DueOn)
+			if previousDue != "" {
+				if due, err := time.Parse("2006-01-02", previousDue); err == nil {
+					sprint.StartDate = due.AddDate(0, 0, 1).Format("2006-01-02")
+				}
+			} else {
+				sprint.StartDate = dateOnly(milestone.CreatedAt)
+			}
+			previousDue = sprint.EndDate
+		}
+		sprints[i] = sprint
+	}
+	return sprints
+}
+
+// dateOnly returns the date part of an ISO 8601 timestamp
+func dateOnly(timestamp string) string {
+	if len(timestamp) > 10 {
+		return timestamp[:10]
+	}
+	return timestamp
+}
+
+// GetCurrentAndAdjacentSprints returns the milestone running today and the ones before and after
+// it. Without a running milestone the next one due is current, or else the last one.
+func (c *GitHubClient) GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error) {
+	milestones, err := c.loadMilestones(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	prev, curr, next = adjacentSprints(githubSprints(milestones), time.Now().Format("2006-01-02"))
+	return prev, curr, next, nil
+}
+
+// GetAllSprints returns every milestone of the repository, in sprint order
+func (c *GitHubClient) GetAllSprints(ctx context.Context) ([]Sprint, error) {
+	milestones, err := c.loadMilestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return githubSprints(milestones), nil
+}
+
+// listIssues returns the issues of a repository issue query, leaving out pull requests.
+// The issues are cached for the list rows and the sub-issues of each are looked up, so the
+// rows can be shown as a tree.
+func (c *GitHubClient) listIssues(ctx context.Context, query url.Values) ([]githubIssue, error) {
+	var issues []githubIssue
+	err := listAll(ctx, c, c.repoPath("/issues"), query, func(page []githubIssue) {
+		for _, issue := range page {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issues: %w", err)
+	}
+	c.remember(issues)
+
+	var parents []int
+	for _, issue := range issues {
+		if issue.SubIssuesSummary != nil && issue.SubIssuesSummary.Total > 0 {
+			parents = append(parents, issue.Number)
+		}
+	}
+	errs := make([]error, len(parents))
+	concurrently(len(parents), func(i int) {
+		errs[i] = c.loadSubIssues(ctx, parents[i])
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
+
+// loadSubIssues records the issue as the parent of its sub-issues in this repository
+func (c *GitHubClient) loadSubIssues(ctx context.Context, number int) error {
+	var children []githubIssue
+	err := listAll(ctx, c, c.repoPath("/issues/", strconv.Itoa(number), "/sub_issues"), url.Values{}, func(page []githubIssue) {
+		children = append(children, page...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get sub-issues of #%d: %w", number, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, child := range children {
+		// Sub-issues may live in other repositories, where the number means another issue
+		if child.RepositoryURL == "" || strings.HasSuffix(child.RepositoryURL, c.repoPath()) {
+			c.parents[child.Number] = number
+		}
+	}
+	return nil
+}
+
+// milestoneFilter returns the milestone parameter of an issue query for the milestone titled
+// title, or for issues without a milestone when title is empty
+func (c *GitHubClient) milestoneFilter(ctx context.Context, title string) (string, error) {
+	milestone, err := c.milestoneNumber(ctx, title)
+	if err != nil || milestone == nil {
+		return "none", err
+	}
+	return fmt.Sprint(milestone), nil
+}
+
+// issueNumbers returns the numbers of the issues
+func issueNumbers(issues []githubIssue) []int {
+	numbers := make([]int, len(issues))
+	for i, issue := range issues {
+		numbers[i] = issue.Number
+	}
+	return numbers
+}
+
+// myOpenIssuesQuery selects my open issues, most recently updated first
+func (c *GitHubClient) myOpenIssuesQuery(ctx context.Context) (url.Values, error) {
+	login, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return url.Values{
+		"state":     {"open"},
+		"assignee":  {login},
+		"sort":      {"updated"},
+		"direction": {"desc"},
+	}, nil
+}
+
+// GetSprintWorkItemIDs returns the numbers of my open issues, most recently updated first,
+// optionally limited to a milestone
+func (c *GitHubClient) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	query, err := c.myOpenIssuesQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sprintPath != "" {
+		milestone, err := c.milestoneFilter(ctx, sprintPath)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("milestone", milestone)
+	}
+
+	issues, err := c.listIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return issueNumbers(issues), nil
+}
+
+// GetSprintWorkItemIDsInState returns the numbers of my issues in a state, open or closed,
+// most recently updated first, optionally limited to a milestone
+func (c *GitHubClient) GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error) {
+	query, err := c.myOpenIssuesQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(state, "Open") {
+		// Closed and Not Planned differ only in the state reason
+		query.Set("state", "closed")
+	}
+	if sprintPath != "" {
+		milestone, err := c.milestoneFilter(ctx, sprintPath)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("milestone", milestone)
+	}
+
+	issues, err := c.listIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	issues = slices.DeleteFunc(issues, func(issue githubIssue) bool {
+		return !strings.EqualFold(githubIssueState(issue), state)
+	})
+	return issueNumbers(issues), nil
+}
+
+// GetRecentBacklogItemIDs returns the numbers of my open issues without a milestone, updated
+// in the last 30 days, most recently updated first
+func (c *GitHubClient) GetRecentBacklogItemIDs(ctx context.Context) ([]int, error) {
+	query, err := c.myOpenIssuesQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.Set("milestone", "none")
+	query.Set("since", time.Now().AddDate(0, 0, -30).UTC().Format(time.RFC3339))
+
+	issues, err := c.listIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return issueNumbers(issues), nil
+}
+
+// GetAbandonedWorkItemIDs returns the numbers of my open issues outside the current milestone
+// that have not been updated in 14+ days, oldest first
+func (c *GitHubClient) GetAbandonedWorkItemIDs(ctx context.Context, currentSprintPath string) ([]int, error) {
+	query, err := c.myOpenIssuesQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.Set("direction", "asc")
+
+	issues, err := c.listIssues(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	staleBefore := time.Now().AddDate(0, 0, -14).UTC().Format(time.RFC3339)
+	var numbers []int
+	for _, issue := range issues {
+		if issue.UpdatedAt >= staleBefore {
+			continue
+		}
+		if issue.Milestone != nil && currentSprintPath != "" && issue.Milestone.Title == currentSprintPath {
+			continue
+		}
+		numbers = append(numbers, issue.Number)
+	}
+	return numbers, nil
+}
+
+// GetSprintWorkItemRevisions returns the revisions of my issues in a milestone, keyed by number.
+// Closed issues are included so the burndown can count them.
+func (c *GitHubClient) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	issues, err := c.sprintIssues(ctx, sprintPath)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[int][]WorkItemRevision, len(issues))
+	for _, issue := range issues {
+		requestCtx, cancel := subRequest(ctx)
+		revisions, err := c.issueRevisions(requestCtx, issue)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		history[issue.Number] = revisions
+	}
+	return history, nil
+}
+
+// sprintIssues lists my open and closed issues in a milestone
+func (c *GitHubClient) sprintIssues(ctx context.Context, sprintPath string) ([]githubIssue, error) {
+	ctx, cancel := subRequest(ctx)
+	defer cancel()
+	query, err := c.myOpenIssuesQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	milestone, err := c.milestoneFilter(ctx, sprintPath)
+	if err != nil {
+		return nil, err
+	}
+	query.Set("state", "all")
+	query.Set("milestone", milestone)
+	return c.listIssues(ctx, query)
+}
+
+// GetCurrentUser returns the login of the authenticated user
+func (c *GitHubClient) GetCurrentUser(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	login := c.login
+	c.mu.Unlock()
+	if login != "" {
+		return login, nil
+	}
+
+	var user githubUser
+	if err := c.do(ctx, http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	c.mu.Lock()
+	c.login = user.Login
+	c.mu.Unlock()
+	return user.Login, nil
+}
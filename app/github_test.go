@@ -0,0 +1,561 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGitHub is an in-memory stand-in for the parts of the GitHub REST API the backend uses
+type fakeGitHub struct {
+	t          *testing.T
+	mu         sync.Mutex
+	login      string
+	issues     map[int]*githubIssue
+	order      []int // Issue numbers in the order list endpoints return them
+	milestones []githubMilestone
+	subIssues  map[int][]int
+	comments   map[int][]githubComment
+	events     map[int][]githubEvent
+	requests   []string // "METHOD path" of every request
+	rateLimit  bool     // Answer the next request as out of rate limit
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *GitHubClient) {
+	t.Helper()
+	fake := &fakeGitHub{
+		t:         t,
+		login:     "octo",
+		issues:    make(map[int]*githubIssue),
+		subIssues: make(map[int][]int),
+		comments:  make(map[int][]githubComment),
+		events:    make(map[int][]githubEvent),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, newGitHubClient(server.URL, "token", "acme", "web")
+}
+
+// addIssue stores an issue assigned to the login unless assignees are given
+func (f *fakeGitHub) addIssue(issue githubIssue) {
+	if issue.Assignees == nil {
+		issue.Assignees = []githubUser{{Login: f.login}}
+	}
+	if issue.State == "" {
+		issue.State = "open"
+	}
+	issue.ID = int64(1000 + issue.Number)
+	issue.NodeID = "I_" + strconv.Itoa(issue.Number)
+	issue.RepositoryURL = "https://api.github.com/repos/acme/web"
+	f.issues[issue.Number] = &issue
+	f.order = append(f.order, issue.Number)
+}
+
+func (f *fakeGitHub) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		f.writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return
+	}
+	if f.rateLimit {
+		f.rateLimit = false
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		f.writeJSON(w, http.StatusForbidden, map[string]string{"message": "API rate limit exceeded"})
+		return
+	}
+	// Every list fits on the first page
+	if page := r.URL.Query().Get("page"); page != "" && page != "1" {
+		f.writeJSON(w, http.StatusOK, []interface{}{})
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	if r.URL.Path == "/user" {
+		f.writeJSON(w, http.StatusOK, githubUser{Login: f.login})
+		return
+	}
+	if r.URL.Path == "/graphql" {
+		f.deleteIssue(w, body)
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, "/repos/acme/web/")
+	if !ok {
+		f.writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	parts := strings.Split(rest, "/")
+	switch {
+	case rest == "milestones":
+		f.writeJSON(w, http.StatusOK, f.milestones)
+	case rest == "issues" && r.Method == http.MethodGet:
+		f.listIssues(w, r)
+	case rest == "issues" && r.Method == http.MethodPost:
+		number := len(f.issues) + 100
+		f.addIssue(githubIssue{Number: number, Title: body["title"].(string)})
+		f.updateIssue(f.issues[number], body)
+		f.writeJSON(w, http.StatusCreated, f.issues[number])
+	case len(parts) >= 2 && parts[0] == "issues":
+		number, _ := strconv.Atoi(parts[1])
+		issue := f.issues[number]
+		if issue == nil {
+			f.writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+			return
+		}
+		f.serveIssue(w, r, issue, parts[2:], body)
+	default:
+		f.writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func (f *fakeGitHub) listIssues(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	issues := []githubIssue{}
+	for _, number := range f.order {
+		issue := f.issues[number]
+		if issue == nil {
+			continue
+		}
+		if state := query.Get("state"); state != "all" && issue.State != state {
+			continue
+		}
+		if assignee := query.Get("assignee"); assignee != "" && (len(issue.Assignees) == 0 || issue.Assignees[0].Login != assignee) {
+			continue
+		}
+		switch milestone := query.Get("milestone"); milestone {
+		case "":
+		case "none":
+			if issue.Milestone != nil {
+				continue
+			}
+		default:
+			if issue.Milestone == nil || strconv.Itoa(issue.Milestone.Number) != milestone {
+				continue
+			}
+		}
+		issues = append(issues, *issue)
+	}
+	f.writeJSON(w, http.StatusOK, issues)
+}
+
+func (f *fakeGitHub) serveIssue(w http.ResponseWriter, r *http.Request, issue *githubIssue, sub []string, body map[string]interface{}) {
+	switch {
+	case len(sub) == 0 && r.Method == http.MethodGet:
+		f.writeJSON(w, http.StatusOK, issue)
+	case len(sub) == 0 && r.Method == http.MethodPatch:
+		f.updateIssue(issue, body)
+		f.writeJSON(w, http.StatusOK, issue)
+	case sub[0] == "comments" && r.Method == http.MethodPost:
+		comment := githubComment{User: githubUser{Login: f.login}, Body: body["body"].(string), CreatedAt: "2025-03-01T10:00:00Z"}
+		f.comments[issue.Number] = append(f.comments[issue.Number], comment)
+		f.writeJSON(w, http.StatusCreated, comment)
+	case sub[0] == "comments":
+		f.writeJSON(w, http.StatusOK, append([]githubComment{}, f.comments[issue.Number]...))
+	case sub[0] == "events":
+		f.writeJSON(w, http.StatusOK, append([]githubEvent{}, f.events[issue.Number]...))
+	case sub[0] == "sub_issues" && r.Method == http.MethodPost:
+		id := int64(body["sub_issue_id"].(float64))
+		for number, child := range f.issues {
+			if child.ID == id {
+				f.subIssues[issue.Number] = append(f.subIssues[issue.Number], number)
+				f.writeJSON(w, http.StatusCreated, issue)
+				return
+			}
+		}
+		f.writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"})
+	case sub[0] == "sub_issues":
+		children := []githubIssue{}
+		for _, number := range f.subIssues[issue.Number] {
+			children = append(children, *f.issues[number])
+		}
+		f.writeJSON(w, http.StatusOK, children)
+	default:
+		f.writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func (f *fakeGitHub) updateIssue(issue *githubIssue, body map[string]interface{}) {
+	for key, value := range body {
+		switch key {
+		case "title":
+			issue.Title = value.(string)
+		case "body":
+			issue.Body = value.(string)
+		case "state":
+			issue.State = value.(string)
+		case "state_reason":
+			issue.StateReason = value.(string)
+		case "labels":
+			issue.Labels = nil
+			for _, name := range value.([]interface{}) {
+				issue.Labels = append(issue.Labels, githubLabel{Name: name.(string)})
+			}
+		case "milestone":
+			issue.Milestone = nil
+			for i := range f.milestones {
+				if value != nil && f.milestones[i].Number == int(value.(float64)) {
+					issue.Milestone = &f.milestones[i]
+				}
+			}
+		}
+	}
+	issue.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+}
+
+func (f *fakeGitHub) deleteIssue(w http.ResponseWriter, body map[string]interface{}) {
+	variables, _ := body["variables"].(map[string]interface{})
+	for number, issue := range f.issues {
+		if issue.NodeID == variables["id"] {
+			delete(f.issues, number)
+			f.writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"deleteIssue": map[string]interface{}{}}})
+			return
+		}
+	}
+	f.writeJSON(w, http.StatusOK, map[string]interface{}{"errors": []map[string]string{{"message": "Could not resolve to a node"}}})
+}
+
+// countRequests returns how many requests matched "METHOD path"
+func (f *fakeGitHub) countRequests(request string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, r := range f.requests {
+		if r == request {
+			count++
+		}
+	}
+	return count
+}
+
+// seedSprint adds three consecutive two-week milestones around today and issues in the current one
+func (f *fakeGitHub) seedSprint() {
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).UTC().Format("2006-01-02") + "T07:00:00Z"
+	}
+	f.milestones = []githubMilestone{
+		// Listed out of order; the backend orders them by due date
+		{Number: 3, Title: "Sprint 3", State: "open", DueOn: day(20), CreatedAt: day(-30)},
+		{Number: 1, Title: "Sprint 1", State: "closed", DueOn: day(-8), CreatedAt: day(-30)},
+		{Number: 2, Title: "Sprint 2", State: "open", DueOn: day(6), CreatedAt: day(-30)},
+		{Number: 4, Title: "Someday", State: "open", CreatedAt: day(-30)},
+	}
+	current := &f.milestones[2]
+	f.addIssue(githubIssue{Number: 1, Title: "Checkout flow", Milestone: current, SubIssuesSummary: &struct {
+		Total int `json:"total"`
+	}{Total: 1}, Labels: []githubLabel{{Name: "frontend"}, {Name: "p1"}}, Body: "The whole flow"})
+	f.addIssue(githubIssue{Number: 2, Title: "Card form", Milestone: current})
+	f.addIssue(githubIssue{Number: 3, Title: "Someone else's", Milestone: current, Assignees: []githubUser{{Login: "hubot"}}})
+	f.addIssue(githubIssue{Number: 4, Title: "Bump deps", Milestone: current, PullRequest: &struct{}{}})
+	f.addIssue(githubIssue{Number: 5, Title: "Unplanned idea", UpdatedAt: time.Now().UTC().Format(time.RFC3339)})
+	f.subIssues[1] = []int{2}
+}
+
+func TestGitHubSprintIDsInState(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.seedSprint()
+	current := &fake.milestones[2]
+	fake.addIssue(githubIssue{Number: 6, Title: "Shipped", Milestone: current, State: "closed", StateReason: "completed"})
+	fake.addIssue(githubIssue{Number: 7, Title: "Dropped", Milestone: current, State: "closed", StateReason: "not_planned"})
+
+	tests := []struct {
+		state string
+		want  []int
+	}{
+		{"Open", []int{1, 2}},
+		{"closed", []int{6}},
+		{"Not Planned", []int{7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			ids, err := client.GetSprintWorkItemIDsInState(context.Background(), "Sprint 2", tt.state)
+			if err != nil {
+				t.Fatalf("GetSprintWorkItemIDsInState() error: %v", err)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("IDs = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubBackendSprintsAndTree(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.seedSprint()
+	ctx := context.Background()
+
+	prev, curr, next, err := client.GetCurrentAndAdjacentSprints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentAndAdjacentSprints() error: %v", err)
+	}
+	if prev == nil || curr == nil || next == nil || prev.Name != "Sprint 1" || curr.Name != "Sprint 2" || next.Name != "Sprint 3" {
+		t.Fatalf("sprints = %v, %v, %v; want Sprint 1, 2 and 3", prev, curr, next)
+	}
+	if want := time.Now().AddDate(0, 0, -7).UTC().Format("2006-01-02"); curr.StartDate != want {
+		t.Errorf("current sprint starts %s, want the day after the previous one is due (%s)", curr.StartDate, want)
+	}
+
+	ids, err := client.GetSprintWorkItemIDs(ctx, curr.Path)
+	if err != nil {
+		t.Fatalf("GetSprintWorkItemIDs() error: %v", err)
+	}
+	if want := []int{1, 2}; !slices.Equal(ids, want) {
+		t.Fatalf("sprint IDs = %v, want my issues %v without pull requests", ids, want)
+	}
+
+	rows, err := client.GetWorkItemsByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetWorkItemsByIDs() error: %v", err)
+	}
+	if fake.countRequests("GET /repos/acme/web/issues/1") != 0 {
+		t.Error("list rows were fetched again; want them from the list query")
+	}
+	if rows[0].Tags != "frontend; p1" || rows[0].IterationPath != "Sprint 2" || rows[0].State != "Open" || !rows[0].Partial || rows[0].Description != "" {
+		t.Errorf("row = %+v, want the labels as tags and the milestone as iteration", rows[0])
+	}
+	if rows[1].ParentID == nil || *rows[1].ParentID != 1 {
+		t.Errorf("sub-issue parent = %v, want #1", rows[1].ParentID)
+	}
+
+	backlog, err := client.GetRecentBacklogItemIDs(ctx)
+	if err != nil || !slices.Equal(backlog, []int{5}) {
+		t.Errorf("backlog = %v (err: %v), want the issue without a milestone", backlog, err)
+	}
+
+	item, err := client.GetWorkItemByID(ctx, 1)
+	if err != nil || item.Description != "The whole flow" || item.Partial {
+		t.Errorf("details = %+v (err: %v), want the complete issue", item, err)
+	}
+}
+
+func TestGitHubBackendUpdates(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.seedSprint()
+	ctx := context.Background()
+
+	failed, err := client.UpdateWorkItems(ctx, []int{1, 2, 99}, stateUpdates("Not Planned", map[string]string{"System.Reason": "Obsolete"}))
+	if err != nil {
+		t.Fatalf("UpdateWorkItems() error: %v", err)
+	}
+	if len(failed) != 1 || failed[99] == nil {
+		t.Fatalf("failed = %v, want only the missing issue", failed)
+	}
+	if issue := fake.issues[2]; issue.State != "closed" || issue.StateReason != "not_planned" {
+		t.Errorf("issue state = %s (%s), want closed as not planned", issue.State, issue.StateReason)
+	}
+
+	if err := client.MoveWorkItemToSprint(ctx, 1, "Sprint 3"); err != nil {
+		t.Fatalf("MoveWorkItemToSprint() error: %v", err)
+	}
+	if err := client.MoveWorkItemToSprint(ctx, 2, ""); err != nil {
+		t.Fatalf("MoveWorkItemToSprint() to backlog error: %v", err)
+	}
+	if fake.issues[1].Milestone == nil || fake.issues[1].Milestone.Title != "Sprint 3" || fake.issues[2].Milestone != nil {
+		t.Errorf("milestones = %v, %v; want Sprint 3 and none", fake.issues[1].Milestone, fake.issues[2].Milestone)
+	}
+	if err := client.MoveWorkItemToSprint(ctx, 1, "Sprint 9"); err == nil {
+		t.Error("moving to an unknown milestone succeeded")
+	}
+
+	updates := map[string]interface{}{"title": "Checkout", "tags": "backend; ", "priority": 1, "comment": "Split up"}
+	if err := client.UpdateWorkItem(ctx, 1, updates); err != nil {
+		t.Fatalf("UpdateWorkItem() error: %v", err)
+	}
+	item, err := client.GetWorkItemByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetWorkItemByID() error: %v", err)
+	}
+	if item.Title != "Checkout" || item.Tags != "backend" || !strings.Contains(item.Comments, "Split up") {
+		t.Errorf("item = %q, tags %q, comments %q; want the updates applied", item.Title, item.Tags, item.Comments)
+	}
+	if err := client.UpdateWorkItem(ctx, 1, map[string]interface{}{"priority": 1}); err == nil {
+		t.Error("an update with only fields issues lack succeeded")
+	}
+}
+
+func TestGitHubBackendCreateAndDelete(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.seedSprint()
+	ctx := context.Background()
+
+	parentID := 1
+	item, err := client.CreateWorkItem(ctx, "Validate card number", "Task", "Sprint 2", &parentID, "")
+	if err != nil {
+		t.Fatalf("CreateWorkItem() error: %v", err)
+	}
+	created := fake.issues[item.ID]
+	if created == nil || created.Milestone == nil || created.Milestone.Title != "Sprint 2" || created.Assignees[0].Login != "octo" {
+		t.Fatalf("created issue = %+v, want it in Sprint 2 and assigned to me", created)
+	}
+	if !slices.Contains(fake.subIssues[1], item.ID) || item.ParentID == nil || *item.ParentID != 1 {
+		t.Errorf("sub-issues of #1 = %v, want the new issue #%d", fake.subIssues[1], item.ID)
+	}
+
+	if err := client.DeleteWorkItem(ctx, item.ID); err != nil {
+		t.Fatalf("DeleteWorkItem() error: %v", err)
+	}
+	if fake.issues[item.ID] != nil {
+		t.Error("issue still exists after delete")
+	}
+	if rows, _ := client.GetWorkItemsByIDs(ctx, []int{item.ID, 2}); len(rows) != 1 || rows[0].ID != 2 {
+		t.Errorf("rows = %v, want the deleted issue skipped", rows)
+	}
+}
+
+func TestGitHubBackendErrors(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.seedSprint()
+
+	fake.rateLimit = true
+	_, err := client.GetAllSprints(context.Background())
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || !githubErr.RateLimited || !isRetryableError(err) {
+		t.Errorf("error = %v, want a retryable rate limit error", err)
+	}
+
+	client.token = "expired"
+	_, err = client.GetCurrentUser(context.Background())
+	if !errors.As(err, &githubErr) || githubErr.StatusCode != http.StatusUnauthorized || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("error = %v, want the server's message", err)
+	}
+}
+
+func TestGitHubRateLimitDetection(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		headers    map[string]string
+		want       bool
+		retryAfter time.Duration
+	}{
+		{"primary limit spent", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0"}, true, 0},
+		{"secondary limit with Retry-After", http.StatusForbidden, map[string]string{"Retry-After": "60"}, true, time.Minute},
+		{"too many requests", http.StatusTooManyRequests, nil, true, 0},
+		{"Retry-After as a date in the past", http.StatusTooManyRequests, map[string]string{"Retry-After": "Wed, 21 Oct 2015 07:28:00 GMT"}, true, 0},
+		{"forbidden", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "4999"}, false, 0},
+		{"not found with Retry-After", http.StatusNotFound, map[string]string{"Retry-After": "60"}, false, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: make(http.Header), Body: http.NoBody}
+			for name, value := range tt.headers {
+				resp.Header.Set(name, value)
+			}
+			var githubErr *GitHubError
+			if err := githubErrorFromResponse(resp); !errors.As(err, &githubErr) || githubErr.RateLimited != tt.want || githubErr.RetryAfter != tt.retryAfter {
+				t.Errorf("githubErrorFromResponse() = %#v, want RateLimited %v and RetryAfter %v", err, tt.want, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestConcurrentlyBoundsRequests(t *testing.T) {
+	var mu sync.Mutex
+	running, peak, calls := 0, 0, 0
+	concurrently(50, func(i int) {
+		mu.Lock()
+		running++
+		calls++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+	if calls != 50 || peak > githubConcurrency || peak < 2 {
+		t.Errorf("calls = %d, peak = %d; want every index with at most %d at once", calls, peak, githubConcurrency)
+	}
+}
+
+func TestGitHubRevisions(t *testing.T) {
+	current := WorkItem{Title: "Checkout", State: "Closed", IterationPath: "Sprint 2", Tags: "frontend; p1", AssignedTo: "octo"}
+	events := []githubEvent{
+		{Event: "assigned", Actor: githubUser{Login: "octo"}, CreatedAt: "2025-03-01T09:00:00Z", Assignee: &githubUser{Login: "octo"}},
+		{Event: "subscribed", CreatedAt: "2025-03-01T09:00:00Z"},
+		{Event: "milestoned", Actor: githubUser{Login: "octo"}, CreatedAt: "2025-03-02T09:00:00Z", Milestone: &githubMilestone{Title: "Sprint 2"}},
+		{Event: "labeled", Actor: githubUser{Login: "octo"}, CreatedAt: "2025-03-03T09:00:00Z", Label: githubLabel{Name: "p1"}},
+		{Event: "renamed", Actor: githubUser{Login: "hubot"}, CreatedAt: "2025-03-04T09:00:00Z", Rename: &struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		}{From: "Checkout flow", To: "Checkout"}},
+		{Event: "closed", Actor: githubUser{Login: "octo"}, CreatedAt: "2025-03-05T09:00:00Z"},
+	}
+
+	revisions := githubRevisions(current, "2025-02-28T09:00:00Z", events)
+	if len(revisions) != 6 {
+		t.Fatalf("got %d revisions, want the creation and one per tracked event", len(revisions))
+	}
+	first := revisions[0]
+	if first.State != "Open" || first.Title != "Checkout flow" || first.IterationPath != "" || first.Tags != "frontend" || first.AssignedTo != "" {
+		t.Errorf("first revision = %+v, want the issue as created", first)
+	}
+	if last := revisions[5]; last.State != "Closed" || last.ChangedDate != "2025-03-05T09:00:00" || last.Rev != 6 {
+		t.Errorf("last revision = %+v, want the close", last)
+	}
+
+	updates := diffRevisions(revisions)
+	if got := stateTimeline(updates); !strings.Contains(got, "Closed") {
+		t.Errorf("state timeline = %q, want the close", got)
+	}
+}
+
+func TestGitHubRevisionsUndoReopen(t *testing.T) {
+	current := WorkItem{Title: "Idea", State: "Closed"}
+	events := []githubEvent{
+		{Event: "closed", CreatedAt: "2025-03-01T09:00:00Z", StateReason: "not_planned"},
+		{Event: "reopened", CreatedAt: "2025-03-02T09:00:00Z"},
+		{Event: "closed", CreatedAt: "2025-03-03T09:00:00Z", StateReason: "completed"},
+	}
+
+	var states []string
+	for _, revision := range githubRevisions(current, "2025-02-28T09:00:00Z", events) {
+		states = append(states, revision.State)
+	}
+	if want := []string{"Open", "Not Planned", "Open", "Closed"}; !slices.Equal(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
+
+func TestItemWebURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		want   string
+	}{
+		{"azure devops", &Config{OrganizationURL: "https://dev.azure.com/acme/", Project: "Web"}, "https://dev.azure.com/acme/Web/_workitems/edit/42"},
+		{"github", &Config{Backend: githubBackend, GitHub: GitHubConfig{Owner: "acme", Repo: "web"}}, "https://github.com/acme/web/issues/42"},
+		{"github enterprise", &Config{Backend: githubBackend, GitHub: GitHubConfig{Owner: "acme", Repo: "web", APIURL: "https://git.acme.dev/api/v3/"}}, "https://git.acme.dev/acme/web/issues/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemWebURL(tt.config, 42); got != tt.want {
+				t.Errorf("itemWebURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
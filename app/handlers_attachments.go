@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openAttachments shows the attachments of the selected work item
+func (m model) openAttachments() (model, tea.Cmd) {
+	if m.selectedTask == nil {
+		return m, nil
+	}
+	if m.selectedTask.Partial {
+		m.statusMessage = "Loading details..."
+		return m, nil
+	}
+
+	pathInput := textinput.New()
+	pathInput.CharLimit = 1024
+	pathInput.Width = 60
+
+	m.attachments = AttachmentsState{pathInput: pathInput}
+	m.state = attachmentsView
+	m.statusMessage = ""
+	return m, nil
+}
+
+// getSelectedAttachment returns the attachment under the cursor, or nil if there are none
+func (m model) getSelectedAttachment() *Attachment {
+	if m.selectedTask == nil || m.attachments.cursor < 0 || m.attachments.cursor >= len(m.selectedTask.Attachments) {
+		return nil
+	}
+	return &m.selectedTask.Attachments[m.attachments.cursor]
+}
+
+// startAttachmentPrompt switches the pane to a path prompt prefilled with value
+func (m *model) startAttachmentPrompt(mode attachmentsMode, value string) tea.Cmd {
+	m.attachments.mode = mode
+	m.attachments.completions = nil
+	m.attachments.pathInput.SetValue(value)
+	m.attachments.pathInput.CursorEnd()
+	m.statusMessage = ""
+	return m.attachments.pathInput.Focus()
+}
+
+// handleAttachmentsView handles keyboard input in the attachments pane
+func (m model) handleAttachmentsView(msg tea.KeyMsg) (model, tea.Cmd) {
+	if m.attachments.mode != browseAttachments {
+		return m.handleAttachmentPathInput(msg)
+	}
+
+	switch m.viewAction(attachmentsKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = detailView
+		m.statusMessage = ""
+		return m, nil
+	case actionUp:
+		if m.attachments.cursor > 0 {
+			m.attachments.cursor--
+		}
+	case actionDown:
+		if m.selectedTask != nil && m.attachments.cursor < len(m.selectedTask.Attachments)-1 {
+			m.attachments.cursor++
+		}
+	case actionDownload:
+		if attachment := m.getSelectedAttachment(); attachment != nil && m.client != nil {
+			return m, m.startAttachmentPrompt(downloadAttachment, defaultDownloadPath(*attachment))
+		}
+	case actionUpload:
+		if m.client != nil {
+			return m, m.startAttachmentPrompt(uploadAttachment, "")
+		}
+	}
+	return m, nil
+}
+
+// handleAttachmentPathInput handles the download/upload path prompt
+func (m model) handleAttachmentPathInput(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(attachmentPathKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.attachments.mode = browseAttachments
+		m.attachments.completions = nil
+		m.attachments.pathInput.Blur()
+		return m, nil
+	case actionCompletePath:
+		completed, candidates := completePath(m.attachments.pathInput.Value())
+		m.attachments.pathInput.SetValue(completed)
+		m.attachments.pathInput.CursorEnd()
+		m.attachments.completions = nil
+		if len(candidates) > 1 {
+			m.attachments.completions = candidates
+		}
+		return m, nil
+	case actionConfirm:
+		path := strings.TrimSpace(m.attachments.pathInput.Value())
+		mode := m.attachments.mode
+		m.attachments.mode = browseAttachments
+		m.attachments.completions = nil
+		m.attachments.pathInput.Blur()
+
+		if mode == downloadAttachment {
+			attachment := m.getSelectedAttachment()
+			if attachment == nil {
+				return m, nil
+			}
+			m.loading = true
+			m.statusMessage = fmt.Sprintf("Downloading %s...", attachment.Name)
+			return m, tea.Batch(downloadAttachmentTo(m.client, *attachment, resolveDownloadPath(path, *attachment)), m.spinner.Tick)
+		}
+
+		if path == "" {
+			m.statusMessage = "No file selected"
+			return m, nil
+		}
+		m.loading = true
+		m.statusMessage = fmt.Sprintf("Uploading %s...", path)
+		return m, tea.Batch(uploadAttachmentFrom(m.client, m.selectedTask.ID, expandHome(path)), m.spinner.Tick)
+	}
+
+	var cmd tea.Cmd
+	m.attachments.pathInput, cmd = m.attachments.pathInput.Update(msg)
+	m.attachments.completions = nil
+	return m, cmd
+}
+
+// handleAttachmentDownloadedMsg handles the attachmentDownloadedMsg response
+func (m model) handleAttachmentDownloadedMsg(msg attachmentDownloadedMsg) (model, tea.Cmd) {
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error downloading attachment: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error downloading attachment: %v", msg.err))
+		return m, nil
+	}
+
+	m.setActionLog(fmt.Sprintf("Saved %s (%s)", msg.path, formatFileSize(int64(msg.size))))
+	return m, nil
+}
+
+// handleAttachmentUploadedMsg handles the attachmentUploadedMsg response
+func (m model) handleAttachmentUploadedMsg(msg attachmentUploadedMsg) (model, tea.Cmd) {
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error attaching %s: %v", msg.name, msg.err)
+		m.setActionLog(fmt.Sprintf("Error attaching %s: %v", msg.name, msg.err))
+		return m, nil
+	}
+
+	m.setActionLog(fmt.Sprintf("Attached %s to #%d", msg.name, msg.workItemID))
+
+	// Reload the item so the new attachment shows up
+	m.loading = true
+	m.statusMessage = "Refreshing..."
+	return m, tea.Batch(refreshWorkItem(m.client, msg.workItemID), m.spinner.Tick)
+}
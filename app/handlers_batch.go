@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedIDs returns the batch-selected work item IDs in ascending order
+func (m model) selectedIDs() []int {
+	ids := make([]int, 0, len(m.batch.selectedItems))
+	for id := range m.batch.selectedItems {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// isSelected reports whether the work item is in the batch selection
+func (b BatchState) isSelected(workItemID int) bool {
+	_, ok := b.selectedItems[workItemID]
+	return ok
+}
+
+// startBatch runs the job over the items and clears the selection
+func (m model) startBatch(job batchJob, workItemIDs []int) (model, tea.Cmd) {
+	m.batch.job = job
+	m.batch.running = true
+	m.batch.total = len(workItemIDs)
+	m.batch.done = 0
+	m.batch.failures = nil
+	m.batch.selectedItems = make(map[int]WorkItem)
+
+	m.loading = true
+	m.state = listView
+	m.stateCursor = 0
+	m.statusMessage = fmt.Sprintf("%s %d work items...", job.progress, len(workItemIDs))
+	return m, tea.Batch(runBatch(m.work.context(), m.client, job, workItemIDs), m.spinner.Tick)
+}
+
+// handleBatchProgressMsg counts a finished item and waits for the next one
+func (m model) handleBatchProgressMsg(msg batchProgressMsg) (model, tea.Cmd) {
+	m.batch.done++
+	if msg.result.err != nil {
+		m.batch.failures = append(m.batch.failures, batchFailure{workItemID: msg.result.workItemID, err: msg.result.err})
+	}
+	return m, waitForBatchResult(msg.results)
+}
+
+// handleBatchFinishedMsg reports the batch and refreshes the list, or shows the failures first
+func (m model) handleBatchFinishedMsg(msg batchFinishedMsg) (model, tea.Cmd) {
+	m.batch.running = false
+	m.loading = false
+	m.statusMessage = ""
+
+	succeeded := m.batch.total - len(m.batch.failures)
+	if len(m.batch.failures) == 0 {
+		m.setActionLog(fmt.Sprintf("%s %d work items", m.batch.job.done, succeeded))
+		return m.reloadLists()
+	}
+
+	slices.SortFunc(m.batch.failures, func(a, b batchFailure) int {
+		return a.workItemID - b.workItemID
+	})
+	m.setActionLog(fmt.Sprintf("%s %d of %d work items, %d failed", m.batch.job.done, succeeded, m.batch.total, len(m.batch.failures)))
+	m.state = batchSummaryView
+	return m, nil
+}
+
+// handleBatchSummaryView handles keyboard input in the batch failure summary
+func (m model) handleBatchSummaryView(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(batchSummaryKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionRetry:
+		if m.client == nil {
+			return m, nil
+		}
+		ids := make([]int, len(m.batch.failures))
+		for i, failure := range m.batch.failures {
+			ids[i] = failure.workItemID
+		}
+		return m.startBatch(m.batch.job, ids)
+	case actionBack:
+		m.state = listView
+		m.batch.failures = nil
+		if m.batch.total > 0 && m.batch.done > 0 {
+			return m.reloadLists()
+		}
+	}
+	return m, nil
+}
+
+// reloadLists reloads the lists of the current mode so they show changes made by a batch
+// or outside Hippo
+func (m model) reloadLists() (model, tea.Cmd) {
+	m.state = listView
+	if m.client == nil {
+		return m, nil
+	}
+	m.loading = true
+	m.statusMessage = "Refreshing list..."
+	if m.currentMode == sprintMode {
+		m.sprintLists = make(map[sprintTab]*WorkItemList)
+		return m, tea.Batch(loadSprintsWithReload(m.client, true), m.spinner.Tick)
+	}
+	m.backlogLists = make(map[backlogTab]*WorkItemList)
+	return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, m.currentBacklogTab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
+}
+
+// batchProgressText describes the running batch with a progress bar
+func (m model) batchProgressText() string {
+	text := m.statusMessage + " " + batchProgressBar(m.batch.done, m.batch.total, 20)
+	if failed := len(m.batch.failures); failed > 0 {
+		text += fmt.Sprintf(" (%d failed)", failed)
+	}
+	return strings.TrimSpace(text)
+}
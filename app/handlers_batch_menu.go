@@ -6,35 +6,36 @@ import (
 
 // handleBatchEditMenuView handles keyboard input in the batch edit menu view
 func (m model) handleBatchEditMenuView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(batchEditMenuKeys, msg) {
+	case actionBack:
 		// Clear batch selection and return to list view
-		m.batch.selectedItems = make(map[int]bool)
+		m.batch.selectedItems = make(map[int]WorkItem)
 		m.state = listView
 		m.stateCursor = 0
+		m.closeViewLoads()
 		return m, nil
 
-	case "up", "k":
+	case actionUp:
 		if m.stateCursor > 0 {
 			m.stateCursor--
 		}
 
-	case "down", "j":
+	case actionDown:
 		maxOptions := 1 // State and Sprint (0-indexed, so max is 1)
 		if m.stateCursor < maxOptions {
 			m.stateCursor++
 		}
 
-	case "ctrl+u", "pgup":
+	case actionPageUp:
 		// Jump up half page
 		m.stateCursor = max(0, m.stateCursor-10)
 
-	case "ctrl+d", "pgdown":
+	case actionPageDown:
 		// Jump down half page
 		maxOptions := 1 // State and Sprint
 		m.stateCursor = min(maxOptions, m.stateCursor+10)
 
-	case "enter":
+	case actionConfirm:
 		// Based on cursor position, determine which field to edit
 		switch m.stateCursor {
 		case 0: // State
@@ -44,7 +45,7 @@ func (m model) handleBatchEditMenuView(msg tea.KeyMsg) (model, tea.Cmd) {
 				m.statusMessage = "Loading states..."
 				m.stateCursor = 0 // Reset for state picker
 				return m, tea.Batch(
-					loadWorkItemStates(m.client, "Task"),
+					loadWorkItemStates(m.viewLoads.context(), m.client, m.stateChangeItems()),
 					m.spinner.Tick,
 				)
 			}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openBoard switches to the board view for the current list and loads the states of its work item types
+func (m model) openBoard() (model, tea.Cmd) {
+	m.state = boardView
+	m.board = BoardState{typeStates: make(map[string][]string)}
+
+	if m.client == nil {
+		return m, nil
+	}
+
+	seen := make(map[string]bool)
+	var workItemTypes []string
+	for _, task := range m.getVisibleTasks() {
+		if task.WorkItemType != "" && !seen[task.WorkItemType] {
+			seen[task.WorkItemType] = true
+			workItemTypes = append(workItemTypes, task.WorkItemType)
+		}
+	}
+	sort.Strings(workItemTypes)
+
+	m.loading = true
+	m.statusMessage = "Loading states..."
+	return m, tea.Batch(loadBoardStates(m.client, workItemTypes), m.spinner.Tick)
+}
+
+// getBoardColumns builds the board columns from the visible tasks of the current list
+func (m model) getBoardColumns() []boardColumn {
+	return buildBoardColumns(m.getVisibleTasks(), m.board.typeStates, m.stateCategories)
+}
+
+// getBoardCard returns the selected card, or nil if the selected column is empty
+func (m model) getBoardCard(columns []boardColumn) *WorkItem {
+	if m.board.column < 0 || m.board.column >= len(columns) {
+		return nil
+	}
+	cards := columns[m.board.column].cards
+	if m.board.row < 0 || m.board.row >= len(cards) {
+		return nil
+	}
+	return &cards[m.board.row]
+}
+
+// selectBoardCard moves the board cursor to the card with the given ID, if it's on the board
+func (m *model) selectBoardCard(columns []boardColumn, workItemID int) {
+	for c, col := range columns {
+		for r, card := range col.cards {
+			if card.ID == workItemID {
+				m.board.column = c
+				m.board.row = r
+				return
+			}
+		}
+	}
+}
+
+// handleBoardView handles keyboard input in the board view
+func (m model) handleBoardView(msg tea.KeyMsg) (model, tea.Cmd) {
+	columns := m.getBoardColumns()
+
+	action := m.viewAction(boardKeys, msg)
+	switch action {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = listView
+		m.statusMessage = ""
+		return m, nil
+	case actionLeft:
+		if m.board.column > 0 {
+			m.board.column--
+		}
+	case actionRight:
+		if m.board.column < len(columns)-1 {
+			m.board.column++
+		}
+	case actionUp:
+		if m.board.row > 0 {
+			m.board.row--
+		}
+	case actionDown:
+		if m.board.column < len(columns) && m.board.row < len(columns[m.board.column].cards)-1 {
+			m.board.row++
+		}
+	case actionMoveLeft, actionMoveRight:
+		card := m.getBoardCard(columns)
+		if card == nil || m.client == nil || m.loading {
+			return m, nil
+		}
+
+		direction := 1
+		if action == actionMoveLeft {
+			direction = -1
+		}
+		newState := adjacentBoardState(columns, *card, direction, m.board.typeStates)
+		if newState == "" {
+			m.setActionLog(fmt.Sprintf("No further state for %s #%d", card.WorkItemType, card.ID))
+			return m, nil
+		}
+
+		m.loading = true
+		m.statusMessage = fmt.Sprintf("Moving #%d to %s...", card.ID, newState)
+		return m, tea.Batch(moveBoardCard(m.client, card.ID, card.State, newState), m.spinner.Tick)
+	case actionOpen:
+		if card := m.getBoardCard(columns); card != nil {
+			// Open the list's copy of the item so children and parent info are available
+			for _, treeItem := range m.getVisibleTreeItems() {
+				if treeItem.WorkItem.ID == card.ID {
+					m.selectedTask = treeItem.WorkItem
+					m.selectedTaskID = card.ID
+					m.board.fromBoard = true
+					m.state = detailView
+					return m, m.loadSelectedDetails()
+				}
+			}
+		}
+		return m, nil
+	}
+
+	// Keep the row within the selected column
+	if m.board.column < len(columns) {
+		if count := len(columns[m.board.column].cards); m.board.row >= count {
+			m.board.row = max(0, count-1)
+		}
+	}
+	return m, nil
+}
+
+// handleBoardStatesLoadedMsg handles the boardStatesLoadedMsg response
+func (m model) handleBoardStatesLoadedMsg(msg boardStatesLoadedMsg) (model, tea.Cmd) {
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading states: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error loading states: %v", msg.err))
+		return m, nil
+	}
+
+	m.board.typeStates = msg.typeStates
+	if m.stateCategories == nil {
+		m.stateCategories = make(map[string]string)
+	}
+	for state, category := range msg.stateCategories {
+		m.stateCategories[state] = category
+	}
+
+	// Start on the card that was under the list cursor
+	treeItems := m.getVisibleTreeItems()
+	if m.ui.cursor < len(treeItems) {
+		m.selectBoardCard(m.getBoardColumns(), treeItems[m.ui.cursor].WorkItem.ID)
+	}
+	return m, nil
+}
+
+// handleBoardCardMovedMsg handles the boardCardMovedMsg response
+func (m model) handleBoardCardMovedMsg(msg boardCardMovedMsg) (model, tea.Cmd) {
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error updating state: %v", msg.err))
+		return m, nil
+	}
+
+	// Update the loaded item in place instead of reloading the whole list
+	if list := m.getCurrentList(); list != nil {
+		list.setTaskState(msg.workItemID, msg.newState)
+	}
+	if m.state == boardView {
+		m.selectBoardCard(m.getBoardColumns(), msg.workItemID)
+	}
+	m.setActionLog(fmt.Sprintf("Updated #%d: %s → %s", msg.workItemID, msg.oldState, msg.newState))
+	return m, nil
+}
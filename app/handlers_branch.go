@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startWorkItemBranch checks out the git branch for the item and moves it to "In Progress"
+func (m model) startWorkItemBranch(item *WorkItem) (model, tea.Cmd) {
+	if item == nil {
+		return m, nil
+	}
+
+	template := ""
+	if m.config != nil {
+		template = m.config.BranchTemplate
+	}
+	branch := branchNameForWorkItem(template, *item, gitUserSlug(""))
+
+	m.loading = true
+	m.statusMessage = fmt.Sprintf("Checking out %s...", branch)
+	return m, tea.Batch(checkoutWorkItemBranch(m.client, "", *item, branch), m.spinner.Tick)
+}
+
+// setLoadedTaskState updates the state of an item in every loaded list and in the detail view
+func (m *model) setLoadedTaskState(workItemID int, state string) {
+	for _, list := range m.sprintLists {
+		if list != nil {
+			list.setTaskState(workItemID, state)
+		}
+	}
+	for _, list := range m.backlogLists {
+		if list != nil {
+			list.setTaskState(workItemID, state)
+		}
+	}
+	if m.selectedTask != nil && m.selectedTask.ID == workItemID {
+		m.selectedTask.State = state
+	}
+}
+
+// handleBranchCheckedOutMsg handles the branchCheckedOutMsg response
+func (m model) handleBranchCheckedOutMsg(msg branchCheckedOutMsg) (model, tea.Cmd) {
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error checking out branch: %v", msg.err))
+		return m, nil
+	}
+
+	action := "Checked out"
+	if msg.created {
+		action = "Created"
+	}
+	switch {
+	case msg.stateErr != nil:
+		m.setActionLog(fmt.Sprintf("%s %s (state not changed: %v)", action, msg.branch, msg.stateErr))
+	case msg.newState != "":
+		m.setLoadedTaskState(msg.workItemID, msg.newState)
+		m.setActionLog(fmt.Sprintf("%s %s, #%d → %s", action, msg.branch, msg.workItemID, msg.newState))
+	default:
+		m.setActionLog(fmt.Sprintf("%s %s", action, msg.branch))
+	}
+	return m, nil
+}
+
+// selectBranchWorkItem moves the cursor to the work item of the git branch Hippo was started on.
+// It runs once, after the initial sprint loads finish.
+func (m *model) selectBranchWorkItem() {
+	if m.branchWorkItemID == 0 {
+		return
+	}
+	workItemID := m.branchWorkItemID
+	m.branchWorkItemID = 0
+
+	for _, tab := range []sprintTab{currentSprint, previousSprint, nextSprint} {
+		list := m.sprintLists[tab]
+		if list == nil {
+			continue
+		}
+		found := false
+		for _, task := range list.tasks {
+			if task.ID == workItemID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		m.currentMode = sprintMode
+		m.currentTab = tab
+		for i, item := range m.getVisibleTreeItems() {
+			if item.WorkItem.ID == workItemID {
+				m.ui.cursor = i
+				m.ui.scrollOffset = 0
+				m.adjustScrollOffset()
+				list.cursor = m.ui.cursor
+				list.scrollOffset = m.ui.scrollOffset
+				m.setActionLog(fmt.Sprintf("Selected #%d from the current git branch", workItemID))
+				return
+			}
+		}
+	}
+}
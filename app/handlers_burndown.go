@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openBurndown switches to the burndown view for the sprint of the current tab and loads its history
+func (m model) openBurndown() (model, tea.Cmd) {
+	if m.currentMode != sprintMode || m.client == nil {
+		return m, nil
+	}
+
+	sprint := m.sprints[m.currentTab]
+	if sprint == nil {
+		m.setActionLog("No sprint to chart")
+		return m, nil
+	}
+
+	m.burndown.sprint = sprint
+	m.burndown.history = nil
+	m.state = burndownView
+	m.loading = true
+	m.statusMessage = "Loading sprint history..."
+	return m, tea.Batch(loadSprintHistory(m.viewLoads.context(), m.client, sprint.Path), m.spinner.Tick)
+}
+
+// handleBurndownView handles keyboard input in the burndown view
+func (m model) handleBurndownView(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(burndownKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = listView
+		m.statusMessage = ""
+		m.closeViewLoads()
+		return m, nil
+	case actionNextMetric:
+		// Cycle through the plotted metric
+		m.burndown.metric = (m.burndown.metric + 1) % burndownMetricCount
+		return m, nil
+	case actionRefresh:
+		// Reload history for the same sprint
+		if m.client != nil && m.burndown.sprint != nil {
+			m.loading = true
+			m.statusMessage = "Loading sprint history..."
+			return m, tea.Batch(loadSprintHistory(m.viewLoads.context(), m.client, m.burndown.sprint.Path), m.spinner.Tick)
+		}
+	}
+	return m, nil
+}
+
+// handleSprintHistoryLoadedMsg handles the sprintHistoryLoadedMsg response
+func (m model) handleSprintHistoryLoadedMsg(msg sprintHistoryLoadedMsg) (model, tea.Cmd) {
+	// Ignore history for a sprint the user has already moved away from
+	if m.burndown.sprint == nil || m.burndown.sprint.Path != msg.sprintPath || isCancelled(msg.err) {
+		return m, nil
+	}
+
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading sprint history: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error loading sprint history: %v", msg.err))
+		return m, nil
+	}
+
+	m.burndown.history = msg.history
+	m.setActionLog(fmt.Sprintf("Loaded history for %d items in %s", len(msg.history), m.burndown.sprint.Name))
+	return m, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// partialIDs returns the IDs of items that only have their list fields
+func partialIDs(items []WorkItem) []int {
+	var ids []int
+	for _, item := range items {
+		if item.Partial {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// loadSelectedDetails fetches the rest of the selected item when it only has its list fields
+func (m model) loadSelectedDetails() tea.Cmd {
+	if m.client == nil || m.selectedTask == nil || !m.selectedTask.Partial {
+		return nil
+	}
+	return loadWorkItemDetails(context.Background(), m.client, []int{m.selectedTask.ID}, detailsForSelected)
+}
+
+// loadFilterDetails fetches the descriptions and comments of the current list when the
+// filter query searches them
+func (m *model) loadFilterDetails() tea.Cmd {
+	list := m.getCurrentList()
+	if m.client == nil || list == nil || m.filter.loadingDetail {
+		return nil
+	}
+	query, err := parseFilterQuery(strings.TrimSpace(m.filter.filterInput.Value()))
+	if err != nil || !query.needsDetails() {
+		// The query no longer searches the details being fetched
+		m.cancelFilterDetails()
+		return nil
+	}
+	ids := partialIDs(list.tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+	m.filter.loadingDetail = true
+	return loadWorkItemDetails(m.filter.detailLoads.context(), m.client, ids, detailsForFilter)
+}
+
+// cancelFilterDetails stops fetching details for a filter query that no longer needs them
+func (m *model) cancelFilterDetails() {
+	m.filter.detailLoads.supersede()
+	m.filter.loadingDetail = false
+}
+
+// loadExportDetails fetches the descriptions of the items about to be exported
+func (m *model) loadExportDetails() tea.Cmd {
+	var items []WorkItem
+	for _, treeItem := range m.getVisibleTreeItems() {
+		items = append(items, *treeItem.WorkItem)
+	}
+	ids := partialIDs(items)
+	if m.client == nil || len(ids) == 0 {
+		return nil
+	}
+	m.export.loading = true
+	return loadWorkItemDetails(m.viewLoads.context(), m.client, ids, detailsForExport)
+}
+
+// handleWorkItemDetailsLoadedMsg fills in the complete work items wherever they are shown
+func (m model) handleWorkItemDetailsLoadedMsg(msg workItemDetailsLoadedMsg) (model, tea.Cmd) {
+	if isCancelled(msg.err) {
+		// A filter query that no longer needs the details or a closed export; its flag was reset on cancel
+		return m, nil
+	}
+	switch msg.purpose {
+	case detailsForFilter:
+		m.filter.loadingDetail = false
+	case detailsForExport:
+		m.export.loading = false
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading details: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error loading details: %v", msg.err))
+		return m, nil
+	}
+
+	details := make(map[int]WorkItem, len(msg.workItems))
+	for _, item := range msg.workItems {
+		details[item.ID] = item
+	}
+	for _, list := range m.sprintLists {
+		list.mergeDetails(details)
+	}
+	for _, list := range m.backlogLists {
+		list.mergeDetails(details)
+	}
+
+	if m.selectedTask != nil {
+		if detail, ok := details[m.selectedTask.ID]; ok {
+			detail.Children = m.selectedTask.Children
+			m.selectedTask = &detail
+		}
+	}
+
+	// Rerun a filter that was waiting for descriptions or comments
+	if m.state == filterView || m.filter.active {
+		m.filterSearch()
+	}
+	return m, nil
+}
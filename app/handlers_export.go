@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openExport shows the export menu for the visible tree
+func (m model) openExport() (model, tea.Cmd) {
+	if len(m.getVisibleTreeItems()) == 0 {
+		m.setActionLog("Nothing to export")
+		return m, nil
+	}
+
+	pathInput := textinput.New()
+	pathInput.CharLimit = 1024
+	pathInput.Width = 60
+
+	m.export = ExportState{pathInput: pathInput}
+	m.state = exportView
+	m.statusMessage = ""
+	// List rows lack descriptions, which CSV and JSON exports include
+	return m, m.loadExportDetails()
+}
+
+// exportWaiting reports whether exported items are still waiting for their descriptions
+func (m *model) exportWaiting() bool {
+	if !m.export.loading {
+		return false
+	}
+	m.statusMessage = "Loading descriptions..."
+	return true
+}
+
+// exportTitle names the exported list: the sprint or backlog tab being shown
+func (m model) exportTitle() string {
+	if m.currentMode == backlogMode {
+		if m.currentBacklogTab == abandonedWork {
+			return "Abandoned Work"
+		}
+		return "Recent Backlog"
+	}
+	if sprint := m.sprints[m.currentTab]; sprint != nil {
+		return sprint.Name
+	}
+	return "Work Items"
+}
+
+// renderExportContent renders the visible tree in the selected format
+func (m model) renderExportContent() (string, error) {
+	var columns []string
+	if m.config != nil {
+		columns = m.config.ExportColumns
+	}
+	format := exportFormats[m.export.formatCursor]
+	return exportTree(format, m.exportTitle(), m.getVisibleTreeItems(), columns, m.getStateCategory)
+}
+
+// handleExportView handles keyboard input in the export menu
+func (m model) handleExportView(msg tea.KeyMsg) (model, tea.Cmd) {
+	if m.export.pathMode {
+		return m.handleExportPathInput(msg)
+	}
+
+	switch m.viewAction(exportKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = listView
+		m.statusMessage = ""
+		m.closeViewLoads()
+		m.export.loading = false
+		return m, nil
+	case actionUp:
+		if m.export.formatCursor > 0 {
+			m.export.formatCursor--
+		}
+	case actionDown:
+		if m.export.formatCursor < len(exportFormats)-1 {
+			m.export.formatCursor++
+		}
+	case actionSaveFile:
+		if m.exportWaiting() {
+			return m, nil
+		}
+		// Save to a file, named after the list
+		format := exportFormats[m.export.formatCursor]
+		m.export.pathMode = true
+		m.export.completions = nil
+		m.export.pathInput.SetValue(slugify(m.exportTitle(), maxBranchTitleLength) + format.extension())
+		m.export.pathInput.CursorEnd()
+		m.statusMessage = ""
+		return m, m.export.pathInput.Focus()
+	case actionCopy:
+		if m.exportWaiting() {
+			return m, nil
+		}
+		content, err := m.renderExportContent()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		return m, copyExportToClipboard(content, len(m.getVisibleTreeItems()))
+	}
+	return m, nil
+}
+
+// handleExportPathInput handles the file path prompt of the export menu
+func (m model) handleExportPathInput(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(exportPathKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.export.pathMode = false
+		m.export.completions = nil
+		m.export.pathInput.Blur()
+		return m, nil
+	case actionCompletePath:
+		completed, candidates := completePath(m.export.pathInput.Value())
+		m.export.pathInput.SetValue(completed)
+		m.export.pathInput.CursorEnd()
+		m.export.completions = nil
+		if len(candidates) > 1 {
+			m.export.completions = candidates
+		}
+		return m, nil
+	case actionConfirm:
+		path := expandHome(strings.TrimSpace(m.export.pathInput.Value()))
+		if path == "" {
+			m.statusMessage = "No file selected"
+			return m, nil
+		}
+		if m.exportWaiting() {
+			return m, nil
+		}
+		content, err := m.renderExportContent()
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.export.pathMode = false
+		m.export.pathInput.Blur()
+		return m, writeExportFile(path, content, len(m.getVisibleTreeItems()))
+	}
+
+	var cmd tea.Cmd
+	m.export.pathInput, cmd = m.export.pathInput.Update(msg)
+	m.export.completions = nil
+	return m, cmd
+}
+
+// handleExportDoneMsg handles the exportDoneMsg response
+func (m model) handleExportDoneMsg(msg exportDoneMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error exporting to %s: %v", msg.destination, msg.err)
+		m.setActionLog(fmt.Sprintf("Error exporting: %v", msg.err))
+		return m, nil
+	}
+
+	m.statusMessage = ""
+	m.state = listView
+	m.setActionLog(fmt.Sprintf("Exported %d items to %s", msg.count, msg.destination))
+	return m, nil
+}
@@ -10,25 +10,57 @@ import (
 // GLOBAL HOTKEYS
 // =============================================================================
 
+// isQuitKey reports whether the key quits: ctrl+c always does, whatever the keymap says,
+// and so do the keys bound to quit
+func (m model) isQuitKey(msg tea.KeyMsg) bool {
+	return msg.String() == "ctrl+c" || m.keys.action(globalScope, msg.String()) == actionQuit
+}
+
+// viewAction returns the action of the key in a view with fixed keys. In the views quit works
+// in, ctrl+c and the keymap's quit key come before the view's own keys.
+func (m model) viewAction(view keyView, msg tea.KeyMsg) keyAction {
+	keys := viewKeyMaps[view]
+	if keys.quits && m.isQuitKey(msg) {
+		return actionQuit
+	}
+	return keys.action(msg.String())
+}
+
 // handleGlobalHotkeys handles global keyboard shortcuts that work across views
 func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	// ctrl+c always quits, whatever the keymap says
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit, true
+	}
+
+	action := m.keys.action(globalScope, msg.String())
+	switch action {
+	case actionQuit:
 		return m, tea.Quit, true
 
-	case "1":
+	case actionSprintMode:
 		// Switch to Sprint Mode
 		if m.state == listView && m.currentMode != sprintMode {
+			m.supersedeLoads()
 			m.currentMode = sprintMode
 			m.ui.cursor = 0
 			m.ui.scrollOffset = 0
+			// Load sprint data if not attempted yet, such as a load cancelled by the switch to backlog
+			currentList := m.getCurrentList()
+			sprint := m.sprints[m.currentTab]
+			if currentList != nil && !currentList.attempted && sprint != nil && m.client != nil {
+				m.loading = true
+				tab := m.currentTab
+				return m, tea.Batch(loadTasksForSprint(m.listLoads.context(), m.client, sprint.Path, m.pageSize(), &tab), m.spinner.Tick), true
+			}
 			m.setActionLog("Switched to Sprint Mode")
 		}
 		return m, nil, true
 
-	case "2":
+	case actionBacklogMode:
 		// Switch to Backlog Mode
 		if m.state == listView && m.currentMode != backlogMode {
+			m.supersedeLoads()
 			m.currentMode = backlogMode
 			m.ui.cursor = 0
 			m.ui.scrollOffset = 0
@@ -37,13 +69,13 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			if currentList != nil && !currentList.attempted && m.client != nil {
 				m.loading = true
 				tab := m.currentBacklogTab
-				return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick), true
+				return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick), true
 			}
 			m.setActionLog("Switched to Backlog Mode")
 		}
 		return m, nil, true
 
-	case "?":
+	case actionHelp:
 		// Show help modal
 		if m.state == helpView {
 			m.state = listView
@@ -54,7 +86,7 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		}
 		return m, nil, true
 
-	case "r":
+	case actionRefresh:
 		// Refresh
 		if m.client != nil {
 			// If we're in detail view, just refresh the selected item
@@ -65,12 +97,14 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				return m, tea.Batch(refreshWorkItem(m.client, m.selectedTask.ID), m.spinner.Tick), true
 			}
 			// Otherwise, refresh everything based on current mode
+			m.supersedeLoads()
 			m.loading = true
 			m.statusMessage = "Refreshing..."
 			m.setActionLog("Refreshing data...")
 			m.filter.active = false
 			m.filter.filterInput.SetValue("")
 			m.filter.filteredTasks = nil
+			m.filter.contextIDs = nil
 
 			if m.currentMode == sprintMode {
 				// Clear sprint data
@@ -81,12 +115,12 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 				m.backlogLists = make(map[backlogTab]*WorkItemList)
 				// Reload current backlog tab
 				tab := m.currentBacklogTab
-				return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick), true
+				return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick), true
 			}
 		}
 		return m, nil, true
 
-	case "o":
+	case actionOpenInBrowser:
 		// Open in browser
 		var workItemID int
 		if m.state == detailView && m.selectedTask != nil {
@@ -98,25 +132,47 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			}
 		}
 		if workItemID > 0 {
-			openInBrowser(m.config.OrganizationURL, m.config.Project, workItemID)
+			openInBrowser(itemWebURL(m.config, workItemID))
 			m.setActionLog(fmt.Sprintf("Opened #%d in browser", workItemID))
 		}
 		return m, nil, true
 
-	case "s":
+	case actionYank:
+		// Copy the item (or the batch selection) as an ID, URL or link
+		if m.state == listView || m.state == detailView {
+			newModel, cmd := m.openYank()
+			return newModel, cmd, true
+		}
+		return m, nil, true
+
+	case actionCheckoutBranch:
+		// Check out a git branch for the item and start working on it
+		var item *WorkItem
+		if m.state == detailView {
+			item = m.selectedTask
+		} else if m.state == listView {
+			treeItems := m.getVisibleTreeItems()
+			if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
+				item = treeItems[m.ui.cursor].WorkItem
+			}
+		}
+		newModel, cmd := m.startWorkItemBranch(item)
+		return newModel, cmd, true
+
+	case actionChangeState:
 		// Change state - only in detail view for single items
 		if m.state == detailView && m.selectedTask != nil && m.client != nil {
 			// Single item state change from detail view
 			m.loading = true
 			m.statusMessage = "Loading states..."
 			return m, tea.Batch(
-				loadWorkItemStates(m.client, m.selectedTask.WorkItemType),
+				loadWorkItemStates(m.viewLoads.context(), m.client, []WorkItem{*m.selectedTask}),
 				m.spinner.Tick,
 			), true
 		}
 		return m, nil, true
 
-	case "e":
+	case actionEdit:
 		// Edit - unified flow for both single and batch edit
 		if m.state == listView {
 			treeItems := m.getVisibleTreeItems()
@@ -124,8 +180,8 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			// If no items are selected, select the current item
 			if len(m.batch.selectedItems) == 0 {
 				if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
-					itemID := treeItems[m.ui.cursor].WorkItem.ID
-					m.batch.selectedItems[itemID] = true
+					item := treeItems[m.ui.cursor].WorkItem
+					m.batch.selectedItems[item.ID] = *item
 				}
 			}
 
@@ -136,13 +192,13 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			}
 		} else if m.state == detailView && m.selectedTask != nil {
 			// In detail view, add current item to batch selection and show menu
-			m.batch.selectedItems[m.selectedTask.ID] = true
+			m.batch.selectedItems[m.selectedTask.ID] = *m.selectedTask
 			m.stateCursor = 0
 			m.state = batchEditMenuView
 		}
 		return m, nil, true
 
-	case "/":
+	case actionFilter:
 		// Filter within existing results
 		if m.state == listView {
 			m.state = filterView
@@ -153,7 +209,7 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		}
 		return m, nil, true
 
-	case "f":
+	case actionFind:
 		// Find with dedicated query
 		if m.state == listView {
 			m.state = findView
@@ -161,8 +217,8 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		}
 		return m, nil, true
 
-	case "i", "a":
-		// Insert (i) or append (a) new work item - only in list view
+	case actionInsert, actionAppend:
+		// Insert before or append after the current item - only in list view
 		if m.state != listView {
 			return m, nil, true
 		}
@@ -180,7 +236,7 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 			return m, nil, true
 		} else {
 			item := treeItems[m.ui.cursor]
-			m.create.after = (msg.String() == "a")
+			m.create.after = (action == actionAppend)
 
 			if m.create.after {
 				// Append after - check if current item has children or can have children
@@ -226,7 +282,7 @@ func (m model) handleGlobalHotkeys(msg tea.KeyMsg) (model, tea.Cmd, bool) {
 		m.state = createView
 		return m, nil, true
 
-	case "d":
+	case actionDelete:
 		// Delete work item(s) - only in list view
 		if m.state != listView {
 			return m, nil, true
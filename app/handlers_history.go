@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleHistoryView handles keyboard input in the history pane
+func (m model) handleHistoryView(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(historyKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = detailView
+		m.closeViewLoads()
+		m.statusMessage = ""
+		return m, nil
+	}
+
+	// Scrolling is handled by the viewport
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// handleHistoryLoadedMsg handles the historyLoadedMsg response
+func (m model) handleHistoryLoadedMsg(msg historyLoadedMsg) (model, tea.Cmd) {
+	// Ignore history for an item the user already navigated away from
+	if msg.workItemID != m.history.workItemID || isCancelled(msg.err) {
+		return m, nil
+	}
+
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading history: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error loading history: %v", msg.err))
+		return m, nil
+	}
+
+	m.history.updates = msg.updates
+	m = m.prepareHistoryViewport()
+	return m, nil
+}
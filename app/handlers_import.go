@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openImport prompts for an outline or CSV file to import
+func (m model) openImport() (model, tea.Cmd) {
+	pathInput := textinput.New()
+	pathInput.Placeholder = "plan.md or items.csv"
+	pathInput.CharLimit = 1024
+	pathInput.Width = 60
+
+	m.imports = ImportState{pathInput: pathInput}
+	if m.currentMode == sprintMode {
+		if sprint := m.sprints[m.currentTab]; sprint != nil {
+			m.imports.iterationPath = sprint.Path
+		}
+	}
+	m.state = importView
+	m.statusMessage = ""
+	return m, m.imports.pathInput.Focus()
+}
+
+// handleImportView handles the file path prompt of the import
+func (m model) handleImportView(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(importKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.imports.pathInput.Blur()
+		m.state = listView
+		m.statusMessage = ""
+		return m, nil
+	case actionCompletePath:
+		completed, candidates := completePath(m.imports.pathInput.Value())
+		m.imports.pathInput.SetValue(completed)
+		m.imports.pathInput.CursorEnd()
+		m.imports.completions = nil
+		if len(candidates) > 1 {
+			m.imports.completions = candidates
+		}
+		return m, nil
+	case actionConfirm:
+		path := expandHome(strings.TrimSpace(m.imports.pathInput.Value()))
+		if path == "" {
+			m.statusMessage = "No file selected"
+			return m, nil
+		}
+		m.statusMessage = ""
+		return m, parseImport(path)
+	}
+
+	var cmd tea.Cmd
+	m.imports.pathInput, cmd = m.imports.pathInput.Update(msg)
+	m.imports.completions = nil
+	return m, cmd
+}
+
+// handleImportParsedMsg shows the dry-run preview, or the parse error in the prompt
+func (m model) handleImportParsedMsg(msg importParsedMsg) (model, tea.Cmd) {
+	if m.state != importView {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+
+	m.imports.path = msg.path
+	m.imports.items = msg.items
+	m.imports.pathInput.Blur()
+	m.state = importConfirmView
+	return m, nil
+}
+
+// handleImportConfirmView handles the dry-run preview of an import
+func (m model) handleImportConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
+	action := m.viewAction(importConfirmKeys, msg)
+	if m.loading {
+		// esc stops the import after the item being created
+		if action == actionBack {
+			m.cancelWork()
+		}
+		return m, nil
+	}
+
+	switch action {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		// Back to the prompt to pick another file
+		m.state = importView
+		m.statusMessage = ""
+		return m, m.imports.pathInput.Focus()
+	case actionConfirm:
+		if m.client == nil {
+			return m, nil
+		}
+		// Created items land in the same area as the list they're imported into
+		var areaPath string
+		if currentTasks := m.getCurrentTasks(); len(currentTasks) > 0 {
+			areaPath = currentTasks[0].AreaPath
+		}
+		m.loading = true
+		m.statusMessage = fmt.Sprintf("Creating %d work items...", len(m.imports.items))
+		return m, tea.Batch(
+			runImport(m.work.context(), m.client, m.imports.items, m.imports.iterationPath, areaPath),
+			m.spinner.Tick,
+		)
+	}
+	return m, nil
+}
+
+// handleImportDoneMsg reports the import and refreshes the list
+func (m model) handleImportDoneMsg(msg importDoneMsg) (model, tea.Cmd) {
+	if isCancelled(msg.err) {
+		m.setActionLog(fmt.Sprintf("Import cancelled after %d of %d items", len(msg.created), msg.total))
+	} else if msg.err != nil {
+		m.setActionLog(fmt.Sprintf("Imported %d of %d items, then failed: %v", len(msg.created), msg.total, msg.err))
+	} else {
+		m.setActionLog(fmt.Sprintf("Imported %d items from %s", len(msg.created), m.imports.path))
+	}
+	m.imports.items = nil
+	m.state = listView
+
+	if m.client == nil || len(msg.created) == 0 {
+		m.loading = false
+		m.statusMessage = ""
+		return m, nil
+	}
+
+	m.statusMessage = "Refreshing list..."
+	if m.currentMode == sprintMode {
+		m.sprintLists = make(map[sprintTab]*WorkItemList)
+		return m, tea.Batch(loadSprintsWithReload(m.client, true), m.spinner.Tick)
+	}
+	m.backlogLists = make(map[backlogTab]*WorkItemList)
+	return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, m.currentBacklogTab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
+}
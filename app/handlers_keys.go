@@ -15,21 +15,28 @@ import (
 func (m model) handleHelpView(msg tea.KeyMsg) (model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	switch msg.String() {
-	case "esc", "?", "q":
+	// The help and quit keys close help, whatever they're bound to
+	switch m.keys.action(globalScope, msg.String()) {
+	case actionHelp, actionQuit:
 		m.state = listView
 		return m, nil
-	case "up", "k":
+	}
+
+	switch m.viewAction(helpKeys, msg) {
+	case actionBack:
+		m.state = listView
+		return m, nil
+	case actionUp:
 		m.viewport.LineUp(1)
-	case "down", "j":
+	case actionDown:
 		m.viewport.LineDown(1)
-	case "ctrl+u", "pgup":
+	case actionPageUp:
 		m.viewport.HalfViewUp()
-	case "ctrl+d", "pgdown":
+	case actionPageDown:
 		m.viewport.HalfViewDown()
-	case "g":
+	case actionTop:
 		m.viewport.GotoTop()
-	case "G":
+	case actionBottom:
 		m.viewport.GotoBottom()
 	}
 
@@ -38,8 +45,8 @@ func (m model) handleHelpView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleErrorView handles keyboard input in the error view
 func (m model) handleErrorView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(errorKeys, msg) {
+	case actionBack:
 		m.state = listView
 		m.statusMessage = ""
 		return m, nil
@@ -49,20 +56,23 @@ func (m model) handleErrorView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleFilterView handles keyboard input in the filter view
 func (m model) handleFilterView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(filterKeys, msg) {
+	case actionBack:
 		m.state = listView
 		m.filter.active = false
 		m.filter.filterInput.SetValue("")
+		m.cancelFilterDetails()
 		// Clear filter in current list
 		if list := m.getCurrentList(); list != nil {
 			list.filterActive = false
 			list.filteredTasks = nil
 		}
 		m.filter.filteredTasks = nil
+		m.filter.contextIDs = nil
+		m.filter.err = nil
 		m.ui.cursor = 0
 		return m, nil
-	case "enter":
+	case actionConfirm:
 		// If there are results, open the selected item in detail view
 		if len(m.filter.filteredTasks) > 0 && m.ui.cursor < len(m.filter.filteredTasks) {
 			// Get the filtered tasks as tree items to respect the cursor position
@@ -74,6 +84,7 @@ func (m model) handleFilterView(msg tea.KeyMsg) (model, tea.Cmd) {
 					m.selectedTaskID = m.selectedTask.ID
 					m.state = detailView
 					m.filter.active = true
+					return m, m.loadSelectedDetails()
 				}
 			}
 		} else {
@@ -83,14 +94,14 @@ func (m model) handleFilterView(msg tea.KeyMsg) (model, tea.Cmd) {
 			m.ui.cursor = 0
 		}
 		return m, nil
-	case "up", "ctrl+k", "ctrl+p":
+	case actionUp:
 		// Navigate up in filtered results
 		if m.ui.cursor > 0 {
 			m.ui.cursor--
 			m.adjustScrollOffset()
 		}
 		return m, nil
-	case "down", "ctrl+j", "ctrl+n":
+	case actionDown:
 		// Navigate down in filtered results
 		treeItems := m.getVisibleTreeItems()
 		if m.ui.cursor < len(treeItems)-1 {
@@ -98,12 +109,12 @@ func (m model) handleFilterView(msg tea.KeyMsg) (model, tea.Cmd) {
 			m.adjustScrollOffset()
 		}
 		return m, nil
-	case "ctrl+u", "pgup":
+	case actionPageUp:
 		// Jump up half page
 		m.ui.cursor = max(0, m.ui.cursor-10)
 		m.adjustScrollOffset()
 		return m, nil
-	case "ctrl+d", "pgdown":
+	case actionPageDown:
 		// Jump down half page
 		treeItems := m.getVisibleTreeItems()
 		m.ui.cursor = min(len(treeItems)-1, m.ui.cursor+10)
@@ -115,25 +126,26 @@ func (m model) handleFilterView(msg tea.KeyMsg) (model, tea.Cmd) {
 		m.filterSearch()
 		// Reset cursor when filter changes
 		m.ui.cursor = 0
-		return m, cmd
+		return m, tea.Batch(cmd, m.loadFilterDetails())
 	}
 }
 
 // handleFindView handles keyboard input in the find view
 func (m model) handleFindView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(findKeys, msg) {
+	case actionBack:
 		m.state = listView
 		m.filter.findInput.SetValue("")
 		return m, nil
-	case "enter":
+	case actionConfirm:
 		// For now, just go back to list view
 		// Could implement custom queries here
 		m.state = listView
 		m.filter.findInput.SetValue("")
 		if m.client != nil {
+			m.supersedeLoads()
 			m.loading = true
-			return m, tea.Batch(loadTasks(m.client), loadSprints(m.client), m.spinner.Tick)
+			return m, tea.Batch(loadTasks(m.listLoads.context(), m.client, m.pageSize()), loadSprints(m.client), m.spinner.Tick)
 		}
 		return m, nil
 	default:
@@ -145,76 +157,50 @@ func (m model) handleFindView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleStatePickerView handles keyboard input in the state picker view
 func (m model) handleStatePickerView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(statePickerKeys, msg) {
+	case actionBack:
 		// Clear batch selection and return to list view
-		m.batch.selectedItems = make(map[int]bool)
+		m.batch.selectedItems = make(map[int]WorkItem)
 		m.state = listView
 		m.stateCursor = 0
+		m.closeViewLoads()
 		return m, nil
-	case "up", "k":
+	case actionUp:
 		if m.stateCursor > 0 {
 			m.stateCursor--
 		}
-	case "down", "j":
+	case actionDown:
 		if m.stateCursor < len(m.availableStates)-1 {
 			m.stateCursor++
 		}
-	case "ctrl+u", "pgup":
+	case actionPageUp:
 		// Jump up half page
 		m.stateCursor = max(0, m.stateCursor-10)
-	case "ctrl+d", "pgdown":
+	case actionPageDown:
 		// Jump down half page
 		m.stateCursor = min(len(m.availableStates)-1, m.stateCursor+10)
-	case "enter":
-		newState := m.availableStates[m.stateCursor]
-
-		// Check if batch operation or single item
-		if len(m.batch.selectedItems) > 0 && m.client != nil {
-			// Batch state update
-			m.loading = true
-			count := len(m.batch.selectedItems)
-			m.batch.operationCount = count // Track batch operations
-			m.statusMessage = fmt.Sprintf("Updating %d items to %s...", count, newState)
-			m.state = listView
-
-			var updateCmds []tea.Cmd
-			for itemID := range m.batch.selectedItems {
-				updateCmds = append(updateCmds, updateWorkItemState(m.client, itemID, newState))
-			}
-
-			// Clear selection after starting update
-			m.batch.selectedItems = make(map[int]bool)
-			updateCmds = append(updateCmds, m.spinner.Tick)
-			return m, tea.Batch(updateCmds...)
-		} else if m.selectedTask != nil && m.client != nil {
-			// Single item state update
-			m.loading = true
-			m.batch.operationCount = 1 // Single operation
-			m.statusMessage = fmt.Sprintf("Updating state to %s...", newState)
-			return m, tea.Batch(
-				updateWorkItemState(m.client, m.selectedTask.ID, newState),
-				m.spinner.Tick,
-			)
+	case actionConfirm:
+		if m.stateCursor >= len(m.availableStates) {
+			return m, nil
 		}
-		return m, nil
+		return m.startStateChange(m.availableStates[m.stateCursor])
 	}
 	return m, nil
 }
 
 // handleEditView handles keyboard input in the edit view
 func (m model) handleEditView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(editKeys, msg) {
+	case actionBack:
 		// Cancel edit and return to detail view
 		m.state = detailView
 		return m, nil
-	case "tab":
+	case actionNextField:
 		// Move to next field
 		m.edit.fieldCursor = (m.edit.fieldCursor + 1) % m.edit.fieldCount
 		m.focusEditField()
 		return m, nil
-	case "shift+tab":
+	case actionPrevField:
 		// Move to previous field
 		m.edit.fieldCursor--
 		if m.edit.fieldCursor < 0 {
@@ -222,7 +208,7 @@ func (m model) handleEditView(msg tea.KeyMsg) (model, tea.Cmd) {
 		}
 		m.focusEditField()
 		return m, nil
-	case "ctrl+s":
+	case actionSave:
 		// Save changes
 		if m.selectedTask != nil && m.client != nil {
 			updates := make(map[string]interface{})
@@ -265,17 +251,17 @@ func (m model) handleEditView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleCreateView handles keyboard input in the create view
 func (m model) handleCreateView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(createKeys, msg) {
+	case actionBack:
 		// Cancel creation and return to list view
 		m.state = listView
 		m.create.input.SetValue("")
 		return m, nil
-	case "enter":
+	case actionConfirm:
 		// Show help hint instead of submitting
 		m.statusMessage = "Use ctrl+s to save, esc to cancel"
 		return m, nil
-	case "ctrl+s":
+	case actionSave:
 		// Save new work item
 		title := strings.TrimSpace(m.create.input.Value())
 		if title == "" {
@@ -314,7 +300,7 @@ func (m model) handleCreateView(msg tea.KeyMsg) (model, tea.Cmd) {
 			m.loading = true
 			m.statusMessage = "Creating work item..."
 			return m, tea.Batch(
-				createWorkItem(m.client, title, "Task", iterationPath, m.create.parentID, areaPath),
+				createWorkItem(m.work.context(), m.client, title, "Task", iterationPath, m.create.parentID, areaPath),
 				m.spinner.Tick,
 			)
 		}
@@ -329,8 +315,8 @@ func (m model) handleCreateView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleDeleteConfirmView handles keyboard input in the delete confirmation view
 func (m model) handleDeleteConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.viewAction(deleteConfirmKeys, msg) {
+	case actionYes:
 		// Confirm delete
 		if m.client != nil {
 			m.loading = true
@@ -338,20 +324,7 @@ func (m model) handleDeleteConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 			// Check if batch delete or single delete
 			if len(m.batch.selectedItems) > 0 {
-				// Batch delete
-				var deleteCmds []tea.Cmd
-				count := len(m.batch.selectedItems)
-				m.batch.operationCount = count // Track batch operations
-				m.statusMessage = fmt.Sprintf("Deleting %d work items...", count)
-
-				for itemID := range m.batch.selectedItems {
-					deleteCmds = append(deleteCmds, deleteWorkItem(m.client, itemID))
-				}
-
-				// Clear selection after starting delete
-				m.batch.selectedItems = make(map[int]bool)
-				deleteCmds = append(deleteCmds, m.spinner.Tick)
-				return m, tea.Batch(deleteCmds...)
+				return m.startBatch(deleteBatchJob(), m.selectedIDs())
 			} else {
 				// Single delete
 				m.batch.operationCount = 1 // Single operation
@@ -361,7 +334,7 @@ func (m model) handleDeleteConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
 		}
 		m.state = listView
 		return m, nil
-	case "n", "N", "esc":
+	case actionNo:
 		// Cancel delete
 		m.state = listView
 		return m, nil
@@ -371,22 +344,16 @@ func (m model) handleDeleteConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleListViewNav handles navigation in the list view
 func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "right", "l":
-		// Drill down to detail view
-		treeItems := m.getVisibleTreeItems()
-		if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
-			m.selectedTask = treeItems[m.ui.cursor].WorkItem
-			m.selectedTaskID = m.selectedTask.ID
-			m.state = detailView
-		}
-	case "tab":
+	switch m.keys.action(listScope, msg.String()) {
+	case actionNextTab:
 		// Cycle through tabs based on current mode
 		// Clear selections when switching tabs
-		m.batch.selectedItems = make(map[int]bool)
+		m.batch.selectedItems = make(map[int]WorkItem)
+		// Loads for the tab being left are no longer wanted
+		m.supersedeLoads()
 
 		if m.currentMode == sprintMode {
-			m.currentTab = (m.currentTab + 1) % 3
+			m.currentTab = (m.currentTab + 1) % m.sprintTabCount()
 			// Restore cursor/scroll from the new tab's list
 			if list := m.getCurrentList(); list != nil {
 				m.ui.cursor = list.cursor
@@ -401,7 +368,7 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 			if currentList != nil && !currentList.attempted && sprint != nil && m.client != nil {
 				m.loading = true
 				tab := m.currentTab
-				return m, tea.Batch(loadTasksForSprint(m.client, nil, sprint.Path, defaultLoadLimit, &tab), m.spinner.Tick)
+				return m, tea.Batch(loadTasksForSprint(m.listLoads.context(), m.client, sprint.Path, m.pageSize(), &tab), m.spinner.Tick)
 			}
 		} else if m.currentMode == backlogMode {
 			m.currentBacklogTab = (m.currentBacklogTab + 1) % 2
@@ -418,10 +385,10 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 			if currentList != nil && !currentList.attempted && m.client != nil {
 				m.loading = true
 				tab := m.currentBacklogTab
-				return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick)
+				return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
 			}
 		}
-	case "up", "k":
+	case actionUp:
 		if m.ui.cursor > 0 {
 			m.ui.cursor--
 			m.adjustScrollOffset()
@@ -431,7 +398,7 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 				list.scrollOffset = m.ui.scrollOffset
 			}
 		}
-	case "down", "j":
+	case actionDown:
 		treeItems := m.getVisibleTreeItems()
 		maxCursor := len(treeItems) - 1
 		// If there are more items to load, allow cursor to go to the "Load More" item
@@ -447,7 +414,7 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 				list.scrollOffset = m.ui.scrollOffset
 			}
 		}
-	case "ctrl+u", "pgup":
+	case actionPageUp:
 		// Jump up half page
 		m.ui.cursor = max(0, m.ui.cursor-10)
 		m.adjustScrollOffset()
@@ -456,7 +423,7 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 			list.cursor = m.ui.cursor
 			list.scrollOffset = m.ui.scrollOffset
 		}
-	case "ctrl+d", "pgdown":
+	case actionPageDown:
 		// Jump down half page
 		treeItems := m.getVisibleTreeItems()
 		maxCursor := len(treeItems) - 1
@@ -470,63 +437,61 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 			list.cursor = m.ui.cursor
 			list.scrollOffset = m.ui.scrollOffset
 		}
-	case " ":
+	case actionBurndown:
+		// Show burndown chart for the current sprint tab
+		return m.openBurndown()
+	case actionBoard:
+		// Show the current list as a board
+		return m.openBoard()
+	case actionExport:
+		// Export the visible tree
+		return m.openExport()
+	case actionImport:
+		// Import work items from an outline or CSV
+		return m.openImport()
+	case actionSprintBrowser:
+		// Browse all team sprints
+		if m.currentMode == sprintMode {
+			return m.openSprintBrowser(false)
+		}
+	case actionToggleSelect:
 		// Toggle selection for current item
 		treeItems := m.getVisibleTreeItems()
 		if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
-			itemID := treeItems[m.ui.cursor].WorkItem.ID
-			if m.batch.selectedItems[itemID] {
-				delete(m.batch.selectedItems, itemID)
+			item := treeItems[m.ui.cursor].WorkItem
+			if m.batch.isSelected(item.ID) {
+				delete(m.batch.selectedItems, item.ID)
 			} else {
-				m.batch.selectedItems[itemID] = true
+				m.batch.selectedItems[item.ID] = *item
 			}
 		}
-	case "enter":
+	case actionOpen:
 		treeItems := m.getVisibleTreeItems()
 		// Check if cursor is on "Load More" item
 		if m.ui.cursor == len(treeItems) && m.hasMoreItems() {
-			if m.client != nil {
+			// Fetch the next page of the IDs returned by the list's query
+			var pageIDs []int
+			totalCount := 0
+			if list := m.getCurrentList(); list != nil {
+				pageIDs = list.nextPageIDs(m.pageSize())
+				totalCount = list.totalCount
+			}
+			if m.client != nil && len(pageIDs) > 0 {
 				m.loadingMore = true
 
 				if m.currentMode == sprintMode {
-					// Load more items by excluding already loaded IDs for current sprint
-					// Get current sprint path
-					sprint := m.sprints[m.currentTab]
-					sprintPath := ""
-					if sprint != nil {
-						sprintPath = sprint.Path
-					}
-
-					// Collect all currently loaded IDs in this sprint to exclude
-					excludeIDs := make([]int, 0)
-					list := m.getCurrentList()
-					if list != nil {
-						for _, task := range list.tasks {
-							if task.IterationPath == sprintPath {
-								excludeIDs = append(excludeIDs, task.ID)
-							}
-						}
-					}
-
 					tab := m.currentTab
-					return m, tea.Batch(loadTasksForSprint(m.client, excludeIDs, sprintPath, defaultLoadLimit, &tab), m.spinner.Tick)
-				} else if m.currentMode == backlogMode {
-					// Load more items for backlog mode
-					// Collect all currently loaded IDs in this backlog tab to exclude
-					excludeIDs := make([]int, 0)
-					list := m.getCurrentList()
-					if list != nil {
-						for _, task := range list.tasks {
-							excludeIDs = append(excludeIDs, task.ID)
-						}
-					}
-					return m, tea.Batch(loadMoreBacklogItems(m.client, m.currentBacklogTab, m.getCurrentSprintPath(), excludeIDs), m.spinner.Tick)
+					return m, tea.Batch(loadNextPage(m.listLoads.context(), m.client, pageIDs, totalCount, &tab, nil), m.spinner.Tick)
 				}
+				tab := m.currentBacklogTab
+				return m, tea.Batch(loadNextPage(m.listLoads.context(), m.client, pageIDs, totalCount, nil, &tab), m.spinner.Tick)
 			}
 		} else if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
 			m.selectedTask = treeItems[m.ui.cursor].WorkItem
 			m.selectedTaskID = m.selectedTask.ID
+			m.board.fromBoard = false
 			m.state = detailView
+			return m, m.loadSelectedDetails()
 		}
 	}
 	return m, nil
@@ -534,9 +499,26 @@ func (m model) handleListViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 
 // handleDetailViewNav handles navigation in the detail view
 func (m model) handleDetailViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "backspace", "left", "h":
+	switch m.keys.action(detailScope, msg.String()) {
+	case actionBack:
 		m.state = listView
+		m.closeViewLoads()
+		if m.board.fromBoard {
+			m.board.fromBoard = false
+			m.state = boardView
+		}
+	case actionHistory:
+		// Show revision history of the item
+		if m.selectedTask != nil && m.client != nil {
+			m.history = HistoryState{workItemID: m.selectedTask.ID}
+			m.state = historyView
+			m.loading = true
+			m.statusMessage = "Loading history..."
+			return m, tea.Batch(loadWorkItemHistory(m.viewLoads.context(), m.client, m.selectedTask.ID), m.spinner.Tick)
+		}
+	case actionAttachments:
+		// Show the item's attachments
+		return m.openAttachments()
 	}
 	return m, nil
 }
@@ -545,18 +527,18 @@ func (m model) handleDetailViewNav(msg tea.KeyMsg) (model, tea.Cmd) {
 func (m model) handleConfigWizardView(msg tea.KeyMsg) (model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	switch msg.String() {
-	case "ctrl+c", "esc":
+	switch m.viewAction(configWizardKeys, msg) {
+	case actionQuit:
 		// Cancel wizard and quit the application
 		return m, tea.Quit
 
-	case "tab":
+	case actionNextField:
 		// Move to next field
 		m.wizard.fieldCursor = (m.wizard.fieldCursor + 1) % 3
 		m.focusWizardField()
 		return m, nil
 
-	case "shift+tab":
+	case actionPrevField:
 		// Move to previous field
 		m.wizard.fieldCursor--
 		if m.wizard.fieldCursor < 0 {
@@ -565,7 +547,7 @@ func (m model) handleConfigWizardView(msg tea.KeyMsg) (model, tea.Cmd) {
 		m.focusWizardField()
 		return m, nil
 
-	case "enter":
+	case actionConfirm:
 		// Validate and save configuration
 		orgURL := strings.TrimSpace(m.wizard.orgInput.Value())
 		project := strings.TrimSpace(m.wizard.projectInput.Value())
@@ -598,13 +580,15 @@ func (m model) handleConfigWizardView(msg tea.KeyMsg) (model, tea.Cmd) {
 			team = project
 		}
 
-		// Save configuration
-		newConfig := &Config{
-			ConfigVersion:   1,
-			OrganizationURL: orgURL,
-			Project:         project,
-			Team:            team,
+		// Save configuration, keeping optional settings from the existing config
+		newConfig := &Config{}
+		if m.config != nil {
+			*newConfig = *m.config
 		}
+		newConfig.ConfigVersion = CurrentConfigVersion
+		newConfig.OrganizationURL = orgURL
+		newConfig.Project = project
+		newConfig.Team = team
 
 		if err := SaveConfig(newConfig); err != nil {
 			m.wizard.err = fmt.Sprintf("Failed to save config: %v", err)
@@ -623,7 +607,7 @@ func (m model) handleConfigWizardView(msg tea.KeyMsg) (model, tea.Cmd) {
 			ConfigPath:      configPath,
 		}
 
-		client, err := NewAzureDevOpsClient(m.config)
+		client, err := newBackend(m.config)
 		if err != nil {
 			m.err = fmt.Errorf("failed to initialize client: %w", err)
 			return m, nil
@@ -637,7 +621,7 @@ func (m model) handleConfigWizardView(msg tea.KeyMsg) (model, tea.Cmd) {
 
 		// Start loading data
 		return m, tea.Batch(
-			loadTasks(client),
+			loadTasks(m.listLoads.context(), client, m.pageSize()),
 			loadSprints(client),
 			m.spinner.Tick,
 		)
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -12,6 +14,10 @@ import (
 
 // handleTasksLoadedMsg handles the tasksLoadedMsg response
 func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
+	if isCancelled(msg.err) {
+		// Superseded by a later load; the list stays unattempted and loads again when shown
+		return m, nil
+	}
 	if msg.err != nil {
 		// Add context to error message based on which tab failed
 		var errorContext string
@@ -20,6 +26,7 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 				previousSprint: "previous sprint",
 				currentSprint:  "current sprint",
 				nextSprint:     "next sprint",
+				customSprint:   "selected sprint",
 			}
 			if tabName, ok := tabNames[*msg.forTab]; ok {
 				errorContext = fmt.Sprintf(" (%s)", tabName)
@@ -35,13 +42,20 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 		}
 
 		// Wrap error with context if available
+		err := msg.err
 		if errorContext != "" {
-			m.err = fmt.Errorf("failed to load%s: %w", errorContext, msg.err)
-		} else {
-			m.err = msg.err
+			err = fmt.Errorf("failed to load%s: %w", errorContext, msg.err)
 		}
 
 		m.statusMessage = ""
+		var timeout *TimeoutError
+		if errors.As(err, &timeout) {
+			// A slow server is worth another try rather than the error screen
+			m.statusMessage = fmt.Sprintf("Error: %v (press r to retry)", err)
+			m.setActionLog(fmt.Sprintf("Timed out loading%s", errorContext))
+		} else {
+			m.err = err
+		}
 		m.loadingMore = false
 		// If this was part of initial loading, decrement counter
 		if m.initialLoading > 0 {
@@ -68,6 +82,7 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 			if msg.append {
 				// Append new tasks to existing ones (load more scenario)
 				list.appendTasks(msg.tasks)
+				list.forgetIDs(msg.missingIDs)
 				list.totalCount = msg.totalCount
 
 				m.setActionLog(fmt.Sprintf("Loaded %d more items", len(msg.tasks)))
@@ -76,6 +91,8 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 			} else {
 				// Initial load or replace
 				list.replaceTasks(msg.tasks, msg.totalCount)
+				list.ids = msg.ids
+				list.forgetIDs(msg.missingIDs)
 
 				if targetTab == m.currentTab && m.currentMode == sprintMode {
 					// Sync cursor/scroll from list
@@ -91,6 +108,7 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 						m.loading = false
 						// Set log message after all initial sprints are loaded
 						m.setActionLog("Loaded previous, current, and next sprint")
+						m.selectBranchWorkItem()
 					}
 				} else {
 					m.loading = false
@@ -111,6 +129,7 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 			if msg.append {
 				// Append new tasks to existing ones (load more scenario)
 				list.appendTasks(msg.tasks)
+				list.forgetIDs(msg.missingIDs)
 				list.totalCount = msg.totalCount
 
 				m.setActionLog(fmt.Sprintf("Loaded %d more items", len(msg.tasks)))
@@ -119,6 +138,8 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 			} else {
 				// Store tasks for specific backlog tab
 				list.replaceTasks(msg.tasks, msg.totalCount)
+				list.ids = msg.ids
+				list.forgetIDs(msg.missingIDs)
 
 				if targetTab == m.currentBacklogTab && m.currentMode == backlogMode {
 					// Sync cursor/scroll from list
@@ -144,10 +165,15 @@ func (m model) handleTasksLoadedMsg(msg tasksLoadedMsg) (model, tea.Cmd) {
 // handleStateUpdatedMsg handles the stateUpdatedMsg response
 func (m model) handleStateUpdatedMsg(msg stateUpdatedMsg) (model, tea.Cmd) {
 	if msg.err != nil {
-		// Show detailed error
+		// Show the process rule that was broken rather than the wrapped server error
+		err := msg.err
+		var violation *RuleViolationError
+		if errors.As(err, &violation) {
+			err = violation
+		}
 		m.loading = false
-		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
-		m.setActionLog(fmt.Sprintf("Error updating state: %v", msg.err))
+		m.statusMessage = fmt.Sprintf("Error: %v", err)
+		m.setActionLog(fmt.Sprintf("Error updating state: %v", err))
 		m.batch.operationCount = 0 // Reset on error
 		m.state = listView
 		m.stateCursor = 0
@@ -167,8 +193,8 @@ func (m model) handleStateUpdatedMsg(msg stateUpdatedMsg) (model, tea.Cmd) {
 				oldState = m.selectedTask.State
 				taskTitle = m.selectedTask.Title
 			}
-			newState := ""
-			if m.stateCursor < len(m.availableStates) {
+			newState := m.stateChange.newState
+			if newState == "" && m.stateCursor < len(m.availableStates) {
 				newState = m.availableStates[m.stateCursor]
 			}
 
@@ -193,7 +219,7 @@ func (m model) handleStateUpdatedMsg(msg stateUpdatedMsg) (model, tea.Cmd) {
 					// Clear backlog data and reload current tab
 					m.backlogLists = make(map[backlogTab]*WorkItemList)
 					tab := m.currentBacklogTab
-					return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick)
+					return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
 				}
 			}
 		}
@@ -239,7 +265,7 @@ func (m model) handleSprintUpdatedMsg(msg sprintUpdatedMsg) (model, tea.Cmd) {
 					// Clear backlog data and reload current tab
 					m.backlogLists = make(map[backlogTab]*WorkItemList)
 					tab := m.currentBacklogTab
-					return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick)
+					return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
 				}
 			}
 		}
@@ -272,7 +298,7 @@ func (m model) handleWorkItemUpdatedMsg(msg workItemUpdatedMsg) (model, tea.Cmd)
 		// Refresh the list
 		if m.client != nil {
 			m.loading = true
-			return m, tea.Batch(loadTasks(m.client), loadSprints(m.client), m.spinner.Tick)
+			return m, tea.Batch(loadTasks(m.listLoads.context(), m.client, m.pageSize()), loadSprints(m.client), m.spinner.Tick)
 		}
 	}
 
@@ -281,16 +307,35 @@ func (m model) handleWorkItemUpdatedMsg(msg workItemUpdatedMsg) (model, tea.Cmd)
 
 // handleStatesLoadedMsg handles the statesLoadedMsg response
 func (m model) handleStatesLoadedMsg(msg statesLoadedMsg) (model, tea.Cmd) {
+	// The view that asked for the states was closed
+	if isCancelled(msg.err) {
+		return m, nil
+	}
 	m.loading = false
 	m.statusMessage = ""
 	if msg.err != nil {
 		m.statusMessage = fmt.Sprintf("Error loading states: %v", msg.err)
-	} else {
-		m.availableStates = msg.states
-		m.stateCategories = msg.stateCategories
-		m.state = statePickerView
-		m.stateCursor = 0
+		return m, nil
+	}
+
+	// The states are already limited to those every item being changed may move to
+	fromStates := m.stateChangeFromStates()
+	available := msg.states
+	if len(available) == 0 {
+		m.statusMessage = fmt.Sprintf("No allowed transitions from %s", strings.Join(fromStates, ", "))
+		return m, nil
+	}
+
+	m.availableStates = available
+	if m.stateCategories == nil {
+		m.stateCategories = make(map[string]string)
 	}
+	for state, category := range msg.stateCategories {
+		m.stateCategories[state] = category
+	}
+	m.stateChange = StateChangeState{fromStates: fromStates}
+	m.state = statePickerView
+	m.stateCursor = 0
 
 	return m, nil
 }
@@ -359,7 +404,7 @@ func (m model) handleWorkItemCreatedMsg(msg workItemCreatedMsg) (model, tea.Cmd)
 				// Clear backlog data and reload current tab
 				m.backlogLists = make(map[backlogTab]*WorkItemList)
 				tab := m.currentBacklogTab
-				return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick)
+				return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
 			}
 		}
 	}
@@ -399,7 +444,7 @@ func (m model) handleWorkItemDeletedMsg(msg workItemDeletedMsg) (model, tea.Cmd)
 					// Clear backlog data and reload current tab
 					m.backlogLists = make(map[backlogTab]*WorkItemList)
 					tab := m.currentBacklogTab
-					return m, tea.Batch(loadTasksForBacklogTab(m.client, tab, m.getCurrentSprintPath()), m.spinner.Tick)
+					return m, tea.Batch(loadTasksForBacklogTab(m.listLoads.context(), m.client, tab, m.getCurrentSprintPath(), m.pageSize()), m.spinner.Tick)
 				}
 			}
 			m.loading = false
@@ -446,7 +491,7 @@ func (m model) handleSprintsLoadedMsg(msg sprintsLoadedMsg) (model, tea.Cmd) {
 			sprintCount := 0
 			for tab, sprint := range m.sprints {
 				if sprint != nil {
-					loadCmds = append(loadCmds, loadInitialTasksForSprint(m.client, sprint.Path, tab))
+					loadCmds = append(loadCmds, loadInitialTasksForSprint(m.listLoads.context(), m.client, sprint.Path, tab, m.pageSize()))
 					sprintCount++
 				}
 			}
@@ -458,6 +503,10 @@ func (m model) handleSprintsLoadedMsg(msg sprintsLoadedMsg) (model, tea.Cmd) {
 				if m.state == loadingView {
 					m.state = listView
 				}
+				loadCmds = append(loadCmds, loadStateCategories(m.client))
+				if m.currentUser == "" {
+					loadCmds = append(loadCmds, loadCurrentUser(m.client))
+				}
 				loadCmds = append(loadCmds, m.spinner.Tick)
 				return m, tea.Batch(loadCmds...)
 			}
@@ -472,3 +521,42 @@ func (m model) handleSprintsLoadedMsg(msg sprintsLoadedMsg) (model, tea.Cmd) {
 
 	return m, nil
 }
+
+// handleStateCategoriesLoadedMsg merges the process's state categories into the known ones
+func (m model) handleStateCategoriesLoadedMsg(msg stateCategoriesLoadedMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		m.setActionLog(fmt.Sprintf("Error loading state categories: %v", msg.err))
+		return m, nil
+	}
+	if m.stateCategories == nil {
+		m.stateCategories = make(map[string]string)
+	}
+	for state, category := range msg.categories {
+		m.stateCategories[state] = category
+	}
+	return m, nil
+}
+
+// handleCurrentUserLoadedMsg stores the signed-in user for assignee:me filters.
+// A failure only affects those filters, so it is not surfaced.
+func (m model) handleCurrentUserLoadedMsg(msg currentUserLoadedMsg) (model, tea.Cmd) {
+	if msg.err == nil {
+		m.currentUser = msg.name
+	}
+	return m, nil
+}
+
+// handleBackendChangedMsg reloads the lists after work items were edited outside Hippo.
+// Other views, and lists that are busy loading, keep what they show until the user refreshes.
+func (m model) handleBackendChangedMsg(msg backendChangedMsg) (model, tea.Cmd) {
+	wait := waitForBackendChanges(m.client)
+	if m.state != listView || m.loading || m.batch.running {
+		m.statusMessage = "Work items changed on disk; press r to refresh"
+		return m, wait
+	}
+
+	m.supersedeLoads()
+	m.setActionLog("Work items changed on disk; reloading")
+	m, cmd := m.reloadLists()
+	return m, tea.Batch(cmd, wait)
+}
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // =============================================================================
@@ -1195,3 +1202,310 @@ func TestHandleSprintsLoadedMsg_ClientStorage(t *testing.T) {
 		t.Error("Expected client to be stored in model")
 	}
 }
+
+func TestLoadMorePagesThroughQueryIDs(t *testing.T) {
+	db := NewDummyBackend()
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	ids, err := db.GetSprintWorkItemIDs(context.Background(), curr.Path)
+	if err != nil || len(ids) < 5 {
+		t.Fatalf("expected at least 5 items in the current sprint, got %d (err: %v)", len(ids), err)
+	}
+
+	m := model{
+		client:      db,
+		config:      &Config{PageSize: 2},
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		batch:       BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+	msg := loadInitialTasksForSprint(context.Background(), db, curr.Path, currentSprint, m.pageSize())().(tasksLoadedMsg)
+	m, _ = m.handleTasksLoadedMsg(msg)
+	if got := len(m.getCurrentTasks()); got != 2 {
+		t.Fatalf("first page has %d items, want 2", got)
+	}
+
+	// An item deleted after the query ran is skipped without stalling paging
+	if err := db.DeleteWorkItem(context.Background(), ids[3]); err != nil {
+		t.Fatalf("DeleteWorkItem() error: %v", err)
+	}
+
+	for pages := 0; m.hasMoreItems(); pages++ {
+		if pages > len(ids) {
+			t.Fatal("paging did not finish")
+		}
+		m.ui.cursor = len(m.getVisibleTreeItems())
+		var cmd tea.Cmd
+		m, cmd = m.handleListViewNav(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil || !m.loadingMore {
+			t.Fatal("expected the next page to load")
+		}
+		page := loadNextPage(context.Background(), db, m.getCurrentList().nextPageIDs(m.pageSize()), m.getCurrentList().totalCount, &m.currentTab, nil)()
+		m, _ = m.handleTasksLoadedMsg(page.(tasksLoadedMsg))
+	}
+
+	var got []int
+	for _, task := range m.getCurrentTasks() {
+		got = append(got, task.ID)
+	}
+	want := append(slices.Clone(ids[:3]), ids[4:]...)
+	if !slices.Equal(got, want) {
+		t.Errorf("loaded IDs = %v, want %v in query order", got, want)
+	}
+	if list := m.getCurrentList(); list.totalCount != len(want) {
+		t.Errorf("totalCount = %d, want %d", list.totalCount, len(want))
+	}
+}
+
+func TestOpeningPartialItemLoadsDetails(t *testing.T) {
+	db := NewDummyBackend()
+	_, curr, _, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	m := model{
+		client:      db,
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		batch:       BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+	msg := loadInitialTasksForSprint(context.Background(), db, curr.Path, currentSprint, m.pageSize())().(tasksLoadedMsg)
+	m, _ = m.handleTasksLoadedMsg(msg)
+	if len(m.getCurrentTasks()) == 0 {
+		t.Fatal("expected items in the current sprint")
+	}
+	task := m.getCurrentTasks()[0]
+	if !task.Partial {
+		t.Fatal("expected list rows to hold only their list fields")
+	}
+
+	m.selectedTask = &task
+	cmd := m.loadSelectedDetails()
+	if cmd == nil {
+		t.Fatal("expected opening a partial item to load its details")
+	}
+	m, _ = m.handleWorkItemDetailsLoadedMsg(cmd().(workItemDetailsLoadedMsg))
+
+	if m.selectedTask.Partial {
+		t.Error("selected item is still partial after its details loaded")
+	}
+	if got := m.getCurrentTasks()[0]; got.Partial || got.ID != task.ID {
+		t.Errorf("list row %d Partial = %v, want the complete item %d", got.ID, got.Partial, task.ID)
+	}
+	if m.loadSelectedDetails() != nil {
+		t.Error("expected no reload once the item is complete")
+	}
+}
+
+func TestSwitchingTabCancelsSupersededLoads(t *testing.T) {
+	db := NewDummyBackend()
+	prev, curr, next, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	m := model{
+		client:      db,
+		sprints:     map[sprintTab]*Sprint{previousSprint: prev, currentSprint: curr, nextSprint: next},
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		batch:       BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+
+	// The current sprint is still loading when the user moves to the next sprint
+	m.loading = true
+	pending := loadInitialTasksForSprint(m.listLoads.context(), db, curr.Path, currentSprint, m.pageSize())
+	m, cmd := m.handleListViewNav(tea.KeyMsg{Type: tea.KeyTab})
+	if m.currentTab != nextSprint || cmd == nil || !m.loading {
+		t.Fatalf("tab = %v, want the next sprint loading", m.currentTab)
+	}
+
+	msg := pending().(tasksLoadedMsg)
+	if !isCancelled(msg.err) {
+		t.Fatalf("superseded load error = %v, want it cancelled", msg.err)
+	}
+	m, _ = m.handleTasksLoadedMsg(msg)
+	if m.err != nil || !m.loading {
+		t.Errorf("cancelled load set err = %v, loading = %v; want it ignored", m.err, m.loading)
+	}
+	if list := m.sprintLists[currentSprint]; list != nil && list.attempted {
+		t.Error("current sprint marked as loaded; want it to load again when shown")
+	}
+
+	// The load for the tab being shown is unaffected
+	msg = loadInitialTasksForSprint(m.listLoads.context(), db, next.Path, nextSprint, m.pageSize())().(tasksLoadedMsg)
+	if msg.err != nil {
+		t.Fatalf("next sprint load error: %v", msg.err)
+	}
+}
+
+func TestTimedOutLoadIsNotFatal(t *testing.T) {
+	tab := currentSprint
+	m := model{sprintLists: make(map[sprintTab]*WorkItemList), loading: true}
+	msg := tasksLoadedMsg{err: &TimeoutError{Timeout: defaultRequestTimeout, Err: context.DeadlineExceeded}, forTab: &tab}
+
+	m, _ = m.handleTasksLoadedMsg(msg)
+	if m.err != nil {
+		t.Errorf("err = %v, want the list to stay usable", m.err)
+	}
+	if m.loading || !strings.Contains(m.statusMessage, "did not respond") || !strings.Contains(m.statusMessage, "r to retry") {
+		t.Errorf("status = %q, loading = %v; want a retry hint", m.statusMessage, m.loading)
+	}
+}
+
+func TestSwitchingModesDuringInitialLoadReloadsTheSprint(t *testing.T) {
+	db := NewDummyBackend()
+	prev, curr, next, _ := db.GetCurrentAndAdjacentSprints(context.Background())
+	m := model{
+		client:       db,
+		state:        listView,
+		sprints:      map[sprintTab]*Sprint{previousSprint: prev, currentSprint: curr, nextSprint: next},
+		sprintLists:  make(map[sprintTab]*WorkItemList),
+		backlogLists: make(map[backlogTab]*WorkItemList),
+		currentMode:  sprintMode,
+		currentTab:   currentSprint,
+		batch:        BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+	m.keys, _ = newKeymap(nil)
+
+	// The current sprint is still loading when the user opens the backlog
+	m.loading = true
+	pending := loadInitialTasksForSprint(m.listLoads.context(), db, curr.Path, currentSprint, m.pageSize())
+	m, _, _ = m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	m, _ = m.handleTasksLoadedMsg(pending().(tasksLoadedMsg))
+
+	// Going back to the sprint loads it again instead of showing an empty list
+	m, cmd, _ := m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if m.currentMode != sprintMode || cmd == nil || !m.loading {
+		t.Fatalf("mode = %v, loading = %v; want the current sprint loading again", m.currentMode, m.loading)
+	}
+	msg := loadTasksForSprint(m.listLoads.context(), db, curr.Path, m.pageSize(), &m.currentTab)().(tasksLoadedMsg)
+	m, _ = m.handleTasksLoadedMsg(msg)
+	if len(m.getCurrentTasks()) == 0 {
+		t.Error("expected the current sprint's items after switching back")
+	}
+}
+
+func TestClearingFilterCancelsDetailLoad(t *testing.T) {
+	m := model{
+		client:      NewDummyBackend(),
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		currentMode: sprintMode,
+		filter:      FilterState{filterInput: textinput.New(), loadingDetail: true},
+	}
+	ctx := m.filter.detailLoads.context()
+
+	m, _ = m.handleFilterView(tea.KeyMsg{Type: tea.KeyEsc})
+	if !isCancelled(ctx.Err()) || m.filter.loadingDetail {
+		t.Fatalf("detail load ctx err = %v, loadingDetail = %v; want it cancelled", ctx.Err(), m.filter.loadingDetail)
+	}
+
+	// The cancelled load reports back after the filter was cleared
+	m.export.loading = true
+	m, _ = m.handleWorkItemDetailsLoadedMsg(workItemDetailsLoadedMsg{err: ctx.Err()})
+	if m.statusMessage != "" || !m.export.loading {
+		t.Errorf("status = %q, export loading = %v; want the cancelled load ignored", m.statusMessage, m.export.loading)
+	}
+}
+
+func TestClosingViewCancelsItsLoad(t *testing.T) {
+	sprint := &Sprint{Name: "Sprint 1", Path: "Project\\Sprint 1"}
+	tests := []struct {
+		name  string
+		model model
+		close func(model) (model, tea.Cmd)
+	}{
+		{"state picker", model{state: statePickerView, batch: BatchState{selectedItems: map[int]WorkItem{1: {ID: 1}}}}, func(m model) (model, tea.Cmd) {
+			return m.handleStatePickerView(tea.KeyMsg{Type: tea.KeyEsc})
+		}},
+		{"burndown", model{state: burndownView, burndown: BurndownState{sprint: sprint}}, func(m model) (model, tea.Cmd) {
+			return m.handleBurndownView(tea.KeyMsg{Type: tea.KeyEsc})
+		}},
+		{"sprint browser", model{state: sprintBrowserView}, func(m model) (model, tea.Cmd) {
+			return m.handleSprintBrowserView(tea.KeyMsg{Type: tea.KeyEsc})
+		}},
+		{"history", model{state: historyView, history: HistoryState{workItemID: 1}}, func(m model) (model, tea.Cmd) {
+			return m.handleHistoryView(tea.KeyMsg{Type: tea.KeyEsc})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model
+			m.loading = true
+			ctx := m.viewLoads.context()
+
+			m, _ = tt.close(m)
+			if !isCancelled(ctx.Err()) || m.loading {
+				t.Fatalf("view load ctx err = %v, loading = %v; want it cancelled", ctx.Err(), m.loading)
+			}
+		})
+	}
+
+	// Late answers of the closed views change nothing
+	m := model{state: listView, history: HistoryState{workItemID: 1}, burndown: BurndownState{sprint: sprint}}
+	cancelled := context.Canceled
+	m, _ = m.handleStatesLoadedMsg(statesLoadedMsg{err: cancelled})
+	m, _ = m.handleRequiredFieldsLoadedMsg(requiredFieldsLoadedMsg{err: cancelled})
+	m, _ = m.handleSprintHistoryLoadedMsg(sprintHistoryLoadedMsg{sprintPath: sprint.Path, err: cancelled})
+	m, _ = m.handleHistoryLoadedMsg(historyLoadedMsg{workItemID: 1, err: cancelled})
+	if m.state != listView || m.statusMessage != "" || m.lastActionLog != "" {
+		t.Errorf("state = %v, status = %q, log = %q; want the cancelled loads ignored", m.state, m.statusMessage, m.lastActionLog)
+	}
+}
+
+func TestDetailsLoadClearsOnlyItsOwnFlag(t *testing.T) {
+	tests := []struct {
+		name          string
+		purpose       detailsPurpose
+		wantFilter    bool
+		wantExporting bool
+	}{
+		{"selected item", detailsForSelected, true, true},
+		{"filter", detailsForFilter, false, true},
+		{"export", detailsForExport, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{
+				sprintLists: make(map[sprintTab]*WorkItemList),
+				filter:      FilterState{loadingDetail: true},
+				export:      ExportState{loading: true},
+			}
+			m, _ = m.handleWorkItemDetailsLoadedMsg(workItemDetailsLoadedMsg{purpose: tt.purpose})
+			if m.filter.loadingDetail != tt.wantFilter || m.export.loading != tt.wantExporting {
+				t.Errorf("loadingDetail = %v, export loading = %v; want %v, %v",
+					m.filter.loadingDetail, m.export.loading, tt.wantFilter, tt.wantExporting)
+			}
+		})
+	}
+}
+
+func TestBackendChangesReloadTheList(t *testing.T) {
+	if waitForBackendChanges(NewDummyBackend()) != nil || waitForBackendChanges(withRequestTimeout(NewDummyBackend(), time.Second)) != nil {
+		t.Error("waiting on a backend that doesn't watch for edits")
+	}
+	local, err := newLocalBackend(t.TempDir(), "ana", nil)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	if waitForBackendChanges(withRequestTimeout(local, time.Second)) == nil {
+		t.Error("not waiting on the edits of a wrapped local backend")
+	}
+
+	m := model{
+		client:      NewDummyBackend(),
+		state:       listView,
+		sprints:     map[sprintTab]*Sprint{},
+		sprintLists: map[sprintTab]*WorkItemList{currentSprint: {attempted: true}},
+		currentMode: sprintMode,
+		batch:       BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+	m, cmd := m.handleBackendChangedMsg(backendChangedMsg{})
+	if cmd == nil || !m.loading || m.sprintLists[currentSprint] != nil {
+		t.Errorf("loading = %v, lists = %v; want the list reloading", m.loading, m.sprintLists)
+	}
+
+	// The detail view keeps the item it shows
+	m = model{client: NewDummyBackend(), state: detailView}
+	m, _ = m.handleBackendChangedMsg(backendChangedMsg{})
+	if m.loading || m.state != detailView || !strings.Contains(m.statusMessage, "changed on disk") {
+		t.Errorf("state = %v, status = %q; want a hint to refresh", m.state, m.statusMessage)
+	}
+}
@@ -8,26 +8,26 @@ import (
 
 // handleMoveChildrenConfirmView handles keyboard input in the move children confirmation view
 func (m model) handleMoveChildrenConfirmView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(moveChildrenConfirmKeys, msg) {
+	case actionBack:
 		// Cancel the entire operation - clear batch selection and return to list view
-		m.batch.selectedItems = make(map[int]bool)
+		m.batch.selectedItems = make(map[int]WorkItem)
 		m.state = listView
 		m.stateCursor = 0
 		m.statusMessage = "Sprint move cancelled"
 		return m, nil
 
-	case "y", "Y":
+	case actionYes:
 		// Yes - move parents AND children
 		m.sprintMove.includeChildren = true
 		return m.executeSprintMove()
 
-	case "n", "N":
+	case actionNo:
 		// No - move only parents
 		m.sprintMove.includeChildren = false
 		return m.executeSprintMove()
 
-	case "enter":
+	case actionConfirm:
 		// If there are no children, just continue with the move
 		if m.sprintMove.childCount == 0 {
 			m.sprintMove.includeChildren = false
@@ -45,8 +45,6 @@ func (m model) executeSprintMove() (model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.loading = true
-
 	// Use the filtered tree that was already built during the confirmation stage
 	// This ensures we only move non-completed items
 	var itemsToMove []int
@@ -64,7 +62,7 @@ func (m model) executeSprintMove() (model, tea.Cmd) {
 	}
 
 	count := len(itemsToMove)
-	m.batch.operationCount = count
+	m, cmd := m.startBatch(moveToSprintBatchJob(m.sprintMove.targetPath, m.sprintMove.targetName), itemsToMove)
 
 	// Build status message
 	if m.sprintMove.includeChildren && m.sprintMove.childCount > 0 {
@@ -83,17 +81,7 @@ func (m model) executeSprintMove() (model, tea.Cmd) {
 			m.statusMessage = fmt.Sprintf("Moving %d items to %s...", count, m.sprintMove.targetName)
 		}
 	}
-	m.state = listView
-
-	var updateCmds []tea.Cmd
-	for _, itemID := range itemsToMove {
-		updateCmds = append(updateCmds, moveWorkItemToSprint(m.client, itemID, m.sprintMove.targetPath))
-	}
-
-	// Clear selection after starting update
-	m.batch.selectedItems = make(map[int]bool)
-	updateCmds = append(updateCmds, m.spinner.Tick)
-	return m, tea.Batch(updateCmds...)
+	return m, cmd
 }
 
 // collectTreeItemIDs recursively collects all work item IDs from a tree item and its children
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openSprintBrowser shows the list of all team sprints and starts loading it.
+// With forMove set, choosing a sprint moves the selected items there instead of opening it as a tab.
+func (m model) openSprintBrowser(forMove bool) (model, tea.Cmd) {
+	if m.client == nil {
+		return m, nil
+	}
+
+	m.browser = SprintBrowserState{forMove: forMove}
+	m.state = sprintBrowserView
+	m.loading = true
+	m.statusMessage = "Loading sprints..."
+	return m, tea.Batch(loadAllSprints(m.viewLoads.context(), m.client), m.spinner.Tick)
+}
+
+// getSprintBrowserHeight returns the number of sprint rows that fit on screen
+func (m model) getSprintBrowserHeight() int {
+	// Title bar, hint, and footer take roughly 10 lines
+	height := m.ui.height - 10
+	if height < 5 {
+		height = 5
+	}
+	return height
+}
+
+// adjustSprintBrowserScroll keeps the browser cursor within the visible rows
+func (m *model) adjustSprintBrowserScroll() {
+	height := m.getSprintBrowserHeight()
+	if m.browser.cursor < m.browser.scrollOffset {
+		m.browser.scrollOffset = m.browser.cursor
+	}
+	if m.browser.cursor >= m.browser.scrollOffset+height {
+		m.browser.scrollOffset = m.browser.cursor - height + 1
+	}
+}
+
+// handleSprintBrowserView handles keyboard input in the sprint browser
+func (m model) handleSprintBrowserView(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch m.viewAction(sprintBrowserKeys, msg) {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.closeViewLoads()
+		m.statusMessage = ""
+		if m.browser.forMove {
+			// Back to the sprint picker the browser was opened from
+			m.state = sprintPickerView
+		} else {
+			m.state = listView
+		}
+		return m, nil
+	case actionUp:
+		if m.browser.cursor > 0 {
+			m.browser.cursor--
+		}
+	case actionDown:
+		if m.browser.cursor < len(m.browser.sprints)-1 {
+			m.browser.cursor++
+		}
+	case actionPageUp:
+		m.browser.cursor = max(0, m.browser.cursor-10)
+	case actionPageDown:
+		m.browser.cursor = max(0, min(len(m.browser.sprints)-1, m.browser.cursor+10))
+	case actionConfirm:
+		if m.browser.cursor < 0 || m.browser.cursor >= len(m.browser.sprints) {
+			return m, nil
+		}
+		sprint := m.browser.sprints[m.browser.cursor]
+		if m.browser.forMove {
+			return m.moveSelectedToSprint(sprint.Path, sprint.Name)
+		}
+		return m.jumpToSprint(sprint)
+	}
+
+	m.adjustSprintBrowserScroll()
+	return m, nil
+}
+
+// jumpToSprint opens a sprint as a tab. Previous, current, and next sprints switch to their own tab;
+// any other sprint replaces the custom sprint tab.
+func (m model) jumpToSprint(sprint Sprint) (model, tea.Cmd) {
+	m.state = listView
+	m.currentMode = sprintMode
+	m.batch.selectedItems = make(map[int]WorkItem)
+
+	for _, tab := range []sprintTab{previousSprint, currentSprint, nextSprint} {
+		if existing := m.sprints[tab]; existing != nil && existing.Path == sprint.Path {
+			m.currentTab = tab
+			if list := m.getCurrentList(); list != nil {
+				m.ui.cursor = list.cursor
+				m.ui.scrollOffset = list.scrollOffset
+			}
+			m.setActionLog(fmt.Sprintf("Switched to %s", sprint.Name))
+			return m, nil
+		}
+	}
+
+	// A sprint opened earlier may still be loading into the custom tab
+	m.supersedeLoads()
+	m.sprints[customSprint] = &sprint
+	m.sprintLists[customSprint] = &WorkItemList{}
+	m.currentTab = customSprint
+	m.ui.cursor = 0
+	m.ui.scrollOffset = 0
+	m.setActionLog(fmt.Sprintf("Opened %s", sprint.Name))
+
+	if m.client == nil {
+		return m, nil
+	}
+	m.loading = true
+	m.statusMessage = fmt.Sprintf("Loading %s...", sprint.Name)
+	return m, tea.Batch(loadInitialTasksForSprint(m.listLoads.context(), m.client, sprint.Path, customSprint, m.pageSize()), m.spinner.Tick)
+}
+
+// handleAllSprintsLoadedMsg handles the allSprintsLoadedMsg response
+func (m model) handleAllSprintsLoadedMsg(msg allSprintsLoadedMsg) (model, tea.Cmd) {
+	if m.state != sprintBrowserView || isCancelled(msg.err) {
+		return m, nil
+	}
+
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading sprints: %v", msg.err)
+		m.setActionLog(fmt.Sprintf("Error loading sprints: %v", msg.err))
+		return m, nil
+	}
+
+	m.browser.sprints = msg.sprints
+
+	// Start at the sprint of the current tab so nearby sprints are one keypress away
+	m.browser.cursor = 0
+	if sprint := m.sprints[m.currentTab]; sprint != nil {
+		for i, s := range msg.sprints {
+			if s.Path == sprint.Path {
+				m.browser.cursor = i
+				break
+			}
+		}
+	}
+	m.adjustSprintBrowserScroll()
+	return m, nil
+}
@@ -6,162 +6,139 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// sprintOption is a target offered by the sprint picker
+type sprintOption struct {
+	name   string
+	path   string
+	browse bool // Opens the sprint browser instead of moving
+}
+
+// sprintPickerOptions builds the list of targets shown in the sprint picker
+func (m model) sprintPickerOptions() []sprintOption {
+	options := []sprintOption{{name: "Backlog (no sprint)", path: ""}}
+
+	if sprint := m.sprints[previousSprint]; sprint != nil {
+		options = append(options, sprintOption{name: fmt.Sprintf("Previous Sprint - %s", sprint.Name), path: sprint.Path})
+	}
+	if sprint := m.sprints[currentSprint]; sprint != nil {
+		options = append(options, sprintOption{name: fmt.Sprintf("Current Sprint - %s", sprint.Name), path: sprint.Path})
+	}
+	if sprint := m.sprints[nextSprint]; sprint != nil {
+		options = append(options, sprintOption{name: fmt.Sprintf("Next Sprint - %s", sprint.Name), path: sprint.Path})
+	}
+	if sprint := m.sprints[customSprint]; sprint != nil {
+		options = append(options, sprintOption{name: sprint.Name, path: sprint.Path})
+	}
+
+	if m.client != nil {
+		options = append(options, sprintOption{name: "Other sprint...", browse: true})
+	}
+
+	return options
+}
+
 // handleSprintPickerView handles keyboard input in the sprint picker view
 func (m model) handleSprintPickerView(msg tea.KeyMsg) (model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch m.viewAction(sprintPickerKeys, msg) {
+	case actionBack:
 		// Clear batch selection and return to list view
-		m.batch.selectedItems = make(map[int]bool)
+		m.batch.selectedItems = make(map[int]WorkItem)
 		m.state = listView
 		m.stateCursor = 0
 		return m, nil
 
-	case "up", "k":
+	case actionUp:
 		if m.stateCursor > 0 {
 			m.stateCursor--
 		}
 
-	case "down", "j":
-		// Count available options dynamically
-		maxOptions := 0 // Backlog
-		if m.sprints[previousSprint] != nil {
-			maxOptions++
-		}
-		if m.sprints[currentSprint] != nil {
-			maxOptions++
-		}
-		if m.sprints[nextSprint] != nil {
-			maxOptions++
-		}
-
-		if m.stateCursor < maxOptions {
+	case actionDown:
+		if m.stateCursor < len(m.sprintPickerOptions())-1 {
 			m.stateCursor++
 		}
 
-	case "enter":
-		// Build the same options list as in the view
-		options := []struct {
-			name string
-			path string
-		}{
-			{"Backlog (no sprint)", ""},
+	case actionConfirm:
+		options := m.sprintPickerOptions()
+		if m.stateCursor >= 0 && m.stateCursor < len(options) {
+			option := options[m.stateCursor]
+			if option.browse {
+				return m.openSprintBrowser(true)
+			}
+			return m.moveSelectedToSprint(option.path, option.name)
 		}
+		return m, nil
+	}
 
-		if m.sprints[previousSprint] != nil {
-			options = append(options, struct {
-				name string
-				path string
-			}{
-				name: fmt.Sprintf("Previous Sprint - %s", m.sprints[previousSprint].Name),
-				path: m.sprints[previousSprint].Path,
-			})
-		}
+	return m, nil
+}
 
-		if m.sprints[currentSprint] != nil {
-			options = append(options, struct {
-				name string
-				path string
-			}{
-				name: fmt.Sprintf("Current Sprint - %s", m.sprints[currentSprint].Name),
-				path: m.sprints[currentSprint].Path,
-			})
-		}
+// moveSelectedToSprint moves the batch-selected items to the target sprint.
+// A single parent item with open children goes through the move children confirmation first.
+func (m model) moveSelectedToSprint(targetPath, targetName string) (model, tea.Cmd) {
+	if len(m.batch.selectedItems) == 0 || m.client == nil {
+		return m, nil
+	}
 
-		if m.sprints[nextSprint] != nil {
-			options = append(options, struct {
-				name string
-				path string
-			}{
-				name: fmt.Sprintf("Next Sprint - %s", m.sprints[nextSprint].Name),
-				path: m.sprints[nextSprint].Path,
-			})
+	// Check if this is a batch operation (multiple items) or single item
+	isBatchMode := len(m.batch.selectedItems) > 1
+
+	if isBatchMode {
+		// BATCH MODE: Move only the selected items, no filtering, no confirmation
+		return m.startBatch(moveToSprintBatchJob(targetPath, targetName), m.selectedIDs())
+	} else {
+		// SINGLE ITEM MODE: Check for parent with children, filter completed, show confirmation
+		treeItems := m.getVisibleTreeItems()
+		taskMap := make(map[int]*WorkItem)
+		for i := range treeItems {
+			taskMap[treeItems[i].WorkItem.ID] = treeItems[i].WorkItem
 		}
 
-		// Get the selected sprint path
-		if m.stateCursor >= 0 && m.stateCursor < len(options) {
-			targetPath := options[m.stateCursor].path
-			targetName := options[m.stateCursor].name
-
-			if len(m.batch.selectedItems) > 0 && m.client != nil {
-				// Check if this is a batch operation (multiple items) or single item
-				isBatchMode := len(m.batch.selectedItems) > 1
-
-				if isBatchMode {
-					// BATCH MODE: Move only the selected items, no filtering, no confirmation
-					m.loading = true
-					count := len(m.batch.selectedItems)
-					m.batch.operationCount = count
-					m.statusMessage = fmt.Sprintf("Moving %d items to %s...", count, targetName)
-					m.state = listView
-
-					var updateCmds []tea.Cmd
-					for itemID := range m.batch.selectedItems {
-						updateCmds = append(updateCmds, moveWorkItemToSprint(m.client, itemID, targetPath))
-					}
-
-					// Clear selection after starting update
-					m.batch.selectedItems = make(map[int]bool)
-					updateCmds = append(updateCmds, m.spinner.Tick)
-					return m, tea.Batch(updateCmds...)
-				} else {
-					// SINGLE ITEM MODE: Check for parent with children, filter completed, show confirmation
-					treeItems := m.getVisibleTreeItems()
-					taskMap := make(map[int]*WorkItem)
-					for i := range treeItems {
-						taskMap[treeItems[i].WorkItem.ID] = treeItems[i].WorkItem
-					}
-
-					// Get the single selected item
-					var selectedItemID int
-					for itemID := range m.batch.selectedItems {
-						selectedItemID = itemID
-						break
-					}
+		// Get the single selected item
+		var selectedItemID int
+		for itemID := range m.batch.selectedItems {
+			selectedItemID = itemID
+			break
+		}
 
-					if task, ok := taskMap[selectedItemID]; ok {
-						// Check if this is a parent with children
-						if len(task.Children) > 0 {
-							// Build filtered tree (excluding completed children)
-							treeItem := m.findTreeItem(treeItems, selectedItemID)
-							if treeItem != nil {
-								totalSkippedCount := 0
-								filteredTreeItem := m.filterCompletedFromTree(*treeItem, &totalSkippedCount)
-
-								if filteredTreeItem != nil {
-									// Count non-completed descendants
-									totalChildCount := m.countNonCompletedDescendants(task)
-
-									if totalChildCount > 0 {
-										// Show confirmation dialog
-										m.sprintMove.targetPath = targetPath
-										m.sprintMove.targetName = targetName
-										m.sprintMove.parentIDs = []int{selectedItemID}
-										m.sprintMove.childCount = totalChildCount
-										m.sprintMove.itemsToMove = []TreeItem{*filteredTreeItem}
-										m.sprintMove.skippedCount = totalSkippedCount
-										m.state = moveChildrenConfirmView
-										return m, nil
-									}
-								}
-							}
+		if task, ok := taskMap[selectedItemID]; ok {
+			// Check if this is a parent with children
+			if len(task.Children) > 0 {
+				// Build filtered tree (excluding completed children)
+				treeItem := m.findTreeItem(treeItems, selectedItemID)
+				if treeItem != nil {
+					totalSkippedCount := 0
+					filteredTreeItem := m.filterCompletedFromTree(*treeItem, &totalSkippedCount)
+
+					if filteredTreeItem != nil {
+						// Count non-completed descendants
+						totalChildCount := m.countNonCompletedDescendants(task)
+
+						if totalChildCount > 0 {
+							// Show confirmation dialog
+							m.sprintMove.targetPath = targetPath
+							m.sprintMove.targetName = targetName
+							m.sprintMove.parentIDs = []int{selectedItemID}
+							m.sprintMove.childCount = totalChildCount
+							m.sprintMove.itemsToMove = []TreeItem{*filteredTreeItem}
+							m.sprintMove.skippedCount = totalSkippedCount
+							m.state = moveChildrenConfirmView
+							return m, nil
 						}
-
-						// Not a parent or no children - just move the single item
-						m.loading = true
-						m.batch.operationCount = 1
-						m.statusMessage = fmt.Sprintf("Moving item to %s...", targetName)
-						m.state = listView
-
-						updateCmd := moveWorkItemToSprint(m.client, selectedItemID, targetPath)
-						m.batch.selectedItems = make(map[int]bool)
-						return m, tea.Batch(updateCmd, m.spinner.Tick)
 					}
 				}
 			}
+
+			// Not a parent or no children - just move the single item
+			m.loading = true
+			m.batch.operationCount = 1
+			m.statusMessage = fmt.Sprintf("Moving item to %s...", targetName)
+			m.state = listView
+
+			updateCmd := moveWorkItemToSprint(m.client, selectedItemID, targetPath)
+			m.batch.selectedItems = make(map[int]WorkItem)
+			return m, tea.Batch(updateCmd, m.spinner.Tick)
 		}
-		return m, nil
 	}
-
 	return m, nil
 }
 
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stateChangeItems returns the items whose state is being changed, in ID order: the batch
+// selection, wherever its items were selected, or the selected item
+func (m model) stateChangeItems() []WorkItem {
+	if len(m.batch.selectedItems) > 0 {
+		items := make([]WorkItem, 0, len(m.batch.selectedItems))
+		for _, id := range m.selectedIDs() {
+			items = append(items, m.batch.selectedItems[id])
+		}
+		return items
+	}
+	if m.selectedTask != nil {
+		return []WorkItem{*m.selectedTask}
+	}
+	return nil
+}
+
+// stateChangeFromStates returns the distinct current states of the items being changed
+func (m model) stateChangeFromStates() []string {
+	var states []string
+	for _, item := range m.stateChangeItems() {
+		if !slices.Contains(states, item.State) {
+			states = append(states, item.State)
+		}
+	}
+	return states
+}
+
+// startStateChange checks which fields the process requires for the new state before submitting
+func (m model) startStateChange(newState string) (model, tea.Cmd) {
+	items := m.stateChangeItems()
+	if len(items) == 0 || m.client == nil {
+		return m, nil
+	}
+
+	m.stateChange.itemIDs = make([]int, len(items))
+	m.stateChange.newState = newState
+	m.stateChange.fields = nil
+	m.stateChange.fieldTypes = nil
+	m.stateChange.values = make(map[string]string)
+	m.stateChange.err = nil
+
+	// Items of the same type share the answers; each field is asked once for all types needing it
+	m.stateChange.itemTypes = make(map[int]string, len(items))
+	for i, item := range items {
+		m.stateChange.itemIDs[i] = item.ID
+		m.stateChange.itemTypes[item.ID] = item.WorkItemType
+	}
+	m.loading = true
+	m.statusMessage = fmt.Sprintf("Checking what %s requires...", newState)
+	return m, tea.Batch(
+		checkRequiredFields(m.viewLoads.context(), m.client, items, newState),
+		m.spinner.Tick,
+	)
+}
+
+// handleRequiredFieldsLoadedMsg prompts for required fields, or submits when there are none
+func (m model) handleRequiredFieldsLoadedMsg(msg requiredFieldsLoadedMsg) (model, tea.Cmd) {
+	// The state picker was closed before the check answered
+	if isCancelled(msg.err) {
+		return m, nil
+	}
+	m.loading = false
+	m.statusMessage = ""
+	if msg.err != nil {
+		// Stay in the picker so another state can be chosen
+		m.stateChange.err = msg.err
+		m.setActionLog(fmt.Sprintf("Cannot change state to %s: %v", m.stateChange.newState, msg.err))
+		return m, nil
+	}
+	if len(msg.fields) == 0 {
+		return m.submitStateChange()
+	}
+
+	m.stateChange.fields = msg.fields
+	m.stateChange.fieldTypes = msg.fieldTypes
+	m.stateChange.fieldIndex = 0
+	m.state = stateFieldsView
+	return m.promptStateField()
+}
+
+// promptStateField prepares the prompt for the current required field
+func (m model) promptStateField() (model, tea.Cmd) {
+	field := m.stateChange.fields[m.stateChange.fieldIndex]
+	m.stateChange.valueCursor = 0
+	m.stateChange.err = nil
+	if len(field.AllowedValues) > 0 {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = field.Name
+	input.CharLimit = 255
+	input.Width = 60
+	m.stateChange.input = input
+	return m, m.stateChange.input.Focus()
+}
+
+// handleStateFieldsView handles keyboard input while prompting for required fields
+func (m model) handleStateFieldsView(msg tea.KeyMsg) (model, tea.Cmd) {
+	field := m.stateChange.fields[m.stateChange.fieldIndex]
+	choosing := len(field.AllowedValues) > 0
+
+	action := m.viewAction(stateFieldsKeys, msg)
+	switch action {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.stateChange.err = nil
+		m.state = statePickerView
+		return m, nil
+	case actionConfirm:
+		value := strings.TrimSpace(m.stateChange.input.Value())
+		if choosing {
+			value = field.AllowedValues[m.stateChange.valueCursor]
+		}
+		if value == "" {
+			m.stateChange.err = fmt.Errorf("%s is required for %s", field.Name, m.stateChange.newState)
+			return m, nil
+		}
+		m.stateChange.values[field.ReferenceName] = value
+		m.stateChange.fieldIndex++
+		if m.stateChange.fieldIndex >= len(m.stateChange.fields) {
+			return m.submitStateChange()
+		}
+		return m.promptStateField()
+	}
+
+	if choosing {
+		switch action {
+		case actionUp:
+			if m.stateChange.valueCursor > 0 {
+				m.stateChange.valueCursor--
+			}
+		case actionDown:
+			if m.stateChange.valueCursor < len(field.AllowedValues)-1 {
+				m.stateChange.valueCursor++
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.stateChange.input, cmd = m.stateChange.input.Update(msg)
+	return m, cmd
+}
+
+// submitStateChange updates every item to the new state along with the collected fields
+func (m model) submitStateChange() (model, tea.Cmd) {
+	change := m.stateChange
+	fieldValues := typeFieldValues(change.values, change.fieldTypes)
+	if len(m.batch.selectedItems) > 0 {
+		return m.startBatch(setStateBatchJob(change.newState, fieldValues, change.itemTypes), change.itemIDs)
+	}
+
+	m.loading = true
+	m.batch.operationCount = len(change.itemIDs)
+	m.statusMessage = fmt.Sprintf("Updating state to %s...", change.newState)
+	m.state = statePickerView
+
+	var cmds []tea.Cmd
+	for _, id := range change.itemIDs {
+		values := fieldValues[change.itemTypes[id]]
+		if len(values) == 0 {
+			cmds = append(cmds, updateWorkItemState(m.work.context(), m.client, id, change.newState))
+		} else {
+			cmds = append(cmds, updateWorkItemStateWithFields(m.work.context(), m.client, id, change.newState, values))
+		}
+	}
+	cmds = append(cmds, m.spinner.Tick)
+	return m, tea.Batch(cmds...)
+}
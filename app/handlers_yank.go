@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// yankTargets returns the items to copy: the batch selection in list order, or the current item
+func (m model) yankTargets() []*WorkItem {
+	if m.state == detailView {
+		if m.selectedTask != nil {
+			return []*WorkItem{m.selectedTask}
+		}
+		return nil
+	}
+
+	treeItems := m.getVisibleTreeItems()
+	if len(m.batch.selectedItems) > 0 {
+		var items []*WorkItem
+		for _, treeItem := range treeItems {
+			if m.batch.isSelected(treeItem.WorkItem.ID) {
+				items = append(items, treeItem.WorkItem)
+			}
+		}
+		return items
+	}
+	if len(treeItems) > 0 && m.ui.cursor < len(treeItems) {
+		return []*WorkItem{treeItems[m.ui.cursor].WorkItem}
+	}
+	return nil
+}
+
+// openYank shows the yank menu for the current item or the batch selection
+func (m model) openYank() (model, tea.Cmd) {
+	items := m.yankTargets()
+	if len(items) == 0 {
+		return m, nil
+	}
+
+	m.yank = YankState{items: items, returnState: m.state}
+	m.state = yankView
+	return m, nil
+}
+
+// yankText renders the items being copied in a format
+func (m model) yankText(format yankFormat) string {
+	return formatYank(format, m.yank.items, m.config)
+}
+
+// handleYankView handles keyboard input in the yank menu
+func (m model) handleYankView(msg tea.KeyMsg) (model, tea.Cmd) {
+	action := m.viewAction(yankKeys, msg)
+	switch action {
+	case actionQuit:
+		return m, tea.Quit
+	case actionBack:
+		m.state = m.yank.returnState
+		return m, nil
+	case actionUp:
+		if m.yank.cursor > 0 {
+			m.yank.cursor--
+		}
+		return m, nil
+	case actionDown:
+		if m.yank.cursor < len(yankFormats)-1 {
+			m.yank.cursor++
+		}
+		return m, nil
+	case actionConfirm:
+		return m.yankAs(yankFormats[m.yank.cursor])
+	}
+
+	// Direct shortcut for each format
+	for _, format := range yankFormats {
+		if action == format.action() {
+			return m.yankAs(format)
+		}
+	}
+	return m, nil
+}
+
+// yankAs copies the items in a format and closes the menu
+func (m model) yankAs(format yankFormat) (model, tea.Cmd) {
+	m.state = m.yank.returnState
+	return m, copyYank(format, m.yankText(format), len(m.yank.items))
+}
+
+// handleYankDoneMsg handles the yankDoneMsg response
+func (m model) handleYankDoneMsg(msg yankDoneMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		m.setActionLog(fmt.Sprintf("Error copying: %v", msg.err))
+		return m, nil
+	}
+
+	if msg.count == 1 {
+		m.setActionLog(fmt.Sprintf("Copied %s to clipboard", m.yankText(msg.format)))
+	} else {
+		m.setActionLog(fmt.Sprintf("Copied %d items as %s to clipboard", msg.count, msg.format))
+	}
+	return m, nil
+}
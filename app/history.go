@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// historyFieldNames maps field reference names to the names shown in the history pane
+var historyFieldNames = map[string]string{
+	"System.Title":                            "Title",
+	"System.State":                            "State",
+	"System.Reason":                           "Reason",
+	"System.AssignedTo":                       "Assigned To",
+	"System.IterationPath":                    "Iteration",
+	"System.AreaPath":                         "Area",
+	"System.Tags":                             "Tags",
+	"System.Description":                      "Description",
+	"System.History":                          "Comment",
+	"System.WorkItemType":                     "Type",
+	"Microsoft.VSTS.Common.Priority":          "Priority",
+	"Microsoft.VSTS.Common.Severity":          "Severity",
+	"Microsoft.VSTS.Common.ResolvedReason":    "Resolved Reason",
+	"Microsoft.VSTS.Scheduling.RemainingWork": "Remaining Work",
+	"Microsoft.VSTS.Scheduling.CompletedWork": "Completed Work",
+	"Microsoft.VSTS.Scheduling.StoryPoints":   "Story Points",
+}
+
+// ignoredHistoryFields are bookkeeping fields that change on every revision
+var ignoredHistoryFields = map[string]bool{
+	"System.Id":                             true,
+	"System.Rev":                            true,
+	"System.ChangedDate":                    true,
+	"System.ChangedBy":                      true,
+	"System.AuthorizedDate":                 true,
+	"System.AuthorizedAs":                   true,
+	"System.RevisedDate":                    true,
+	"System.Watermark":                      true,
+	"System.PersonId":                       true,
+	"System.CommentCount":                   true,
+	"System.IterationId":                    true,
+	"System.AreaId":                         true,
+	"System.NodeName":                       true,
+	"System.TeamProject":                    true,
+	"System.CreatedDate":                    true,
+	"System.CreatedBy":                      true,
+	"System.BoardColumn":                    true,
+	"System.BoardColumnDone":                true,
+	"System.IterationLevel1":                true,
+	"System.IterationLevel2":                true,
+	"System.IterationLevel3":                true,
+	"System.AreaLevel1":                     true,
+	"System.AreaLevel2":                     true,
+	"System.AreaLevel3":                     true,
+	"Microsoft.VSTS.Common.StateChangeDate": true,
+	"Microsoft.VSTS.Common.ActivatedDate":   true,
+	"Microsoft.VSTS.Common.ActivatedBy":     true,
+	"Microsoft.VSTS.Common.ClosedDate":      true,
+	"Microsoft.VSTS.Common.ClosedBy":        true,
+	"Microsoft.VSTS.Common.ResolvedDate":    true,
+	"Microsoft.VSTS.Common.ResolvedBy":      true,
+}
+
+// fieldDisplayName returns a readable name for a field reference name (e.g. "Custom.ReleaseTrain" -> "ReleaseTrain")
+func fieldDisplayName(referenceName string) string {
+	if name, ok := historyFieldNames[referenceName]; ok {
+		return name
+	}
+	if idx := strings.LastIndex(referenceName, "."); idx >= 0 {
+		return referenceName[idx+1:]
+	}
+	return referenceName
+}
+
+// formatFieldValue converts a raw field value from the updates API to display text
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if strings.Contains(v, "<") {
+			return stripHTML(v)
+		}
+		return v
+	case float64:
+		return formatChartValue(v)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case map[string]interface{}:
+		// Identity fields
+		if displayName, ok := v["displayName"].(string); ok {
+			return displayName
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// diffRevisions turns consecutive revision snapshots into field-level updates.
+// The first revision lists every field it was created with.
+func diffRevisions(revisions []WorkItemRevision) []WorkItemUpdate {
+	sorted := append([]WorkItemRevision{}, revisions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Rev < sorted[j].Rev })
+
+	var updates []WorkItemUpdate
+	var prev WorkItemRevision
+	for _, rev := range sorted {
+		update := WorkItemUpdate{Rev: rev.Rev, ChangedBy: rev.ChangedBy, ChangedDate: rev.ChangedDate}
+		addChange := func(field, oldValue, newValue string) {
+			if oldValue != newValue {
+				update.Changes = append(update.Changes, WorkItemFieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+
+		addChange("Title", prev.Title, rev.Title)
+		addChange("State", prev.State, rev.State)
+		addChange("Assigned To", prev.AssignedTo, rev.AssignedTo)
+		addChange("Iteration", prev.IterationPath, rev.IterationPath)
+		addChange("Priority", formatPriority(prev.Priority), formatPriority(rev.Priority))
+		addChange("Tags", prev.Tags, rev.Tags)
+		addChange("Remaining Work", formatWork(prev.RemainingWork), formatWork(rev.RemainingWork))
+
+		updates = append(updates, update)
+		prev = rev
+	}
+	return updates
+}
+
+// formatPriority formats a priority for display, leaving unset priorities empty
+func formatPriority(priority int) string {
+	if priority == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", priority)
+}
+
+// formatWork formats remaining work for display, leaving zero empty
+func formatWork(work float64) string {
+	if work == 0 {
+		return ""
+	}
+	return formatChartValue(work)
+}
+
+// stateTimeline summarizes when the item entered each state, e.g. "New (Jan 2) → Active (Jan 5)"
+func stateTimeline(updates []WorkItemUpdate) string {
+	var steps []string
+	for _, update := range updates {
+		for _, change := range update.Changes {
+			if change.Field != "State" {
+				continue
+			}
+			date := ""
+			if t, err := parseRevisionDate(update.ChangedDate); err == nil {
+				date = fmt.Sprintf(" (%s)", t.Format("Jan 2"))
+			}
+			steps = append(steps, change.NewValue+date)
+		}
+	}
+	return strings.Join(steps, " → ")
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/workitemtracking"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	revisions := []WorkItemRevision{
+		// Out of order on purpose
+		{Rev: 2, ChangedBy: "Bob", ChangedDate: "2024-01-05T10:00:00", Title: "Fix login", State: "Active", AssignedTo: "Bob", Priority: 2, IterationPath: "P\\Sprint 1", RemainingWork: 5},
+		{Rev: 1, ChangedBy: "Alice", ChangedDate: "2024-01-02T09:00:00", Title: "Fix login", State: "New", AssignedTo: "Alice", Priority: 2, IterationPath: "P", RemainingWork: 8},
+		{Rev: 3, ChangedBy: "Bob", ChangedDate: "2024-01-06T10:00:00", Title: "Fix login", State: "Active", AssignedTo: "Bob", Priority: 2, IterationPath: "P\\Sprint 1", RemainingWork: 5},
+	}
+
+	updates := diffRevisions(revisions)
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(updates))
+	}
+
+	// First revision lists the initial values
+	if updates[0].Rev != 1 || len(updates[0].Changes) == 0 {
+		t.Errorf("expected initial values in first update, got %+v", updates[0])
+	}
+
+	// Second revision only lists what changed
+	changes := make(map[string]WorkItemFieldChange)
+	for _, change := range updates[1].Changes {
+		changes[change.Field] = change
+	}
+	expected := map[string][2]string{
+		"State":          {"New", "Active"},
+		"Assigned To":    {"Alice", "Bob"},
+		"Iteration":      {"P", "P\\Sprint 1"},
+		"Remaining Work": {"8", "5"},
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("expected %d changes, got %+v", len(expected), updates[1].Changes)
+	}
+	for field, values := range expected {
+		change, ok := changes[field]
+		if !ok {
+			t.Errorf("missing change for %s", field)
+			continue
+		}
+		if change.OldValue != values[0] || change.NewValue != values[1] {
+			t.Errorf("%s: got %q → %q, want %q → %q", field, change.OldValue, change.NewValue, values[0], values[1])
+		}
+	}
+
+	// Identical revision has no changes
+	if len(updates[2].Changes) != 0 {
+		t.Errorf("expected no changes, got %+v", updates[2].Changes)
+	}
+}
+
+func TestStateTimeline(t *testing.T) {
+	updates := []WorkItemUpdate{
+		{Rev: 1, ChangedDate: "2024-01-02T09:00:00", Changes: []WorkItemFieldChange{{Field: "State", NewValue: "New"}, {Field: "Title", NewValue: "X"}}},
+		{Rev: 2, ChangedDate: "2024-01-05T10:00:00", Changes: []WorkItemFieldChange{{Field: "Title", OldValue: "X", NewValue: "Y"}}},
+		{Rev: 3, ChangedDate: "2024-01-09T10:00:00", Changes: []WorkItemFieldChange{{Field: "State", OldValue: "New", NewValue: "Active"}}},
+	}
+
+	got := stateTimeline(updates)
+	want := "New (Jan 2) → Active (Jan 9)"
+	if got != want {
+		t.Errorf("stateTimeline() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatFieldValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"string", "Active", "Active"},
+		{"html", "<div>Hello <b>world</b></div>", "Hello world"},
+		{"whole number", float64(2), "2"},
+		{"fraction", 1.5, "1.5"},
+		{"identity", map[string]interface{}{"displayName": "Jane Doe", "uniqueName": "jane@example.com"}, "Jane Doe"},
+		{"bool", true, "Yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatFieldValue(tt.value); got != tt.expected {
+				t.Errorf("formatFieldValue() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertWorkItemUpdate(t *testing.T) {
+	rev := 4
+	name := "Jane Doe"
+	fields := map[string]workitemtracking.WorkItemFieldUpdate{
+		"System.State":       {OldValue: "New", NewValue: "Active"},
+		"System.Rev":         {OldValue: float64(3), NewValue: float64(4)},
+		"System.ChangedDate": {OldValue: "2024-01-01T10:00:00.123Z", NewValue: "2024-01-03T08:30:00.456Z"},
+		"Custom.Team":        {NewValue: "Platform"},
+	}
+	update := convertWorkItemUpdate(workitemtracking.WorkItemUpdate{
+		Rev:       &rev,
+		RevisedBy: &workitemtracking.IdentityReference{DisplayName: &name},
+		Fields:    &fields,
+	})
+
+	if update.Rev != 4 || update.ChangedBy != "Jane Doe" {
+		t.Errorf("unexpected header: %+v", update)
+	}
+	if update.ChangedDate != "2024-01-03T08:30:00" {
+		t.Errorf("expected changed date from System.ChangedDate, got %q", update.ChangedDate)
+	}
+	// Bookkeeping fields are dropped and the rest sorted by reference name
+	if len(update.Changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", update.Changes)
+	}
+	if update.Changes[0].Field != "Team" || update.Changes[0].NewValue != "Platform" {
+		t.Errorf("unexpected custom field change: %+v", update.Changes[0])
+	}
+	if update.Changes[1].Field != "State" || update.Changes[1].OldValue != "New" || update.Changes[1].NewValue != "Active" {
+		t.Errorf("unexpected state change: %+v", update.Changes[1])
+	}
+}
+
+func TestDummyBackend_GetWorkItemHistory(t *testing.T) {
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "History test", "Task", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.UpdateWorkItemState(context.Background(), item.ID, "Active"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updates, err := db.GetWorkItemHistory(context.Background(), item.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(updates))
+	}
+	last := updates[1]
+	if len(last.Changes) != 1 || last.Changes[0].Field != "State" || last.Changes[0].NewValue != "Active" {
+		t.Errorf("unexpected changes: %+v", last.Changes)
+	}
+
+	if _, err := db.GetWorkItemHistory(context.Background(), 99999); err == nil {
+		t.Error("expected error for unknown work item")
+	}
+}
+
+func TestHandleHistoryLoadedMsg(t *testing.T) {
+	updates := []WorkItemUpdate{{Rev: 1, ChangedBy: "Alice", ChangedDate: "2024-01-02T09:00:00", Changes: []WorkItemFieldChange{{Field: "State", NewValue: "New"}}}}
+
+	tests := []struct {
+		name          string
+		msg           historyLoadedMsg
+		expectUpdates bool
+		expectError   bool
+	}{
+		{"success", historyLoadedMsg{workItemID: 1, updates: updates}, true, false},
+		{"error", historyLoadedMsg{workItemID: 1, err: errors.New("forbidden")}, false, true},
+		{"stale item", historyLoadedMsg{workItemID: 2, updates: updates}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{
+				state:    historyView,
+				loading:  true,
+				history:  HistoryState{workItemID: 1},
+				viewport: viewport.New(80, 20),
+				ui:       UIState{width: 80, height: 30},
+			}
+
+			m, _ = m.handleHistoryLoadedMsg(tt.msg)
+
+			if (len(m.history.updates) > 0) != tt.expectUpdates {
+				t.Errorf("updates set = %v, want %v", len(m.history.updates) > 0, tt.expectUpdates)
+			}
+			if strings.Contains(m.statusMessage, "forbidden") != tt.expectError {
+				t.Errorf("unexpected status message: %q", m.statusMessage)
+			}
+			if tt.expectUpdates && !strings.Contains(m.buildHistoryContent(), "Alice") {
+				t.Error("expected history content to include the author")
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hookMarker identifies hook scripts written by `hippo hook install`
+const hookMarker = "# Installed by hippo hook install"
+
+// hippoHooks are the git hooks Hippo installs. prepare-commit-msg adds the work item
+// reference, commit-msg queues transitions, and post-commit applies them once the commit exists.
+var hippoHooks = []string{"prepare-commit-msg", "commit-msg", "post-commit"}
+
+// transitionQueueFile is the file in the git directory holding queued state transitions
+const transitionQueueFile = "hippo-transitions"
+
+var (
+	// workItemReference matches Azure Boards commit mentions like AB#1234
+	workItemReference = regexp.MustCompile(`(?i)\bAB#(\d+)\b`)
+	// transitionKeyword matches "fixes AB#1234" and friends
+	transitionKeyword = regexp.MustCompile(`(?i)\b(fix|fixes|fixed|resolve|resolves|resolved|close|closes|closed)\s*:?\s+AB#(\d+)\b`)
+)
+
+// workItemTransition is a state change requested by a commit message keyword
+type workItemTransition struct {
+	WorkItemID int
+	Keyword    string // Lowercased keyword, e.g. "fixes"
+}
+
+// transitionCategories returns the state categories a keyword moves an item to, in order of preference
+func transitionCategories(keyword string) []string {
+	if strings.HasPrefix(strings.ToLower(keyword), "close") {
+		return []string{"Completed"}
+	}
+	// Fixes and resolves go to Resolved when the process has it, so a tester can still verify
+	return []string{"Resolved", "Completed"}
+}
+
+// transitionTargetState picks the state to move an item to for a keyword, or "" if none applies
+func transitionTargetState(keyword, currentState string, states []string, categories map[string]string) string {
+	current := categories[currentState]
+	for _, category := range transitionCategories(keyword) {
+		if current == category || (category == "Resolved" && current == "Completed") {
+			return "" // Already there (or further)
+		}
+		for _, state := range states {
+			if categories[state] == category {
+				return state
+			}
+		}
+	}
+	return ""
+}
+
+// stripCommitComments removes the lines git strips from commit messages
+func stripCommitComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// insertWorkItemReference appends "AB#<id>" to the message body, before git's comment lines.
+// Messages that already mention the item are returned unchanged.
+func insertWorkItemReference(message string, workItemID int) string {
+	for _, match := range workItemReference.FindAllStringSubmatch(stripCommitComments(message), -1) {
+		if id, _ := strconv.Atoi(match[1]); id == workItemID {
+			return message
+		}
+	}
+
+	lines := strings.Split(message, "\n")
+
+	// The body ends where git's trailing comment block starts
+	bodyEnd := len(lines)
+	for bodyEnd > 0 && (strings.HasPrefix(lines[bodyEnd-1], "#") || strings.TrimSpace(lines[bodyEnd-1]) == "") {
+		bodyEnd--
+	}
+
+	reference := fmt.Sprintf("AB#%d", workItemID)
+	var result []string
+	result = append(result, lines[:bodyEnd]...)
+	if bodyEnd > 0 {
+		result = append(result, "")
+	}
+	result = append(result, reference)
+	if bodyEnd < len(lines) {
+		rest := lines[bodyEnd:]
+		if strings.TrimSpace(rest[0]) != "" {
+			result = append(result, "")
+		}
+		result = append(result, rest...)
+	} else {
+		result = append(result, "")
+	}
+	return strings.Join(result, "\n")
+}
+
+// parseTransitions returns the state transitions requested in a commit message, one per work item
+func parseTransitions(message string) []workItemTransition {
+	var transitions []workItemTransition
+	seen := make(map[int]bool)
+	for _, match := range transitionKeyword.FindAllStringSubmatch(stripCommitComments(message), -1) {
+		id, err := strconv.Atoi(match[2])
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		transitions = append(transitions, workItemTransition{WorkItemID: id, Keyword: strings.ToLower(match[1])})
+	}
+	return transitions
+}
+
+// gitPath resolves a path inside the git directory (hooks, queue files), honoring worktrees and core.hooksPath
+func gitPath(dir, name string) (string, error) {
+	path, err := runGit(dir, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, nil
+}
+
+// appendTransitionQueue adds transitions to the queue file in the git directory
+func appendTransitionQueue(dir string, transitions []workItemTransition) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+	path, err := gitPath(dir, transitionQueueFile)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open transition queue: %w", err)
+	}
+	defer file.Close()
+
+	for _, transition := range transitions {
+		if _, err := fmt.Fprintf(file, "%d %s\n", transition.WorkItemID, transition.Keyword); err != nil {
+			return fmt.Errorf("failed to queue transition: %w", err)
+		}
+	}
+	return nil
+}
+
+// takeTransitionQueue reads and removes the queued transitions
+func takeTransitionQueue(dir string) ([]workItemTransition, error) {
+	path, err := gitPath(dir, transitionQueueFile)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transition queue: %w", err)
+	}
+
+	var transitions []workItemTransition
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if id, err := strconv.Atoi(fields[0]); err == nil {
+			transitions = append(transitions, workItemTransition{WorkItemID: id, Keyword: fields[1]})
+		}
+	}
+	file.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transition queue: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return nil, fmt.Errorf("failed to clear transition queue: %w", err)
+	}
+	return transitions, nil
+}
+
+// hookScript returns the script for a hook. Global flags given at install time are kept,
+// so the hook loads configuration with the same precedence as the command that installed it.
+func hookScript(hook string, globalArgs []string) string {
+	args := append([]string{"hippo"}, globalArgs...)
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	return fmt.Sprintf("#!/bin/sh\n%s\n# Edits to this file are overwritten by the next install.\nexec %s hook %s \"$@\"\n", hookMarker, strings.Join(args, " "), hook)
+}
+
+// shellQuote quotes a value for a POSIX shell when needed
+func shellQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n'\"\\$`&|;<>()*?[]#~!{}") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// hookGlobalArgs returns the global flags to forward from the install command to the hooks
+func hookGlobalArgs(flags *FlagConfig) []string {
+	if flags == nil {
+		return nil
+	}
+	var args []string
+	if flags.ConfigPath != nil {
+		if abs, err := filepath.Abs(*flags.ConfigPath); err == nil {
+			args = append(args, "--config", abs)
+		}
+	}
+	if flags.OrganizationURL != nil {
+		args = append(args, "--org", *flags.OrganizationURL)
+	}
+	if flags.Project != nil {
+		args = append(args, "--project", *flags.Project)
+	}
+	if flags.Team != nil {
+		args = append(args, "--team", *flags.Team)
+	}
+	if flags.DummyMode {
+		args = append(args, "--dummy")
+	}
+	return args
+}
+
+// isHippoHook returns true if the file at path was written by hippo
+func isHippoHook(path string) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(content), hookMarker)
+}
+
+// installHooks writes the hippo hooks into the repository at dir. Existing hooks
+// that weren't written by hippo are only replaced with force.
+func installHooks(dir string, globalArgs []string, force bool) ([]string, error) {
+	hooksDir, err := gitPath(dir, "hooks")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	if !force {
+		for _, hook := range hippoHooks {
+			path := filepath.Join(hooksDir, hook)
+			if _, err := os.Stat(path); err == nil && !isHippoHook(path) {
+				return nil, fmt.Errorf("%s already exists (use --force to replace it)", path)
+			}
+		}
+	}
+
+	var installed []string
+	for _, hook := range hippoHooks {
+		path := filepath.Join(hooksDir, hook)
+		if err := os.WriteFile(path, []byte(hookScript(hook, globalArgs)), 0755); err != nil {
+			return installed, fmt.Errorf("failed to write %s: %w", hook, err)
+		}
+		installed = append(installed, path)
+	}
+	return installed, nil
+}
+
+// uninstallHooks removes the hooks written by hippo and leaves any others alone
+func uninstallHooks(dir string) ([]string, error) {
+	hooksDir, err := gitPath(dir, "hooks")
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, hook := range hippoHooks {
+		path := filepath.Join(hooksDir, hook)
+		if !isHippoHook(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", hook, err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
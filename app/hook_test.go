@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestInsertWorkItemReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "message with git comments",
+			message:  "Fix login\n\n# Please enter the commit message\n# Lines starting with '#' are ignored\n",
+			expected: "Fix login\n\nAB#42\n\n# Please enter the commit message\n# Lines starting with '#' are ignored\n",
+		},
+		{
+			name:     "plain message",
+			message:  "Fix login\n",
+			expected: "Fix login\n\nAB#42\n",
+		},
+		{
+			name:     "empty message from editor",
+			message:  "\n# Please enter the commit message\n",
+			expected: "AB#42\n\n# Please enter the commit message\n",
+		},
+		{
+			name:     "already referenced",
+			message:  "Fix login\n\nfixes ab#42\n",
+			expected: "Fix login\n\nfixes ab#42\n",
+		},
+		{
+			name:     "reference only in a comment",
+			message:  "Fix login\n# AB#42\n",
+			expected: "Fix login\n\nAB#42\n\n# AB#42\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertWorkItemReference(tt.message, 42); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseTransitions(t *testing.T) {
+	message := "Fix crash\n\nFixes AB#12, closes: AB#34\nresolved AB#12 again\nSee AB#56\n# fixes AB#78\n"
+	expected := []workItemTransition{
+		{WorkItemID: 12, Keyword: "fixes"},
+		{WorkItemID: 34, Keyword: "closes"},
+	}
+
+	if got := parseTransitions(message); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestTransitionTargetState(t *testing.T) {
+	agile := []string{"New", "Active", "Resolved", "Closed"}
+	agileCategories := map[string]string{"New": "Proposed", "Active": "InProgress", "Resolved": "Resolved", "Closed": "Completed"}
+	basic := []string{"To Do", "Doing", "Done"}
+	basicCategories := map[string]string{"To Do": "Proposed", "Doing": "InProgress", "Done": "Completed"}
+
+	tests := []struct {
+		name       string
+		keyword    string
+		state      string
+		states     []string
+		categories map[string]string
+		expected   string
+	}{
+		{"fixes prefers resolved", "fixes", "Active", agile, agileCategories, "Resolved"},
+		{"closes skips resolved", "closes", "Active", agile, agileCategories, "Closed"},
+		{"closes a resolved item", "closed", "Resolved", agile, agileCategories, "Closed"},
+		{"fixes a closed item", "fix", "Closed", agile, agileCategories, ""},
+		{"fixes without resolved state", "fixes", "Doing", basic, basicCategories, "Done"},
+		{"already done", "closes", "Done", basic, basicCategories, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transitionTargetState(tt.keyword, tt.state, tt.states, tt.categories); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHookScript(t *testing.T) {
+	script := hookScript("commit-msg", []string{"--config", "/home/me/my config.yaml", "--project", "Proj"})
+
+	if !strings.HasPrefix(script, "#!/bin/sh\n") || !strings.Contains(script, hookMarker) {
+		t.Errorf("expected shell script with marker, got %q", script)
+	}
+	expected := `exec hippo --config '/home/me/my config.yaml' --project Proj hook commit-msg "$@"`
+	if !strings.Contains(script, expected) {
+		t.Errorf("expected %q in script, got %q", expected, script)
+	}
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("unexpected quoting: %q", got)
+	}
+}
+
+func TestHookGlobalArgs(t *testing.T) {
+	project := "Proj"
+	configPath := "config.yaml"
+	args := hookGlobalArgs(&FlagConfig{Project: &project, ConfigPath: &configPath})
+
+	abs, _ := filepath.Abs(configPath)
+	expected := []string{"--config", abs, "--project", "Proj"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestInstallAndUninstallHooks(t *testing.T) {
+	dir := initTestRepo(t)
+	hooksDir := filepath.Join(dir, ".git", "hooks")
+
+	// Foreign hooks are kept unless forced
+	foreign := filepath.Join(hooksDir, "commit-msg")
+	if err := os.WriteFile(foreign, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := installHooks(dir, nil, false); err == nil {
+		t.Fatal("expected error for existing hook")
+	}
+
+	installed, err := installHooks(dir, nil, true)
+	if err != nil || len(installed) != len(hippoHooks) {
+		t.Fatalf("expected %d hooks installed, got %v (err: %v)", len(hippoHooks), installed, err)
+	}
+	for _, hook := range hippoHooks {
+		info, err := os.Stat(filepath.Join(hooksDir, hook))
+		if err != nil || info.Mode()&0111 == 0 {
+			t.Errorf("expected executable %s hook", hook)
+		}
+	}
+
+	// Reinstalling over our own hooks doesn't need force
+	if _, err := installHooks(dir, nil, false); err != nil {
+		t.Errorf("unexpected error reinstalling: %v", err)
+	}
+
+	removed, err := uninstallHooks(dir)
+	if err != nil || len(removed) != len(hippoHooks) {
+		t.Errorf("expected hooks removed, got %v (err: %v)", removed, err)
+	}
+}
+
+func TestHookCommandsEndToEnd(t *testing.T) {
+	dir := initTestRepo(t)
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "Hook test", "Bug", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other, err := db.CreateWorkItem(context.Background(), "Hook test 2", "Bug", "", nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := runGit(dir, "checkout", "-q", "-b", "users/jane/"+strconv.Itoa(item.ID)+"-hook-test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	ctx := &cliContext{client: db, dir: dir, stdout: &stdout, stderr: &stderr}
+
+	messageFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(messageFile, []byte("Handle hooks\n\ncloses AB#"+strconv.Itoa(other.ID)+"\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"prepare-commit-msg", messageFile, "message"},
+		{"commit-msg", messageFile},
+	} {
+		if err := runHookCommand(ctx, args); err != nil {
+			t.Fatalf("hook %s failed: %v", args[0], err)
+		}
+	}
+
+	content, _ := os.ReadFile(messageFile)
+	if !strings.Contains(string(content), "AB#"+strconv.Itoa(item.ID)) {
+		t.Errorf("expected branch reference in message, got %q", content)
+	}
+
+	// Nothing changes until the commit exists
+	if unchanged, _ := db.GetWorkItemByID(context.Background(), other.ID); unchanged.State != "New" {
+		t.Errorf("expected state unchanged before post-commit, got %q", unchanged.State)
+	}
+
+	if err := runHookCommand(ctx, []string{"post-commit"}); err != nil {
+		t.Fatalf("post-commit failed: %v", err)
+	}
+	if closed, _ := db.GetWorkItemByID(context.Background(), other.ID); closed.State != "Closed" {
+		t.Errorf("expected #%d closed, got %q (stderr: %s)", other.ID, closed.State, stderr.String())
+	}
+
+	// The queue is consumed
+	if transitions, err := takeTransitionQueue(dir); err != nil || len(transitions) != 0 {
+		t.Errorf("expected empty queue, got %v (err: %v)", transitions, err)
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// importItem is a work item to be created by an import
+type importItem struct {
+	Title         string
+	WorkItemType  string
+	Tags          string
+	IterationPath string // Empty uses the sprint the import targets
+	Priority      int
+	Description   string
+	Parent        int  // Index of the parent in the import, -1 for none
+	ParentID      *int // Existing work item to create the item under (CSV parent_id not in the file)
+	Line          int  // Line in the source file, for error messages
+}
+
+// knownWorkItemTypes are the type prefixes recognized in Markdown outlines ("Bug: Crash on save")
+var knownWorkItemTypes = []string{"Epic", "Feature", "User Story", "Product Backlog Item", "Requirement", "Issue", "Task", "Bug", "Test Case"}
+
+var (
+	// outlineBullet matches a Markdown list item with an optional checkbox
+	outlineBullet = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.*)$`)
+	// exportedID and exportedState strip what the Markdown export adds, so exports can be re-imported
+	exportedID    = regexp.MustCompile(`^#\d+\s+`)
+	exportedState = regexp.MustCompile(`\s+_\([^)]*\)_$`)
+	// trailingTags matches hashtags at the end of a title (#ui #backend); #123 stays in the title
+	trailingTag = regexp.MustCompile(`\s+#([A-Za-z][\w-]*)$`)
+)
+
+// parseImportFile parses an outline or CSV based on the file extension
+func parseImportFile(path string, r io.Reader) ([]importItem, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parseImportCSV(r)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseMarkdownOutline(string(content))
+}
+
+// parseOutlineTitle splits an outline entry into type, title and tags
+func parseOutlineTitle(text string) (workItemType, title, tags string) {
+	title = exportedState.ReplaceAllString(exportedID.ReplaceAllString(strings.TrimSpace(text), ""), "")
+
+	var tagList []string
+	for {
+		match := trailingTag.FindStringSubmatchIndex(title)
+		if match == nil {
+			break
+		}
+		tagList = append([]string{title[match[2]:match[3]]}, tagList...)
+		title = strings.TrimSpace(title[:match[0]])
+	}
+
+	if prefix, rest, found := strings.Cut(title, ":"); found {
+		for _, known := range knownWorkItemTypes {
+			if strings.EqualFold(strings.TrimSpace(prefix), known) {
+				workItemType = known
+				title = strings.TrimSpace(rest)
+				break
+			}
+		}
+	}
+
+	return workItemType, title, strings.Join(tagList, "; ")
+}
+
+// parseMarkdownOutline turns nested bullets into import items. Indentation sets the hierarchy;
+// items without a type prefix become a User Story at the top level when they have children, or a Task.
+func parseMarkdownOutline(content string) ([]importItem, error) {
+	var items []importItem
+	type level struct {
+		indent int
+		index  int
+	}
+	var stack []level
+
+	for lineNumber, line := range strings.Split(strings.ReplaceAll(content, "\t", "    "), "\n") {
+		match := outlineBullet.FindStringSubmatch(line)
+		if match == nil {
+			// Headings, prose and blank lines are skipped
+			continue
+		}
+		indent := len(match[1])
+		workItemType, title, tags := parseOutlineTitle(match[2])
+		if title == "" {
+			return nil, fmt.Errorf("line %d: empty title", lineNumber+1)
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := -1
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1].index
+		}
+
+		items = append(items, importItem{
+			Title:        title,
+			WorkItemType: workItemType,
+			Tags:         tags,
+			Parent:       parent,
+			Line:         lineNumber + 1,
+		})
+		stack = append(stack, level{indent: indent, index: len(items) - 1})
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no list items found")
+	}
+
+	// Default types: containers are stories, leaves are tasks
+	hasChildren := make(map[int]bool)
+	for _, item := range items {
+		if item.Parent >= 0 {
+			hasChildren[item.Parent] = true
+		}
+	}
+	for i := range items {
+		if items[i].WorkItemType != "" {
+			continue
+		}
+		if hasChildren[i] && items[i].Parent < 0 {
+			items[i].WorkItemType = "User Story"
+		} else {
+			items[i].WorkItemType = "Task"
+		}
+	}
+
+	return items, nil
+}
+
+// parseImportCSV reads items from a CSV with a header row. Only the title column is required.
+// An id column lets parent_id refer to rows of the same file; other parent IDs are existing work items.
+func parseImportCSV(r io.Reader) ([]importItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("csv needs a header row and at least one item")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("csv has no title column")
+	}
+	value := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var items []importItem
+	rowIndex := make(map[string]int) // Value of the id column → item index
+	for rowNumber, record := range records[1:] {
+		line := rowNumber + 2
+		item := importItem{
+			Title:         value(record, "title"),
+			WorkItemType:  value(record, "type"),
+			Tags:          value(record, "tags"),
+			IterationPath: value(record, "iteration_path"),
+			Description:   value(record, "description"),
+			Parent:        -1,
+			Line:          line,
+		}
+		if item.Title == "" {
+			return nil, fmt.Errorf("line %d: empty title", line)
+		}
+		if item.WorkItemType == "" {
+			item.WorkItemType = "Task"
+		}
+		if priority := value(record, "priority"); priority != "" {
+			p, err := strconv.Atoi(priority)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid priority %q", line, priority)
+			}
+			item.Priority = p
+		}
+
+		if parent := value(record, "parent_id"); parent != "" {
+			if index, ok := rowIndex[parent]; ok {
+				item.Parent = index
+			} else {
+				id, err := strconv.Atoi(strings.TrimPrefix(parent, "#"))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: parent %q must appear before its children or be a work item id", line, parent)
+				}
+				item.ParentID = &id
+			}
+		}
+
+		items = append(items, item)
+		if id := value(record, "id"); id != "" {
+			rowIndex[id] = len(items) - 1
+		}
+	}
+
+	return items, nil
+}
+
+// importPreviewTree arranges the items as a tree for the dry-run preview.
+// Preview items use their 1-based position in the import as a placeholder ID.
+func importPreviewTree(items []importItem) []TreeItem {
+	workItems := make([]WorkItem, len(items))
+	for i, item := range items {
+		workItems[i] = WorkItem{
+			ID:            i + 1,
+			Title:         item.Title,
+			WorkItemType:  item.WorkItemType,
+			Tags:          item.Tags,
+			IterationPath: item.IterationPath,
+		}
+		if item.Parent >= 0 {
+			parent := item.Parent + 1
+			workItems[i].ParentID = &parent
+		}
+	}
+	return flattenTree(buildTreeStructure(workItems))
+}
+
+// createImportedItems creates the items in order, so parents exist before their children.
+// It stops at the first failure or when ctx is cancelled and returns the items created so far.
+func createImportedItems(ctx context.Context, client Backend, items []importItem, iterationPath, areaPath string) ([]*WorkItem, error) {
+	created := make([]*WorkItem, 0, len(items))
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return created, err
+		}
+		parentID := item.ParentID
+		if item.Parent >= 0 {
+			id := created[item.Parent].ID
+			parentID = &id
+		}
+		path := item.IterationPath
+		if path == "" {
+			path = iterationPath
+		}
+
+		workItem, err := client.CreateWorkItem(ctx, item.Title, item.WorkItemType, path, parentID, areaPath)
+		if err != nil {
+			return created, fmt.Errorf("line %d (%s): %w", item.Line, item.Title, err)
+		}
+
+		// CreateWorkItem only takes the basics; set the rest in one update
+		updates := make(map[string]interface{})
+		if item.Tags != "" {
+			updates["tags"] = item.Tags
+		}
+		if item.Priority > 0 {
+			updates["priority"] = item.Priority
+		}
+		if item.Description != "" {
+			updates["description"] = item.Description
+		}
+		if len(updates) > 0 {
+			if err := client.UpdateWorkItem(ctx, workItem.ID, updates); err != nil {
+				created = append(created, workItem)
+				return created, fmt.Errorf("line %d (%s): created as #%d but fields not set: %w", item.Line, item.Title, workItem.ID, err)
+			}
+		}
+
+		created = append(created, workItem)
+	}
+	return created, nil
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseOutlineTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantType string
+		want     string
+		wantTags string
+	}{
+		{"Write docs", "", "Write docs", ""},
+		{"Bug: Crash on save #ui #p1", "Bug", "Crash on save", "ui; p1"},
+		{"user story: Login page", "User Story", "Login page", ""},
+		{"Note: not a type", "", "Note: not a type", ""},
+		{"#12 Exported item _(Active)_", "", "Exported item", ""},
+		{"Fix #123", "", "Fix #123", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			workItemType, title, tags := parseOutlineTitle(tt.input)
+			if workItemType != tt.wantType || title != tt.want || tags != tt.wantTags {
+				t.Errorf("parseOutlineTitle(%q) = %q, %q, %q; want %q, %q, %q",
+					tt.input, workItemType, title, tags, tt.wantType, tt.want, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestParseMarkdownOutline(t *testing.T) {
+	content := `# Release plan
+
+- Checkout
+  - Design the form
+  - Bug: Card declined twice #payments
+- [ ] Epic: Reporting
+	* Feature: Exports
+		1. CSV columns
+- Standalone
+`
+	items, err := parseMarkdownOutline(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		title        string
+		workItemType string
+		parent       int
+	}{
+		{"Checkout", "User Story", -1},
+		{"Design the form", "Task", 0},
+		{"Card declined twice", "Bug", 0},
+		{"Reporting", "Epic", -1},
+		{"Exports", "Feature", 3},
+		{"CSV columns", "Task", 4},
+		{"Standalone", "Task", -1},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d: %+v", len(want), len(items), items)
+	}
+	for i, w := range want {
+		if items[i].Title != w.title || items[i].WorkItemType != w.workItemType || items[i].Parent != w.parent {
+			t.Errorf("item %d = %q %q parent %d; want %q %q parent %d",
+				i, items[i].Title, items[i].WorkItemType, items[i].Parent, w.title, w.workItemType, w.parent)
+		}
+	}
+	if items[2].Tags != "payments" {
+		t.Errorf("expected tags from hashtags, got %q", items[2].Tags)
+	}
+
+	if _, err := parseMarkdownOutline("# Only a heading\n"); err == nil {
+		t.Error("expected error for an outline without items")
+	}
+}
+
+func TestParseMarkdownOutlineRoundTripsExport(t *testing.T) {
+	parent := 1
+	treeItems := flattenTree(buildTreeStructure([]WorkItem{
+		{ID: 1, Title: "Parent", State: "Active"},
+		{ID: 2, Title: "Child", State: "Closed", ParentID: &parent},
+	}))
+
+	items, err := parseMarkdownOutline(markdownChecklist("Sprint 1", treeItems, testCategoryOf))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].Title != "Parent" || items[1].Title != "Child" || items[1].Parent != 0 {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"missing title column", "name\nfoo\n", "no title column"},
+		{"empty title", "title\n\"\"\n", "line 2: empty title"},
+		{"invalid priority", "title,priority\nfoo,high\n", "invalid priority"},
+		{"unknown parent row", "title,parent_id\nfoo,abc\n", "must appear before its children"},
+		{"header only", "title\n", "at least one item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	input := "id,title,type,parent_id,tags,priority\n" +
+		"a,Checkout,User Story,,web,1\n" +
+		"b,Design the form,,a,,\n" +
+		"c,Fix rounding,Bug,#42,,\n"
+	items, err := parseImportCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if items[0].Priority != 1 || items[0].Tags != "web" || items[0].WorkItemType != "User Story" {
+		t.Errorf("unexpected first item: %+v", items[0])
+	}
+	if items[1].Parent != 0 || items[1].WorkItemType != "Task" {
+		t.Errorf("expected row parent and default type, got %+v", items[1])
+	}
+	if items[2].Parent != -1 || items[2].ParentID == nil || *items[2].ParentID != 42 {
+		t.Errorf("expected existing parent #42, got %+v", items[2])
+	}
+}
+
+func TestCreateImportedItems(t *testing.T) {
+	db := NewDummyBackend()
+	items := []importItem{
+		{Title: "Imported story", WorkItemType: "User Story", Parent: -1, Tags: "import", Priority: 2},
+		{Title: "Imported task", WorkItemType: "Task", Parent: 0, IterationPath: "Other\\Sprint"},
+	}
+
+	created, err := createImportedItems(context.Background(), db, items, "Project\\Sprint 1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created items, got %d", len(created))
+	}
+
+	story, _ := db.GetWorkItemByID(context.Background(), created[0].ID)
+	if story.Tags != "import" || story.Priority != 2 || story.IterationPath != "Project\\Sprint 1" {
+		t.Errorf("unexpected story: %+v", story)
+	}
+	task, _ := db.GetWorkItemByID(context.Background(), created[1].ID)
+	if task.ParentID == nil || *task.ParentID != story.ID || task.IterationPath != "Other\\Sprint" {
+		t.Errorf("expected task under #%d in its own sprint, got %+v", story.ID, task)
+	}
+}
+
+func TestImportViewFlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte("- Parent\n  - Child\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := model{
+		client:      NewDummyBackend(),
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		sprints:     map[sprintTab]*Sprint{currentSprint: {Name: "Sprint 1", Path: "Project\\Sprint 1"}},
+		sprintLists: map[sprintTab]*WorkItemList{},
+	}
+
+	m, _ = m.openImport()
+	if m.state != importView || m.imports.iterationPath != "Project\\Sprint 1" {
+		t.Fatalf("expected import prompt for the current sprint, got %v %q", m.state, m.imports.iterationPath)
+	}
+
+	m.imports.pathInput.SetValue(path)
+	m, cmd := m.handleImportView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected parse command")
+	}
+	m, _ = m.handleImportParsedMsg(cmd().(importParsedMsg))
+	if m.state != importConfirmView || len(m.imports.items) != 2 {
+		t.Fatalf("expected preview of 2 items, got %v %+v", m.state, m.imports.items)
+	}
+	if view := m.renderImportConfirmView(); !strings.Contains(view, "╰── [Task] Child") {
+		t.Errorf("expected child in preview tree, got:\n%s", view)
+	}
+
+	m, cmd = m.handleImportConfirmView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil || !m.loading {
+		t.Fatal("expected import to start")
+	}
+	var done importDoneMsg
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if result, ok := msg().(importDoneMsg); ok {
+			done = result
+			break
+		}
+	}
+	if done.err != nil || len(done.created) != 2 {
+		t.Fatalf("unexpected result: %+v", done)
+	}
+
+	m, _ = m.handleImportDoneMsg(done)
+	if m.state != listView || !strings.Contains(m.lastActionLog, "Imported 2 items") {
+		t.Errorf("expected list view with import log, got %v %q", m.state, m.lastActionLog)
+	}
+}
+
+func TestImportParseErrorStaysInPrompt(t *testing.T) {
+	m := model{sprintLists: map[sprintTab]*WorkItemList{}}
+	m, _ = m.openImport()
+
+	m, _ = m.handleImportParsedMsg(importParsedMsg{path: "x.csv", err: os.ErrNotExist})
+	if m.state != importView || !strings.Contains(m.statusMessage, "Error") {
+		t.Errorf("expected error in prompt, got %v %q", m.state, m.statusMessage)
+	}
+}
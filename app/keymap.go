@@ -0,0 +1,548 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	"gopkg.in/yaml.v3"
+)
+
+// keyAction is a named action that keys are bound to
+type keyAction string
+
+const (
+	// Global: list and detail views
+	actionQuit           keyAction = "quit"
+	actionHelp           keyAction = "help"
+	actionSprintMode     keyAction = "sprint_mode"
+	actionBacklogMode    keyAction = "backlog_mode"
+	actionRefresh        keyAction = "refresh"
+	actionOpenInBrowser  keyAction = "open_in_browser"
+	actionYank           keyAction = "yank"
+	actionCheckoutBranch keyAction = "checkout_branch"
+	actionChangeState    keyAction = "change_state"
+	actionEdit           keyAction = "edit"
+	actionFilter         keyAction = "filter"
+	actionFind           keyAction = "find"
+	actionInsert         keyAction = "insert"
+	actionAppend         keyAction = "append"
+	actionDelete         keyAction = "delete"
+
+	// List view
+	actionUp            keyAction = "up"
+	actionDown          keyAction = "down"
+	actionPageUp        keyAction = "page_up"
+	actionPageDown      keyAction = "page_down"
+	actionOpen          keyAction = "open"
+	actionNextTab       keyAction = "next_tab"
+	actionToggleSelect  keyAction = "toggle_select"
+	actionBurndown      keyAction = "burndown"
+	actionBoard         keyAction = "board"
+	actionExport        keyAction = "export"
+	actionImport        keyAction = "import"
+	actionSprintBrowser keyAction = "sprint_browser"
+
+	// Detail view
+	actionBack        keyAction = "back"
+	actionHistory     keyAction = "history"
+	actionAttachments keyAction = "attachments"
+
+	// Views with fixed keys
+	actionConfirm      keyAction = "confirm"
+	actionYes          keyAction = "yes"
+	actionNo           keyAction = "no"
+	actionSave         keyAction = "save"
+	actionNextField    keyAction = "next_field"
+	actionPrevField    keyAction = "previous_field"
+	actionCompletePath keyAction = "complete_path"
+	actionLeft         keyAction = "left"
+	actionRight        keyAction = "right"
+	actionMoveLeft     keyAction = "move_left"
+	actionMoveRight    keyAction = "move_right"
+	actionTop          keyAction = "top"
+	actionBottom       keyAction = "bottom"
+	actionScroll       keyAction = "scroll"
+	actionNextMetric   keyAction = "next_metric"
+	actionRetry        keyAction = "retry"
+	actionDownload     keyAction = "download"
+	actionUpload       keyAction = "upload"
+	actionSaveFile     keyAction = "save_file"
+	actionCopy         keyAction = "copy"
+)
+
+// keyScope is where a binding is active. Global bindings are tried first in the list and detail views.
+type keyScope string
+
+const (
+	globalScope keyScope = "Global"
+	listScope   keyScope = "List View"
+	detailScope keyScope = "Detail View"
+)
+
+// keyBinding describes an action and its default keys
+type keyBinding struct {
+	action keyAction
+	scope  keyScope
+	keys   []string
+	help   string // Description in the help view
+	short  string // Label in footers
+}
+
+// defaultKeyBindings lists every bindable action, in help view order
+var defaultKeyBindings = []keyBinding{
+	{actionHelp, globalScope, []string{"?"}, "Show/hide this help", "help"},
+	{actionQuit, globalScope, []string{"q"}, "Quit application (ctrl+c always quits)", "quit"},
+	{actionSprintMode, globalScope, []string{"1"}, "Switch to Sprint Mode", "sprints"},
+	{actionBacklogMode, globalScope, []string{"2"}, "Switch to Backlog Mode", "backlog"},
+	{actionRefresh, globalScope, []string{"r"}, "Refresh (all data in list, single item in detail)", "refresh"},
+	{actionOpenInBrowser, globalScope, []string{"o"}, "Open current item in browser", "open"},
+	{actionYank, globalScope, []string{"y"}, "Copy #ID, URL, \"ID: Title\" or a Markdown link (all selected items if any)", "copy"},
+	{actionCheckoutBranch, globalScope, []string{"c"}, "Check out a git branch for the item and move it to In Progress", "branch"},
+	{actionEdit, globalScope, []string{"e"}, "Edit current or selected items (shows menu: state, sprint, etc.)", "edit"},
+	{actionChangeState, globalScope, []string{"s"}, "Quick change state in detail view (skips menu)", "change state"},
+	{actionFilter, globalScope, []string{"/"}, "Filter items in current list", "filter"},
+	{actionFind, globalScope, []string{"f"}, "Find items with dedicated query", "find"},
+	{actionInsert, globalScope, []string{"i"}, "Insert new item before current", "insert"},
+	{actionAppend, globalScope, []string{"a"}, "Append new item after current (or as first child if parent)", "append"},
+	{actionDelete, globalScope, []string{"d"}, "Delete current item or selected items (with confirmation)", "delete"},
+
+	{actionNextTab, listScope, []string{"tab"}, "Cycle through tabs (sprint or backlog)", "cycle tabs"},
+	{actionUp, listScope, []string{"up", "k"}, "Move up", "up"},
+	{actionDown, listScope, []string{"down", "j"}, "Move down", "down"},
+	{actionPageUp, listScope, []string{"ctrl+u", "pgup"}, "Jump half page up", "page up"},
+	{actionPageDown, listScope, []string{"ctrl+d", "pgdown"}, "Jump half page down", "page down"},
+	{actionToggleSelect, listScope, []string{" "}, "Select/deselect item for batch operations", "select/deselect"},
+	{actionOpen, listScope, []string{"enter", "right", "l"}, "Open item details", "details"},
+	{actionBurndown, listScope, []string{"b"}, "Show burndown chart for the current sprint", "burndown"},
+	{actionSprintBrowser, listScope, []string{"S"}, "Browse all sprints and open one as a tab", "sprints"},
+	{actionBoard, listScope, []string{"v"}, "Show current list as a board (one column per state)", "board"},
+	{actionExport, listScope, []string{"x"}, "Export visible items (Markdown checklist, CSV, JSON) to a file or the clipboard", "export"},
+	{actionImport, listScope, []string{"I"}, "Import a Markdown outline or CSV as work items (with preview)", "import"},
+
+	{actionBack, detailScope, []string{"esc", "backspace", "left", "h"}, "Back to list", "back"},
+	{actionHistory, detailScope, []string{"H"}, "Show revision history (who changed what, and when)", "history"},
+	{actionAttachments, detailScope, []string{"A"}, "Show attachments (enter: download, u: attach a local file)", "attachments"},
+}
+
+// keyView is a view, or a mode of one, whose keys are fixed rather than configured
+type keyView string
+
+const (
+	helpKeys                keyView = "help"
+	errorKeys               keyView = "error"
+	filterKeys              keyView = "filter"
+	findKeys                keyView = "find"
+	statePickerKeys         keyView = "state picker"
+	stateFieldsKeys         keyView = "state fields"
+	editKeys                keyView = "edit"
+	createKeys              keyView = "create"
+	deleteConfirmKeys       keyView = "delete confirmation"
+	batchEditMenuKeys       keyView = "edit menu"
+	sprintPickerKeys        keyView = "sprint picker"
+	moveChildrenConfirmKeys keyView = "move children confirmation"
+	configWizardKeys        keyView = "setup wizard"
+	burndownKeys            keyView = "burndown"
+	sprintBrowserKeys       keyView = "sprint browser"
+	boardKeys               keyView = "board"
+	historyKeys             keyView = "history"
+	attachmentsKeys         keyView = "attachments"
+	attachmentPathKeys      keyView = "attachment path"
+	exportKeys              keyView = "export"
+	exportPathKeys          keyView = "export path"
+	importKeys              keyView = "import"
+	importConfirmKeys       keyView = "import preview"
+	yankKeys                keyView = "yank"
+	batchSummaryKeys        keyView = "batch summary"
+)
+
+// viewKeyBinding binds fixed keys of a view to an action
+type viewKeyBinding struct {
+	action keyAction
+	keys   []string
+}
+
+// viewKeyMap is the fixed keys of a view
+type viewKeyMap struct {
+	quits    bool // The keymap's quit key works in the view too, so it must not take a key of the view
+	bindings []viewKeyBinding
+}
+
+// viewKeyMaps holds the fixed keys of every view but the list and detail views, which use the
+// keymap. The view handlers dispatch through viewAction and newKeymap checks quit against the
+// same bindings, so the two can't drift apart.
+var viewKeyMaps = map[keyView]viewKeyMap{
+	helpKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionPageUp, []string{"ctrl+u", "pgup"}},
+		{actionPageDown, []string{"ctrl+d", "pgdown"}},
+		{actionTop, []string{"g"}},
+		{actionBottom, []string{"G"}},
+	}},
+	errorKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+	}},
+	filterKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionConfirm, []string{"enter"}},
+		{actionUp, []string{"up", "ctrl+k", "ctrl+p"}},
+		{actionDown, []string{"down", "ctrl+j", "ctrl+n"}},
+		{actionPageUp, []string{"ctrl+u", "pgup"}},
+		{actionPageDown, []string{"ctrl+d", "pgdown"}},
+	}},
+	findKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	statePickerKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionPageUp, []string{"ctrl+u", "pgup"}},
+		{actionPageDown, []string{"ctrl+d", "pgdown"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	stateFieldsKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c"}},
+		{actionBack, []string{"esc"}},
+		{actionConfirm, []string{"enter"}},
+		// Only when choosing from allowed values; a text field takes every other key
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+	}},
+	editKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionNextField, []string{"tab"}},
+		{actionPrevField, []string{"shift+tab"}},
+		{actionSave, []string{"ctrl+s"}},
+	}},
+	createKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionConfirm, []string{"enter"}},
+		{actionSave, []string{"ctrl+s"}},
+	}},
+	deleteConfirmKeys: {bindings: []viewKeyBinding{
+		{actionYes, []string{"y", "Y"}},
+		{actionNo, []string{"n", "N", "esc"}},
+	}},
+	batchEditMenuKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionPageUp, []string{"ctrl+u", "pgup"}},
+		{actionPageDown, []string{"ctrl+d", "pgdown"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	sprintPickerKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	moveChildrenConfirmKeys: {bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionYes, []string{"y", "Y"}},
+		{actionNo, []string{"n", "N"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	configWizardKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c", "esc"}},
+		{actionNextField, []string{"tab"}},
+		{actionPrevField, []string{"shift+tab"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	burndownKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc", "b"}},
+		{actionNextMetric, []string{"tab", "m"}},
+		{actionRefresh, []string{"r"}},
+	}},
+	sprintBrowserKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionPageUp, []string{"ctrl+u", "pgup"}},
+		{actionPageDown, []string{"ctrl+d", "pgdown"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	boardKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc", "v"}},
+		{actionLeft, []string{"left", "h"}},
+		{actionRight, []string{"right", "l"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionMoveLeft, []string{"shift+left", "H"}},
+		{actionMoveRight, []string{"shift+right", "L"}},
+		{actionOpen, []string{"enter"}},
+	}},
+	historyKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc", "H", "left", "h", "backspace"}},
+		{actionScroll, viewportKeys()},
+	}},
+	attachmentsKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc", "A", "left", "h", "backspace"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionDownload, []string{"enter", "d"}},
+		{actionUpload, []string{"u"}},
+	}},
+	attachmentPathKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c"}},
+		{actionBack, []string{"esc"}},
+		{actionCompletePath, []string{"tab"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	exportKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionBack, []string{"esc", "x"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionSaveFile, []string{"enter", "w"}},
+		{actionCopy, []string{"c", "y"}},
+	}},
+	exportPathKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c"}},
+		{actionBack, []string{"esc"}},
+		{actionCompletePath, []string{"tab"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	importKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c"}},
+		{actionBack, []string{"esc"}},
+		{actionCompletePath, []string{"tab"}},
+		{actionConfirm, []string{"enter"}},
+	}},
+	importConfirmKeys: {bindings: []viewKeyBinding{
+		{actionQuit, []string{"ctrl+c"}},
+		{actionBack, []string{"esc", "n"}},
+		{actionConfirm, []string{"y", "enter"}},
+	}},
+	yankKeys: {quits: true, bindings: append([]viewKeyBinding{
+		{actionBack, []string{"esc"}},
+		{actionUp, []string{"up", "k"}},
+		{actionDown, []string{"down", "j"}},
+		{actionConfirm, []string{"enter", "y"}},
+	}, yankFormatBindings()...)},
+	batchSummaryKeys: {quits: true, bindings: []viewKeyBinding{
+		{actionRetry, []string{"r"}},
+		{actionBack, []string{"esc", "enter"}},
+	}},
+}
+
+// viewportKeys returns the keys a viewport scrolls with
+func viewportKeys() []string {
+	keyMap := viewport.DefaultKeyMap()
+	var keys []string
+	for _, binding := range []key.Binding{keyMap.PageDown, keyMap.PageUp, keyMap.HalfPageUp, keyMap.HalfPageDown, keyMap.Up, keyMap.Down} {
+		keys = append(keys, binding.Keys()...)
+	}
+	return keys
+}
+
+// yankFormatBindings returns the shortcut of each yank format
+func yankFormatBindings() []viewKeyBinding {
+	bindings := make([]viewKeyBinding, len(yankFormats))
+	for i, format := range yankFormats {
+		bindings[i] = viewKeyBinding{format.action(), []string{format.key()}}
+	}
+	return bindings
+}
+
+// action returns the action bound to the key in the view
+func (v viewKeyMap) action(key string) keyAction {
+	for _, binding := range v.bindings {
+		if slices.Contains(binding.keys, key) {
+			return binding.action
+		}
+	}
+	return ""
+}
+
+// KeyList is one or more keys in config.yaml: `delete: ctrl+d` or `delete: [D, ctrl+d]`
+type KeyList []string
+
+// UnmarshalYAML accepts a single key as well as a list
+func (k *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*k = KeyList{value.Value}
+		return nil
+	}
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// Keymap resolves pressed keys to actions
+type Keymap struct {
+	bindings map[keyAction][]string
+	actions  map[keyScope]map[string]keyAction
+}
+
+// defaultKeymap is used by models without a configured keymap
+var defaultKeymap, _ = newKeymap(nil)
+
+// normalizeKey converts a configured key to the form bubbletea reports ("space" is " ")
+func normalizeKey(key string) string {
+	if strings.EqualFold(key, "space") {
+		return " "
+	}
+	return key
+}
+
+// displayKey converts a key to the form shown in help and footers
+func displayKey(key string) string {
+	switch key {
+	case " ":
+		return "space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	return key
+}
+
+// scopesOverlap reports whether bindings in the two scopes are active in the same view
+func scopesOverlap(a, b keyScope) bool {
+	return a == b || a == globalScope || b == globalScope
+}
+
+// newKeymap applies the configured overrides to the defaults. An override replaces all keys
+// of the action; an empty list unbinds it. Unknown actions and keys bound to two actions
+// that are active in the same view are reported as an error.
+func newKeymap(overrides map[string]KeyList) (Keymap, error) {
+	known := make(map[keyAction]keyBinding, len(defaultKeyBindings))
+	for _, binding := range defaultKeyBindings {
+		known[binding.action] = binding
+	}
+
+	var problems []string
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := known[keyAction(name)]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+		}
+	}
+
+	keymap := Keymap{
+		bindings: make(map[keyAction][]string),
+		actions:  make(map[keyScope]map[string]keyAction),
+	}
+	for _, binding := range defaultKeyBindings {
+		keys := binding.keys
+		if override, ok := overrides[string(binding.action)]; ok {
+			keys = nil
+			for _, key := range override {
+				keys = append(keys, normalizeKey(key))
+			}
+		}
+		keymap.bindings[binding.action] = keys
+
+		if keymap.actions[binding.scope] == nil {
+			keymap.actions[binding.scope] = make(map[string]keyAction)
+		}
+		for _, key := range keys {
+			keymap.actions[binding.scope][key] = binding.action
+		}
+	}
+
+	// Check every pair of bindings once, in help order, so messages are stable
+	for i, a := range defaultKeyBindings {
+		for _, b := range defaultKeyBindings[i+1:] {
+			if !scopesOverlap(a.scope, b.scope) {
+				continue
+			}
+			for _, key := range keymap.bindings[a.action] {
+				for _, other := range keymap.bindings[b.action] {
+					if key == other {
+						problems = append(problems, fmt.Sprintf("%q is bound to both %s and %s", displayKey(key), a.action, b.action))
+					}
+				}
+			}
+		}
+	}
+
+	// Quit also works in some views with fixed keys, so it must not take one of theirs
+	var views []string
+	for view, keys := range viewKeyMaps {
+		if keys.quits {
+			views = append(views, string(view))
+		}
+	}
+	sort.Strings(views)
+	for _, key := range keymap.bindings[actionQuit] {
+		for _, view := range views {
+			if action := viewKeyMaps[keyView(view)].action(key); action != "" && action != actionQuit {
+				problems = append(problems, fmt.Sprintf("%q is bound to %s but used by the %s view", displayKey(key), actionQuit, view))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return keymap, fmt.Errorf("invalid keybindings: %s", strings.Join(problems, "; "))
+	}
+	return keymap, nil
+}
+
+// action returns the action bound to the key in the scope
+func (k Keymap) action(scope keyScope, key string) keyAction {
+	if k.actions == nil {
+		k = defaultKeymap
+	}
+	return k.actions[scope][key]
+}
+
+// keys returns the keys bound to the action
+func (k Keymap) keys(action keyAction) []string {
+	if k.bindings == nil {
+		k = defaultKeymap
+	}
+	return k.bindings[action]
+}
+
+// label returns the keys of the action for the help view ("↑, k")
+func (k Keymap) label(action keyAction) string {
+	keys := k.keys(action)
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = displayKey(key)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// hints renders footer hints for the actions ("e: edit • d: delete"), skipping unbound ones
+func (k Keymap) hints(actions ...keyAction) string {
+	var hints []string
+	for _, action := range actions {
+		keys := k.keys(action)
+		if len(keys) == 0 {
+			continue
+		}
+		hints = append(hints, fmt.Sprintf("%s: %s", displayKey(keys[0]), keyBindingFor(action).short))
+	}
+	return strings.Join(hints, " • ")
+}
+
+// keyBindingFor returns the default binding of an action
+func keyBindingFor(action keyAction) keyBinding {
+	for _, binding := range defaultKeyBindings {
+		if binding.action == action {
+			return binding
+		}
+	}
+	return keyBinding{action: action}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultKeymapHasNoConflicts(t *testing.T) {
+	if _, err := newKeymap(nil); err != nil {
+		t.Fatalf("default keymap should be valid: %v", err)
+	}
+	if got := defaultKeymap.action(listScope, " "); got != actionToggleSelect {
+		t.Errorf("expected space to toggle selection, got %q", got)
+	}
+}
+
+func TestNewKeymap(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]KeyList
+		wantErr   string
+	}{
+		{"rebind", map[string]KeyList{"quit": {"ctrl+q"}, "delete": {"D"}}, ""},
+		{"unbind", map[string]KeyList{"delete": {}}, ""},
+		{"same key in list and detail", map[string]KeyList{"board": {"z"}, "history": {"z"}}, ""},
+		{"unknown action", map[string]KeyList{"explode": {"x"}}, `unknown action "explode"`},
+		{"conflict with global", map[string]KeyList{"history": {"e"}}, `"e" is bound to both edit and history`},
+		{"conflict in scope", map[string]KeyList{"board": {"space"}}, `"space" is bound to both toggle_select and board`},
+		{"quit on a view key", map[string]KeyList{"quit": {"w"}}, `"w" is bound to quit but used by the export view`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeymap(tt.overrides)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKeymapFromYAML(t *testing.T) {
+	var config Config
+	input := "keybindings:\n  quit: ctrl+q\n  up: [up, ctrl+p]\n  delete: []\n"
+	if err := yaml.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, err := newKeymap(config.Keybindings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys.action(globalScope, "ctrl+q") != actionQuit || keys.action(globalScope, "q") != "" {
+		t.Error("expected quit to move from q to ctrl+q")
+	}
+	if keys.label(actionUp) != "↑, ctrl+p" {
+		t.Errorf("unexpected up label %q", keys.label(actionUp))
+	}
+	if keys.action(globalScope, "d") != "" || len(keys.keys(actionDelete)) != 0 {
+		t.Error("expected delete to be unbound")
+	}
+	if hints := keys.hints(actionDelete, actionQuit); hints != "ctrl+q: quit" {
+		t.Errorf("expected unbound actions to be left out of hints, got %q", hints)
+	}
+}
+
+func TestRemappedKeysDispatch(t *testing.T) {
+	keys, err := newKeymap(map[string]KeyList{"quit": {"ctrl+q"}, "delete": {"D"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := []WorkItem{createTestWorkItem(1, "One", nil)}
+	m := model{
+		state:       listView,
+		keys:        keys,
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		sprintLists: map[sprintTab]*WorkItemList{currentSprint: createTestList(tasks)},
+		batch:       BatchState{selectedItems: map[int]WorkItem{}},
+	}
+
+	_, cmd, handled := m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if handled || cmd != nil {
+		t.Error("expected q to no longer quit")
+	}
+	_, cmd, _ = m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	if cmd == nil {
+		t.Error("expected ctrl+q to quit")
+	}
+	_, cmd, _ = m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Error("expected ctrl+c to always quit")
+	}
+
+	// Views with their own fixed keys take quit from the keymap too
+	quits := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+	views := map[string]func(model, tea.KeyMsg) (model, tea.Cmd){
+		"attachments":    model.handleAttachmentsView,
+		"batch summary":  model.handleBatchSummaryView,
+		"board":          model.handleBoardView,
+		"burndown":       model.handleBurndownView,
+		"export":         model.handleExportView,
+		"history":        model.handleHistoryView,
+		"sprint browser": model.handleSprintBrowserView,
+		"yank":           model.handleYankView,
+	}
+	for name, handle := range views {
+		if _, cmd := handle(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); quits(cmd) {
+			t.Errorf("expected q to no longer quit from the %s view", name)
+		}
+		if _, cmd := handle(m, tea.KeyMsg{Type: tea.KeyCtrlQ}); !quits(cmd) {
+			t.Errorf("expected ctrl+q to quit from the %s view", name)
+		}
+	}
+
+	next, _, _ := m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if next.state == deleteConfirmView {
+		t.Error("expected d to no longer delete")
+	}
+	next, _, _ = m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if next.state != deleteConfirmView || next.delete.itemID != 1 {
+		t.Errorf("expected D to delete, got state %v", next.state)
+	}
+
+	if footer := m.renderListView(); !strings.Contains(footer, "D: delete") || !strings.Contains(footer, "ctrl+q: quit") {
+		t.Errorf("expected footer to show the remapped keys, got:\n%s", footer)
+	}
+	m = m.prepareHelpViewport()
+	if help := m.viewport.View(); !strings.Contains(help, "ctrl+q") {
+		t.Errorf("expected help to show the remapped keys, got:\n%s", help)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listColumn is a column shown beside the tree title in the list view
+type listColumn struct {
+	width    int // Preferred width; values are truncated to it
+	minWidth int // Width the column may shrink to on narrow terminals
+	value    func(item *WorkItem) string
+}
+
+// listColumns maps the column names usable in list_columns to how they render
+var listColumns = map[string]listColumn{
+	"id":          {width: 7, minWidth: 5, value: func(item *WorkItem) string { return fmt.Sprintf("#%d", item.ID) }},
+	"type":        {width: 12, minWidth: 4, value: func(item *WorkItem) string { return item.WorkItemType }},
+	"state":       {width: 12, minWidth: 6, value: func(item *WorkItem) string { return item.State }},
+	"assigned_to": {width: 16, minWidth: 6, value: func(item *WorkItem) string { return item.AssignedTo }},
+	"priority": {width: 2, minWidth: 2, value: func(item *WorkItem) string {
+		if item.Priority == 0 {
+			return ""
+		}
+		return "P" + strconv.Itoa(item.Priority)
+	}},
+	"tags": {width: 18, minWidth: 6, value: func(item *WorkItem) string { return item.Tags }},
+	"remaining_work": {width: 5, minWidth: 5, value: func(item *WorkItem) string {
+		if item.RemainingWork == 0 {
+			return ""
+		}
+		return formatChartValue(item.RemainingWork) + "h"
+	}},
+	"iteration": {width: 14, minWidth: 6, value: func(item *WorkItem) string {
+		parts := strings.Split(item.IterationPath, "\\")
+		return parts[len(parts)-1]
+	}},
+	"changed": {width: 13, minWidth: 8, value: func(item *WorkItem) string {
+		return strings.Trim(getRelativeTime(item.ChangedDate), "()")
+	}},
+}
+
+// defaultListColumns keeps the classic layout: the state after the title
+var defaultListColumns = []string{"state"}
+
+// listColumnModes are the keys of list_columns, one per app mode
+var listColumnModes = map[string]appMode{
+	"sprint":  sprintMode,
+	"backlog": backlogMode,
+}
+
+// minTitleWidth is the title width kept before columns shrink or are dropped
+const minTitleWidth = 20
+
+// validateListColumns returns an error naming the first unknown mode or column
+func validateListColumns(columnsByMode map[string][]string) error {
+	modes := make([]string, 0, len(columnsByMode))
+	for mode := range columnsByMode {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+
+	for _, mode := range modes {
+		if _, ok := listColumnModes[mode]; !ok {
+			return fmt.Errorf("unknown list_columns mode %q (use sprint or backlog)", mode)
+		}
+		for _, column := range columnsByMode[mode] {
+			if _, ok := listColumns[column]; !ok {
+				return fmt.Errorf("unknown list column %q in %s", column, mode)
+			}
+		}
+	}
+	return nil
+}
+
+// fittedColumn is a list column with the width it gets on screen
+type fittedColumn struct {
+	name  string
+	width int
+}
+
+// fitListColumns sizes the columns to leave at least minTitleWidth of the available width
+// for the title. Columns shrink towards their minimum from the last one backwards; when that
+// isn't enough, the last columns are dropped. An available width of 0 (unknown) keeps the preferred widths.
+func fitListColumns(names []string, available int) []fittedColumn {
+	columns := make([]fittedColumn, 0, len(names))
+	for _, name := range names {
+		if column, ok := listColumns[name]; ok {
+			columns = append(columns, fittedColumn{name: name, width: column.width})
+		}
+	}
+	if available <= 0 {
+		return columns
+	}
+
+	for len(columns) > 0 {
+		deficit, shrinkable := minTitleWidth-available, 0
+		for _, column := range columns {
+			deficit += column.width + 1
+			shrinkable += column.width - listColumns[column.name].minWidth
+		}
+		if deficit <= shrinkable {
+			for i := len(columns) - 1; i >= 0 && deficit > 0; i-- {
+				shrink := min(deficit, columns[i].width-listColumns[columns[i].name].minWidth)
+				columns[i].width -= shrink
+				deficit -= shrink
+			}
+			return columns
+		}
+		columns = columns[:len(columns)-1]
+	}
+	return columns
+}
+
+// padText truncates text to width and pads it with spaces to exactly width
+func padText(text string, width int) string {
+	text = truncateText(text, width)
+	if pad := width - len([]rune(text)); pad > 0 {
+		text += strings.Repeat(" ", pad)
+	}
+	return text
+}
+
+// listColumnNames returns the columns configured for the current mode
+func (m model) listColumnNames() []string {
+	if m.config != nil {
+		for mode, columnMode := range listColumnModes {
+			if columnMode == m.currentMode {
+				if columns, ok := m.config.ListColumns[mode]; ok {
+					return columns
+				}
+			}
+		}
+	}
+	return defaultListColumns
+}
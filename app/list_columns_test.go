@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFitListColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   []string
+		available int
+		want      []fittedColumn
+	}{
+		{"unknown width keeps preferred widths", []string{"id", "state"}, 0, []fittedColumn{{"id", 7}, {"state", 12}}},
+		{"wide terminal", []string{"id", "state"}, 100, []fittedColumn{{"id", 7}, {"state", 12}}},
+		{"last column shrinks first", []string{"state", "assigned_to"}, 45, []fittedColumn{{"state", 12}, {"assigned_to", 11}}},
+		{"shrinks to minimum then drops", []string{"state", "assigned_to", "tags"}, 40, []fittedColumn{{"state", 12}, {"assigned_to", 6}}},
+		{"nothing fits", []string{"state"}, 20, []fittedColumn{}},
+		{"unknown column skipped", []string{"bogus", "id"}, 0, []fittedColumn{{"id", 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitListColumns(tt.columns, tt.available)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fitListColumns() = %v, want %v", got, tt.want)
+			}
+			if tt.available > 0 {
+				used := 0
+				for _, column := range got {
+					used += column.width + 1
+				}
+				if len(got) > 0 && tt.available-used < minTitleWidth {
+					t.Errorf("title left with %d columns, want at least %d", tt.available-used, minTitleWidth)
+				}
+			}
+		})
+	}
+}
+
+func TestPadText(t *testing.T) {
+	if got := padText("Active", 8); got != "Active  " {
+		t.Errorf("expected padding, got %q", got)
+	}
+	if got := padText("In Progress", 6); got != "In Pr…" {
+		t.Errorf("expected truncation, got %q", got)
+	}
+}
+
+func TestValidateListColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns map[string][]string
+		wantErr string
+	}{
+		{"empty", nil, ""},
+		{"valid", map[string][]string{"sprint": {"id", "state", "changed"}, "backlog": {"iteration"}}, ""},
+		{"unknown mode", map[string][]string{"board": {"id"}}, `unknown list_columns mode "board"`},
+		{"unknown column", map[string][]string{"sprint": {"id", "owner"}}, `unknown list column "owner" in sprint`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListColumns(tt.columns)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRenderTreeItemListColumns(t *testing.T) {
+	item := &WorkItem{ID: 42, Title: "A rather long work item title that needs truncating", State: "Active", AssignedTo: "Dana Scully", Priority: 1}
+	m := model{
+		config:          &Config{ListColumns: map[string][]string{"sprint": {"id", "assigned_to", "priority", "state"}}},
+		currentMode:     sprintMode,
+		stateCategories: map[string]string{"Active": "InProgress"},
+		styles:          NewStyles(),
+	}
+
+	if got := m.listColumnNames(); !reflect.DeepEqual(got, []string{"id", "assigned_to", "priority", "state"}) {
+		t.Errorf("unexpected sprint columns %v", got)
+	}
+	m.currentMode = backlogMode
+	if got := m.listColumnNames(); !reflect.DeepEqual(got, defaultListColumns) {
+		t.Errorf("expected default columns for backlog, got %v", got)
+	}
+	m.currentMode = sprintMode
+
+	// Without a known width, values follow the title
+	line := m.renderTreeItemList(TreeItem{WorkItem: item}, false, false)
+	if !strings.Contains(line, item.Title+" #42 Dana Scully P1 Active") {
+		t.Errorf("unexpected line without width: %q", line)
+	}
+
+	for _, width := range []int{120, 60, 30} {
+		m.ui.width = width
+		line := m.renderTreeItemList(TreeItem{WorkItem: item}, width == 60, false)
+		if got := lipgloss.Width(line); got >= width {
+			t.Errorf("width %d: line is %d wide: %q", width, got, line)
+		}
+		if width == 120 && (!strings.Contains(line, "Dana Scully") || !strings.Contains(line, "#42")) {
+			t.Errorf("width %d: expected all columns, got %q", width, line)
+		}
+		if width == 30 && strings.Contains(line, "#42") {
+			t.Errorf("width %d: expected columns to be dropped, got %q", width, line)
+		}
+	}
+}
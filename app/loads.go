@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// loadScope is the context shared by a group of loads. Superseding the scope cancels the
+// loads still running, so results the user has navigated away from are never applied.
+type loadScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns the context new loads of the scope run under, starting one if needed
+func (s *loadScope) context() context.Context {
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	return s.ctx
+}
+
+// supersede cancels the loads started so far; later loads get a fresh context
+func (s *loadScope) supersede() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	*s = loadScope{}
+}
+
+// isCancelled reports whether err comes from a load that was superseded
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// closeViewLoads cancels the loads of the view being closed, such as the states of a
+// state picker or the history of a burndown chart
+func (m *model) closeViewLoads() {
+	m.viewLoads.supersede()
+	m.loading = false
+}
+
+// cancelWork cancels the batch or import in flight. Items already changed stay changed;
+// the rest are reported as cancelled.
+func (m *model) cancelWork() {
+	m.work.supersede()
+	m.statusMessage = "Cancelling..."
+}
+
+// supersedeLoads cancels the list loads in flight once the user has moved on from the
+// lists they were for. Lists whose load was cancelled stay unattempted and load again
+// when shown.
+func (m *model) supersedeLoads() {
+	m.listLoads.supersede()
+	m.cancelFilterDetails()
+	m.loading = false
+	m.loadingMore = false
+	m.initialLoading = 0
+}
@@ -0,0 +1,813 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// LocalBackend is the Backend of a directory of work item files, for personal and offline
+// task lists. Files in the directory itself are the backlog and each folder is a sprint,
+// dated by an optional sprint.yaml in the folder. An item is a Markdown file whose front
+// matter holds the fields and whose body is the description, or a YAML file with a
+// description field.
+//
+// Revision history and attachments are kept in the .hippo folder. Edits made to the files
+// outside Hippo are read again by the watcher started with Changes.
+//
+// Work item operations are in local_workitems.go
+type LocalBackend struct {
+	dir          string
+	user         string
+	states       []LocalState // States items can be in, in process order
+	settleDelay  time.Duration
+	pollInterval time.Duration
+
+	mu        sync.Mutex                 // Guards the state below; commands and the watcher run concurrently
+	items     map[int]*localItem         // Items keyed by ID
+	sprints   []Sprint                   // Sprint folders in date order
+	revisions map[int][]WorkItemRevision // Revision history keyed by item ID
+	nextID    int                        // ID of the next new item
+	stamp     string                     // Fingerprint of the files as last read or written
+	loadErr   error                      // Why the files could not be read again, if they couldn't
+
+	watch   sync.Once
+	changes chan struct{}
+}
+
+// Compile-time check that LocalBackend implements Backend
+var _ Backend = (*LocalBackend)(nil)
+
+// localItem is a work item and the file it is stored in
+type localItem struct {
+	WorkItem
+	fields map[string]string // Other fields by reference name, such as System.Reason
+	path   string
+}
+
+// localItemFile is the front matter of a Markdown item, or the whole of a YAML item
+type localItemFile struct {
+	ID            int               `yaml:"id"`
+	Title         string            `yaml:"title"`
+	Type          string            `yaml:"type,omitempty"`
+	State         string            `yaml:"state,omitempty"`
+	AssignedTo    string            `yaml:"assigned_to,omitempty"`
+	Tags          localTags         `yaml:"tags,omitempty,flow"`
+	Priority      int               `yaml:"priority,omitempty"`
+	RemainingWork float64           `yaml:"remaining_work,omitempty"`
+	Parent        int               `yaml:"parent,omitempty"`
+	Created       string            `yaml:"created,omitempty"`
+	Changed       string            `yaml:"changed,omitempty"`
+	Description   string            `yaml:"description,omitempty"` // YAML items only; Markdown items keep it in the body
+	Comments      string            `yaml:"comments,omitempty"`
+	Attachments   []localAttachment `yaml:"attachments,omitempty"`
+	Fields        map[string]string `yaml:"fields,omitempty"` // Other fields by reference name
+}
+
+// localAttachment is an attachment listed in an item file. The content is in .hippo/attachments.
+type localAttachment struct {
+	ID      string `yaml:"id"`
+	Name    string `yaml:"name"`
+	Size    int64  `yaml:"size,omitempty"`
+	Added   string `yaml:"added,omitempty"`
+	Comment string `yaml:"comment,omitempty"`
+}
+
+// localTags are the tags of an item, written as a list or as a semicolon-separated string
+type localTags []string
+
+func (t *localTags) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = splitTags(node.Value)
+		return nil
+	}
+	var tags []string
+	if err := node.Decode(&tags); err != nil {
+		return err
+	}
+	*t = tags
+	return nil
+}
+
+// localSprintFile is the sprint.yaml of a sprint folder
+type localSprintFile struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// localRevisionLine is one line of the revision log
+type localRevisionLine struct {
+	ID       int              `json:"id"`
+	Revision WorkItemRevision `json:"revision"`
+}
+
+const (
+	localDataDir        = ".hippo"               // Folder of the files Hippo keeps for itself
+	localHistoryFile    = "history.jsonl"        // Revision log in the data folder, one revision per line
+	localAttachmentsDir = "attachments"          // Attachment content in the data folder
+	localSprintFileName = "sprint.yaml"          // Dates of a sprint folder
+	localSettleDelay    = 100 * time.Millisecond // How long the watcher lets a burst of file events settle
+	localPollInterval   = 2 * time.Second        // How often the watcher looks for edits without file events
+	localDateLayout     = "2006-01-02T15:04:05"
+)
+
+// localIDPrefix matches the ID at the start of an item file name, as in 12-checkout-flow.md
+var localIDPrefix = regexp.MustCompile(`^(\d+)-`)
+
+// NewLocalBackend reads the work items in the directory of the config, creating it if needed
+func NewLocalBackend(config *Config) (*LocalBackend, error) {
+	if config.Local.Path == "" {
+		return nil, fmt.Errorf("local.path is not set")
+	}
+	user := config.Local.User
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+	if user == "" {
+		user = "me"
+	}
+	return newLocalBackend(expandHome(config.Local.Path), user, config.Local.States)
+}
+
+// defaultLocalStates are the states of the demo process, used unless the config lists others
+func defaultLocalStates() []LocalState {
+	states := make([]LocalState, len(dummyStates))
+	for i, name := range dummyStates {
+		states[i] = LocalState{Name: name, Category: dummyStateCategories[name]}
+	}
+	return states
+}
+
+// newLocalBackend reads the work items in dir on behalf of user. Items use the default
+// states unless states are given.
+func newLocalBackend(dir, user string, states []LocalState) (*LocalBackend, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create work item directory: %w", err)
+	}
+	db := &LocalBackend{
+		dir:          dir,
+		user:         user,
+		states:       states,
+		settleDelay:  localSettleDelay,
+		pollInterval: localPollInterval,
+		changes:      make(chan struct{}, 1),
+	}
+	if len(db.states) == 0 {
+		db.states = defaultLocalStates()
+	}
+	if err := db.load(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// =============================================================================
+// READING AND WRITING FILES
+// =============================================================================
+
+// isLocalItemFile reports whether a file name is a work item file
+func isLocalItemFile(name string) bool {
+	if name == localSprintFileName || strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// localItemPaths returns the item files of the directory by sprint folder, "" being the backlog
+func (db *LocalBackend) localItemPaths() (map[string][]string, error) {
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read work item directory: %w", err)
+	}
+
+	paths := make(map[string][]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() {
+			if isLocalItemFile(name) {
+				paths[""] = append(paths[""], filepath.Join(db.dir, name))
+			}
+			continue
+		}
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		paths[name] = []string{}
+		files, err := os.ReadDir(filepath.Join(db.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sprint %s: %w", name, err)
+		}
+		for _, file := range files {
+			if !file.IsDir() && isLocalItemFile(file.Name()) {
+				paths[name] = append(paths[name], filepath.Join(db.dir, name, file.Name()))
+			}
+		}
+	}
+	return paths, nil
+}
+
+// localStamp fingerprints the files of the directory by name, size and modification time
+func localStamp(dir string) (string, error) {
+	var stamp strings.Builder
+	add := func(path string) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&stamp, "%s|%d|%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if !entry.IsDir() {
+			if isLocalItemFile(name) {
+				if err := add(path); err != nil {
+					return "", err
+				}
+			}
+			continue
+		}
+
+		stamp.WriteString(path + "/\n")
+		files, err := os.ReadDir(path)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if !file.IsDir() && (isLocalItemFile(file.Name()) || file.Name() == localSprintFileName) {
+				if err := add(filepath.Join(path, file.Name())); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	return stamp.String(), nil
+}
+
+// load reads every sprint folder, item file and the revision log. Items without an ID, or
+// with the ID of another item, are given a new one in their file. Each item whose fields
+// differ from its latest revision gets a revision, so edits made outside Hippo show in the history.
+func (db *LocalBackend) load() error {
+	paths, err := db.localItemPaths()
+	if err != nil {
+		return err
+	}
+
+	revisions, err := db.readRevisions()
+	if err != nil {
+		return err
+	}
+
+	var sprints []Sprint
+	items := make(map[int]*localItem)
+	var unnumbered []*localItem
+	nextID := 1
+	for id := range revisions {
+		nextID = max(nextID, id+1)
+	}
+
+	folders := make([]string, 0, len(paths))
+	for folder := range paths {
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+
+	for _, folder := range folders {
+		if folder != "" {
+			sprint, err := db.readSprint(folder)
+			if err != nil {
+				return err
+			}
+			sprints = append(sprints, sprint)
+		}
+
+		for _, path := range paths[folder] {
+			item, err := db.readItem(path, folder)
+			if err != nil {
+				return err
+			}
+			if item.ID == 0 || items[item.ID] != nil {
+				unnumbered = append(unnumbered, item)
+				continue
+			}
+			items[item.ID] = item
+			nextID = max(nextID, item.ID+1)
+		}
+	}
+
+	for _, item := range unnumbered {
+		item.ID = nextID
+		nextID++
+		if err := writeLocalItem(item); err != nil {
+			return err
+		}
+		items[item.ID] = item
+	}
+
+	sortLocalSprints(sprints)
+
+	db.items = items
+	db.sprints = sprints
+	db.revisions = revisions
+	db.nextID = nextID
+	for _, item := range items {
+		if err := db.recordRevision(item); err != nil {
+			return err
+		}
+	}
+	db.stamp, _ = localStamp(db.dir)
+	return nil
+}
+
+// readSprint returns the sprint of a folder, with the dates of its sprint.yaml if it has one
+func (db *LocalBackend) readSprint(folder string) (Sprint, error) {
+	sprint := Sprint{Name: folder, Path: folder}
+
+	content, err := os.ReadFile(filepath.Join(db.dir, folder, localSprintFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return sprint, nil
+	}
+	if err != nil {
+		return sprint, fmt.Errorf("failed to read dates of sprint %s: %w", folder, err)
+	}
+
+	var dates localSprintFile
+	if err := yaml.Unmarshal(content, &dates); err != nil {
+		return sprint, fmt.Errorf("failed to parse %s of sprint %s: %w", localSprintFileName, folder, err)
+	}
+	sprint.StartDate = dateOnly(localDate(dates.Start))
+	sprint.EndDate = dateOnly(localDate(dates.End))
+	return sprint, nil
+}
+
+// sortLocalSprints orders sprints by end date, with undated sprints last by name
+func sortLocalSprints(sprints []Sprint) {
+	sort.SliceStable(sprints, func(i, j int) bool {
+		a, b := sprints[i], sprints[j]
+		if (a.EndDate == "") != (b.EndDate == "") {
+			return a.EndDate != ""
+		}
+		if a.EndDate != b.EndDate {
+			return a.EndDate < b.EndDate
+		}
+		return a.Name < b.Name
+	})
+}
+
+// localDate normalizes a date or timestamp written by hand to the work item date format.
+// Values in no known format are returned unchanged.
+func localDate(value string) string {
+	for _, layout := range []string{localDateLayout, time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(localDateLayout)
+		}
+	}
+	return value
+}
+
+// splitFrontMatter separates the YAML front matter of a Markdown file from its body.
+// A file without front matter is all body.
+func splitFrontMatter(content []byte) (frontMatter, body []byte) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil, content
+	}
+	rest := content[len("---\n"):]
+	if bytes.HasPrefix(rest, []byte("---\n")) {
+		return nil, rest[len("---\n"):]
+	}
+	end := bytes.Index(rest, []byte("\n---\n"))
+	if end == -1 {
+		if bytes.HasSuffix(rest, []byte("\n---")) {
+			return rest[:len(rest)-len("---")], nil
+		}
+		return nil, content
+	}
+	return rest[:end+1], rest[end+len("\n---\n"):]
+}
+
+// isMarkdown reports whether an item file is Markdown rather than YAML
+func isMarkdown(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+// readItem reads the item file at path in a sprint folder ("" for the backlog)
+func (db *LocalBackend) readItem(path, sprint string) (*localItem, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file localItemFile
+	if isMarkdown(path) {
+		frontMatter, body := splitFrontMatter(content)
+		if err := yaml.Unmarshal(frontMatter, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse front matter of %s: %w", path, err)
+		}
+		file.Description = strings.TrimSpace(string(body))
+	} else if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	base := filepath.Base(path)
+	if file.ID == 0 {
+		if match := localIDPrefix.FindStringSubmatch(base); match != nil {
+			file.ID, _ = strconv.Atoi(match[1])
+		}
+	}
+	if file.Title == "" {
+		file.Title = strings.TrimSuffix(localIDPrefix.ReplaceAllString(base, ""), filepath.Ext(base))
+	}
+	if file.Changed == "" {
+		file.Changed = info.ModTime().Format(localDateLayout)
+	}
+	if file.Created == "" {
+		file.Created = file.Changed
+	}
+
+	item := &localItem{path: path, fields: file.Fields, WorkItem: WorkItem{
+		ID:            file.ID,
+		Title:         file.Title,
+		State:         file.State,
+		AssignedTo:    file.AssignedTo,
+		WorkItemType:  file.Type,
+		Description:   file.Description,
+		Tags:          strings.Join(file.Tags, "; "),
+		Priority:      file.Priority,
+		RemainingWork: file.RemainingWork,
+		CreatedDate:   localDate(file.Created),
+		ChangedDate:   localDate(file.Changed),
+		IterationPath: sprint,
+		AreaPath:      filepath.Base(db.dir),
+		Comments:      strings.TrimSpace(file.Comments),
+	}}
+	if item.State == "" {
+		item.State = db.states[0].Name
+	}
+	if item.WorkItemType == "" {
+		item.WorkItemType = "Task"
+	}
+	if file.Parent != 0 {
+		parent := file.Parent
+		item.ParentID = &parent
+	}
+	for _, attachment := range file.Attachments {
+		item.Attachments = append(item.Attachments, Attachment{
+			ID:        attachment.ID,
+			Name:      attachment.Name,
+			URL:       "file://" + filepath.ToSlash(db.attachmentPath(attachment.ID)),
+			Size:      attachment.Size,
+			AddedDate: attachment.Added,
+			Comment:   attachment.Comment,
+		})
+	}
+	return item, nil
+}
+
+// attachmentPath returns where the content of an attachment is stored
+func (db *LocalBackend) attachmentPath(id string) string {
+	return filepath.Join(db.dir, localDataDir, localAttachmentsDir, id)
+}
+
+// writeLocalItem writes an item to its file, in the format of the file's extension.
+// The file is replaced in one step so the watcher never reads half of it.
+func writeLocalItem(item *localItem) error {
+	file := localItemFile{
+		ID:            item.ID,
+		Title:         item.Title,
+		Type:          item.WorkItemType,
+		State:         item.State,
+		AssignedTo:    item.AssignedTo,
+		Tags:          splitTags(item.Tags),
+		Priority:      item.Priority,
+		RemainingWork: item.RemainingWork,
+		Created:       item.CreatedDate,
+		Changed:       item.ChangedDate,
+		Description:   item.Description,
+		Comments:      item.Comments,
+		Fields:        item.fields,
+	}
+	if item.ParentID != nil {
+		file.Parent = *item.ParentID
+	}
+	for _, attachment := range item.Attachments {
+		file.Attachments = append(file.Attachments, localAttachment{
+			ID:      attachment.ID,
+			Name:    attachment.Name,
+			Size:    attachment.Size,
+			Added:   attachment.AddedDate,
+			Comment: attachment.Comment,
+		})
+	}
+
+	var content bytes.Buffer
+	if isMarkdown(item.path) {
+		file.Description = ""
+		frontMatter, err := marshalLocalYAML(file)
+		if err != nil {
+			return fmt.Errorf("failed to encode work item %d: %w", item.ID, err)
+		}
+		content.WriteString("---\n")
+		content.Write(frontMatter)
+		content.WriteString("---\n")
+		if item.Description != "" {
+			content.WriteString("\n" + strings.TrimSpace(item.Description) + "\n")
+		}
+	} else {
+		data, err := marshalLocalYAML(file)
+		if err != nil {
+			return fmt.Errorf("failed to encode work item %d: %w", item.ID, err)
+		}
+		content.Write(data)
+	}
+
+	return writeFileAtomic(item.path, content.Bytes())
+}
+
+// marshalLocalYAML encodes an item file with the two-space indent people write by hand
+func marshalLocalYAML(file localItemFile) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFileAtomic writes a file through a temporary file renamed over it
+func writeFileAtomic(path string, content []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), ".hippo-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// saved records the files as written by Hippo, so the watcher doesn't read them again
+func (db *LocalBackend) saved() {
+	db.stamp, _ = localStamp(db.dir)
+}
+
+// =============================================================================
+// REVISION LOG
+// =============================================================================
+
+// historyPath returns the path of the revision log
+func (db *LocalBackend) historyPath() string {
+	return filepath.Join(db.dir, localDataDir, localHistoryFile)
+}
+
+// readRevisions reads the revision log, keyed by item ID
+func (db *LocalBackend) readRevisions() (map[int][]WorkItemRevision, error) {
+	revisions := make(map[int][]WorkItemRevision)
+
+	file, err := os.Open(db.historyPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return revisions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line localRevisionLine
+		// A line cut short by a crash is skipped rather than losing the whole history
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		revisions[line.ID] = append(revisions[line.ID], line.Revision)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read revision history: %w", err)
+	}
+	return revisions, nil
+}
+
+// recordRevision appends a revision of the item to the log, unless its tracked fields are
+// the same as in its latest revision
+func (db *LocalBackend) recordRevision(item *localItem) error {
+	revision := WorkItemRevision{
+		ChangedBy:     db.user,
+		ChangedDate:   item.ChangedDate,
+		State:         item.State,
+		IterationPath: item.IterationPath,
+		RemainingWork: item.RemainingWork,
+		Title:         item.Title,
+		AssignedTo:    item.AssignedTo,
+		Priority:      item.Priority,
+		Tags:          item.Tags,
+	}
+
+	history := db.revisions[item.ID]
+	if len(history) > 0 && sameTrackedFields(history[len(history)-1], revision) {
+		return nil
+	}
+	revision.Rev = len(history) + 1
+
+	line, err := json.Marshal(localRevisionLine{ID: item.ID, Revision: revision})
+	if err != nil {
+		return fmt.Errorf("failed to encode revision: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(db.historyPath()), 0755); err != nil {
+		return fmt.Errorf("failed to write revision history: %w", err)
+	}
+	file, err := os.OpenFile(db.historyPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write revision history: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write revision history: %w", err)
+	}
+
+	db.revisions[item.ID] = append(history, revision)
+	return nil
+}
+
+// sameTrackedFields reports whether two revisions differ in nothing but number, author and date
+func sameTrackedFields(a, b WorkItemRevision) bool {
+	a.Rev, a.ChangedBy, a.ChangedDate = 0, "", ""
+	b.Rev, b.ChangedBy, b.ChangedDate = 0, "", ""
+	return a == b
+}
+
+// =============================================================================
+// WATCHING FOR EDITS
+// =============================================================================
+
+// Changes returns a channel that receives a value after the files were edited outside Hippo
+// and read again. The first call starts watching the directory and its sprint folders for
+// file events; where the system has none to give, it polls the files instead.
+func (db *LocalBackend) Changes() <-chan struct{} {
+	db.watch.Do(func() {
+		watcher, err := db.newWatcher()
+		if err != nil {
+			go db.poll()
+			return
+		}
+		go db.watchEvents(watcher)
+	})
+	return db.changes
+}
+
+// newWatcher watches the directory and every sprint folder in it
+func (db *LocalBackend) newWatcher() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(db.dir)
+	if err == nil {
+		err = watcher.Add(db.dir)
+	}
+	for _, entry := range entries {
+		if err == nil && entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			err = watcher.Add(filepath.Join(db.dir, entry.Name()))
+		}
+	}
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// watchEvents reads the files again once their events settled, so an editor saving through a
+// temporary file causes one reload, until the program exits
+func (db *LocalBackend) watchEvents(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+	var settled <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// Hidden files are editor swap files and Hippo's own data folder
+			if strings.HasPrefix(filepath.Base(event.Name), ".") {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watcher.Add(event.Name)
+				}
+			}
+			settled = time.After(db.settleDelay)
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			// Events were dropped; comparing the files tells whether any mattered
+			settled = time.After(db.settleDelay)
+		case <-settled:
+			settled = nil
+			db.notifyIfChanged()
+		}
+	}
+}
+
+// poll looks for edits every pollInterval until the program exits. Each look stats every item
+// file, so it only runs where file events are not available.
+func (db *LocalBackend) poll() {
+	ticker := time.NewTicker(db.pollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		db.notifyIfChanged()
+	}
+}
+
+// notifyIfChanged reads the files again if they changed and reports it on the changes channel
+func (db *LocalBackend) notifyIfChanged() {
+	if db.reloadIfChanged() {
+		select {
+		case db.changes <- struct{}{}:
+		default: // A change is already waiting to be picked up
+		}
+	}
+}
+
+// reloadIfChanged reads the files again if they changed since they were last read or written.
+// If they can't be read, the previous items stay and queries report the error until the
+// files are fixed.
+func (db *LocalBackend) reloadIfChanged() bool {
+	stamp, err := localStamp(db.dir)
+	if err != nil {
+		return false
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if stamp == db.stamp {
+		return false
+	}
+	db.loadErr = db.load()
+	if db.loadErr != nil {
+		db.stamp = stamp
+	}
+	return true
+}
+
+// localItemURL returns the file URL of the item file named after the ID, or of the directory
+// if there is none
+func localItemURL(dir string, workItemID int) string {
+	dir = expandHome(dir)
+	name := strconv.Itoa(workItemID) + "-*"
+	for _, pattern := range []string{filepath.Join(dir, name), filepath.Join(dir, "*", name)} {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if isLocalItemFile(filepath.Base(match)) {
+				return "file://" + filepath.ToSlash(match)
+			}
+		}
+	}
+	return "file://" + filepath.ToSlash(dir)
+}
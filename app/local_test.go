@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestFile writes a file under dir, creating its folder
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// seedLocalDirectory writes a backlog, a finished, a running and an undated sprint
+func seedLocalDirectory(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+	changed := time.Now().AddDate(0, 0, -1).Format("2006-01-02T15:04:05")
+
+	writeTestFile(t, dir, "Sprint 1/sprint.yaml", "start: "+day(-21)+"\nend: "+day(-8)+"\n")
+	writeTestFile(t, dir, "Sprint 2/sprint.yaml", "start: "+day(-7)+"\nend: "+day(6)+"\n")
+	writeTestFile(t, dir, "Sprint 2/7-checkout.md", `---
+id: 7
+title: Checkout flow
+type: User Story
+state: Active
+tags: frontend; p1
+changed: `+changed+`
+---
+
+Everything from the cart
+to the receipt.
+`)
+	writeTestFile(t, dir, "Sprint 2/12-card-form.yaml", "title: Card form\nparent: 7\nchanged: "+changed+"\ndescription: Number, expiry and CVC\n")
+	writeTestFile(t, dir, "Sprint 1/3-done.md", "---\nid: 3\ntitle: Login\nstate: Closed\n---\n")
+	writeTestFile(t, dir, "Someday/.gitkeep", "")
+	writeTestFile(t, dir, "Dark mode.md", "Maybe after launch.\n")
+	writeTestFile(t, dir, "notes.txt", "not a work item")
+	return dir
+}
+
+func TestLocalBackendReadsDirectory(t *testing.T) {
+	dir := seedLocalDirectory(t)
+	db, err := newLocalBackend(dir, "ana", nil)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	ctx := context.Background()
+
+	prev, curr, next, err := db.GetCurrentAndAdjacentSprints(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentAndAdjacentSprints() error: %v", err)
+	}
+	if prev == nil || curr == nil || next == nil || prev.Path != "Sprint 1" || curr.Path != "Sprint 2" || next.Path != "Someday" {
+		t.Fatalf("sprints = %v, %v, %v; want Sprint 1, Sprint 2 and the undated Someday", prev, curr, next)
+	}
+
+	ids, err := db.GetSprintWorkItemIDs(ctx, "Sprint 2")
+	if err != nil {
+		t.Fatalf("GetSprintWorkItemIDs() error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 12 {
+		t.Fatalf("sprint IDs = %v, want [7 12]", ids)
+	}
+
+	rows, _ := db.GetWorkItemsByIDs(ctx, ids)
+	if story := rows[0]; story.Title != "Checkout flow" || story.Tags != "frontend; p1" || story.State != "Active" || !story.Partial || story.Description != "" {
+		t.Errorf("story row = %+v", story)
+	}
+	if task := rows[1]; task.ParentID == nil || *task.ParentID != 7 || task.State != "New" || task.WorkItemType != "Task" {
+		t.Errorf("task row = %+v, want a New task under #7", task)
+	}
+
+	story, _ := db.GetWorkItemByID(ctx, 7)
+	if story.Description != "Everything from the cart\nto the receipt." {
+		t.Errorf("description = %q, want the Markdown body", story.Description)
+	}
+
+	// The backlog item had no ID, so it got the next free one written to its file
+	backlog, err := db.GetRecentBacklogItemIDs(ctx)
+	if err != nil || len(backlog) != 1 || backlog[0] != 13 {
+		t.Fatalf("backlog = %v (err: %v), want the new item #13", backlog, err)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "Dark mode.md"))
+	if !strings.Contains(string(content), "id: 13") || !strings.Contains(string(content), "title: Dark mode") || !strings.HasSuffix(string(content), "\nMaybe after launch.\n") {
+		t.Errorf("backlog file = %q, want the ID and title added in front matter", content)
+	}
+}
+
+func TestLocalBackendPersistsChanges(t *testing.T) {
+	dir := seedLocalDirectory(t)
+	db, err := newLocalBackend(dir, "ana", nil)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	ctx := context.Background()
+
+	updates := map[string]interface{}{"state": "Closed", "title": "Card form v2", "tags": "ui;", "comment": "Shipped", "priority": 1}
+	if err := db.UpdateWorkItem(ctx, 12, updates); err != nil {
+		t.Fatalf("UpdateWorkItem() error: %v", err)
+	}
+	if err := db.UpdateWorkItem(ctx, 12, map[string]interface{}{"state": "Done"}); err == nil {
+		t.Error("an unknown state was accepted")
+	}
+	if err := db.UpdateWorkItem(ctx, 12, map[string]interface{}{"titel": "Typo"}); err == nil {
+		t.Error("an unknown field was accepted")
+	}
+	if err := db.UpdateWorkItem(ctx, 3, stateUpdates("Removed", map[string]string{"System.Reason": "Obsolete"})); err != nil {
+		t.Fatalf("UpdateWorkItem() with a reason error: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "Sprint 1", "3-done.md")); !strings.Contains(string(content), "fields:\n  System.Reason: Obsolete\n") {
+		t.Errorf("item file = %q, want the reason kept in front matter", content)
+	}
+
+	failed, err := db.UpdateWorkItems(ctx, []int{7, 99}, map[string]interface{}{"iterationPath": "Sprint 3"})
+	if err != nil || len(failed) != 1 || failed[99] == nil {
+		t.Fatalf("UpdateWorkItems() = %v, %v; want only the missing item to fail", failed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Sprint 3", "7-checkout.md")); err != nil {
+		t.Errorf("moved item file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Sprint 2", "7-checkout.md")); !os.IsNotExist(err) {
+		t.Error("item file is still in the old sprint folder")
+	}
+
+	parentID := 7
+	created, err := db.CreateWorkItem(ctx, "Apple Pay", "Task", "Sprint 3", &parentID, "")
+	if err != nil {
+		t.Fatalf("CreateWorkItem() error: %v", err)
+	}
+	if created.ID != 14 || created.AssignedTo != "ana" {
+		t.Errorf("created = %+v, want #14 assigned to the user", created)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Sprint 3", "14-apple-pay.md")); err != nil {
+		t.Errorf("created item file: %v", err)
+	}
+	if err := db.DeleteWorkItem(ctx, 3); err != nil {
+		t.Fatalf("DeleteWorkItem() error: %v", err)
+	}
+
+	// A new backend reads everything back from the files
+	reopened, err := newLocalBackend(dir, "ana", nil)
+	if err != nil {
+		t.Fatalf("reopening error: %v", err)
+	}
+	item, err := reopened.GetWorkItemByID(ctx, 12)
+	if err != nil {
+		t.Fatalf("GetWorkItemByID() error: %v", err)
+	}
+	if item.Title != "Card form v2" || item.State != "Closed" || item.Tags != "ui" || item.Priority != 1 || item.Comments != "Shipped" || item.Description != "Number, expiry and CVC" {
+		t.Errorf("reopened item = %+v", item)
+	}
+	if item, _ := reopened.GetWorkItemByID(ctx, 14); item == nil || item.IterationPath != "Sprint 3" || item.ParentID == nil || *item.ParentID != 7 {
+		t.Errorf("reopened created item = %+v", item)
+	}
+	if _, err := reopened.GetWorkItemByID(ctx, 3); err == nil {
+		t.Error("deleted item is back")
+	}
+	if sprints, _ := reopened.GetAllSprints(ctx); len(sprints) != 4 || sprints[3].Path != "Sprint 3" {
+		t.Errorf("sprints = %v, want the new Sprint 3 folder", sprints)
+	}
+
+	history, err := reopened.GetWorkItemHistory(ctx, 7)
+	if err != nil || len(history) != 2 || !strings.Contains(iterationTimeline(history), "Sprint 3") {
+		t.Errorf("history = %+v (err: %v), want the read and the sprint move", history, err)
+	}
+	revisions, _ := reopened.GetSprintWorkItemRevisions(ctx, "Sprint 2")
+	if len(revisions) != 2 || revisions[3] != nil {
+		t.Errorf("Sprint 2 revisions = %v, want #7 and #12", revisions)
+	}
+
+	attachment, err := reopened.UploadAttachment(ctx, "trace.log", []byte("boom"))
+	if err != nil {
+		t.Fatalf("UploadAttachment() error: %v", err)
+	}
+	if err := reopened.AddAttachmentToWorkItem(ctx, 12, *attachment, "Crash"); err != nil {
+		t.Fatalf("AddAttachmentToWorkItem() error: %v", err)
+	}
+	item, _ = reopened.GetWorkItemByID(ctx, 12)
+	if len(item.Attachments) != 1 || item.Attachments[0].Comment != "Crash" {
+		t.Fatalf("attachments = %+v", item.Attachments)
+	}
+	if content, err := reopened.DownloadAttachment(ctx, item.Attachments[0]); err != nil || string(content) != "boom" {
+		t.Errorf("DownloadAttachment() = %q, %v", content, err)
+	}
+}
+
+func TestLocalBackendConfiguredStates(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "Sprint 1/1-draft.md", "---\ntitle: Draft\n---\n")
+	writeTestFile(t, dir, "Sprint 1/2-shipped.md", "---\ntitle: Shipped\nstate: Done\n---\n")
+	states := []LocalState{{Name: "Todo", Category: "Proposed"}, {Name: "Doing", Category: "InProgress"}, {Name: "Done", Category: "Completed"}}
+	db, err := newLocalBackend(dir, "ana", states)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	ctx := context.Background()
+
+	names, categories, _ := db.GetWorkItemTypeStates(ctx, "Task")
+	if strings.Join(names, ",") != "Todo,Doing,Done" || categories["Done"] != "Completed" {
+		t.Errorf("states = %v %v, want the configured states", names, categories)
+	}
+
+	// Items without a state start in the first one, and Done items are finished
+	ids, _ := db.GetSprintWorkItemIDs(ctx, "Sprint 1")
+	if len(ids) != 1 || db.items[1].State != "Todo" {
+		t.Errorf("sprint IDs = %v, state of #1 = %q; want only the Todo item", ids, db.items[1].State)
+	}
+
+	if err := db.UpdateWorkItem(ctx, 1, map[string]interface{}{"state": "Doing"}); err != nil {
+		t.Errorf("UpdateWorkItem() to a configured state error: %v", err)
+	}
+	if err := db.UpdateWorkItem(ctx, 1, map[string]interface{}{"state": "Closed"}); err == nil || !strings.Contains(err.Error(), "Todo, Doing, Done") {
+		t.Errorf("UpdateWorkItem() to a default state error = %v, want the configured states listed", err)
+	}
+}
+
+// iterationTimeline joins the iteration paths an item moved through
+func iterationTimeline(history []WorkItemUpdate) string {
+	var paths []string
+	for _, update := range history {
+		for _, change := range update.Changes {
+			if change.Field == "Iteration" {
+				paths = append(paths, change.NewValue)
+			}
+		}
+	}
+	return strings.Join(paths, " > ")
+}
+
+func TestLocalBackendWatchesEdits(t *testing.T) {
+	dir := seedLocalDirectory(t)
+	db, err := newLocalBackend(dir, "ana", nil)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	db.settleDelay = 10 * time.Millisecond
+	ctx := context.Background()
+	changes := db.Changes()
+
+	waitForChange := func() {
+		t.Helper()
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no change noticed")
+		}
+	}
+
+	// Hippo's own writes are not reported
+	if err := db.UpdateWorkItemState(ctx, 7, "Closed"); err != nil {
+		t.Fatalf("UpdateWorkItemState() error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-changes:
+		t.Fatal("Hippo's own write was reported as an outside edit")
+	default:
+	}
+
+	writeTestFile(t, dir, "Sprint 2/12-card-form.yaml", "title: Card form\nparent: 7\nstate: Active\n")
+	waitForChange()
+	if item, _ := db.GetWorkItemByID(ctx, 12); item == nil || item.State != "Active" {
+		t.Errorf("item after edit = %+v, want it Active", item)
+	}
+	if history, _ := db.GetWorkItemHistory(ctx, 12); len(history) != 2 {
+		t.Errorf("history = %+v, want the outside edit recorded", history)
+	}
+
+	// A broken file fails the queries until it is fixed
+	writeTestFile(t, dir, "Sprint 2/12-card-form.yaml", "title: [unclosed\n")
+	waitForChange()
+	if _, err := db.GetSprintWorkItemIDs(ctx, "Sprint 2"); err == nil || !strings.Contains(err.Error(), "12-card-form.yaml") {
+		t.Errorf("query error = %v, want the broken file named", err)
+	}
+	writeTestFile(t, dir, "Sprint 2/12-card-form.yaml", "title: Card form\n")
+	waitForChange()
+	if ids, err := db.GetSprintWorkItemIDs(ctx, "Sprint 2"); err != nil || len(ids) != 1 {
+		t.Errorf("query after fix = %v, %v", ids, err)
+	}
+
+	// Folders created after the watcher started are watched too
+	writeTestFile(t, dir, "Sprint 3/30-spike.yaml", "title: Spike\n")
+	waitForChange()
+	writeTestFile(t, dir, "Sprint 3/30-spike.yaml", "title: Spike on payments\n")
+	waitForChange()
+	if item, _ := db.GetWorkItemByID(ctx, 30); item == nil || item.Title != "Spike on payments" {
+		t.Errorf("item in new folder = %+v, want the edit read", item)
+	}
+}
+
+func TestLocalBackendPollsWithoutFileEvents(t *testing.T) {
+	dir := seedLocalDirectory(t)
+	db, err := newLocalBackend(dir, "ana", nil)
+	if err != nil {
+		t.Fatalf("newLocalBackend() error: %v", err)
+	}
+	db.pollInterval = 10 * time.Millisecond
+	go db.poll()
+
+	writeTestFile(t, dir, "Sprint 2/12-card-form.yaml", "title: Card form\nstate: Active\n")
+	select {
+	case <-db.changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change noticed")
+	}
+	if item, _ := db.GetWorkItemByID(context.Background(), 12); item == nil || item.State != "Active" {
+		t.Errorf("item after edit = %+v, want it Active", item)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		frontMatter string
+		body        string
+	}{
+		{"front matter and body", "---\nid: 1\n---\nBody\n", "id: 1\n", "Body\n"},
+		{"windows line endings", "---\r\nid: 1\r\n---\r\nBody\r\n", "id: 1\n", "Body\n"},
+		{"front matter only", "---\nid: 1\n---", "id: 1\n", ""},
+		{"no front matter", "Just text\n", "", "Just text\n"},
+		{"unclosed front matter", "---\nid: 1\n", "", "---\nid: 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontMatter, body := splitFrontMatter([]byte(tt.content))
+			if string(frontMatter) != tt.frontMatter || string(body) != tt.body {
+				t.Errorf("splitFrontMatter() = %q, %q; want %q, %q", frontMatter, body, tt.frontMatter, tt.body)
+			}
+		})
+	}
+}
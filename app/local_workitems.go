@@ -0,0 +1,525 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// WORK ITEM CRUD OPERATIONS
+// =============================================================================
+
+// GetWorkItemByID fetches a single work item by its ID
+func (db *LocalBackend) GetWorkItemByID(ctx context.Context, id int) (*WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	item, exists := db.items[id]
+	if !exists {
+		return nil, fmt.Errorf("work item %d not found", id)
+	}
+	workItem := item.WorkItem
+	return &workItem, nil
+}
+
+// GetWorkItemsByIDs returns the list rows of the work items with the given IDs in the same order,
+// skipping deleted ones
+func (db *LocalBackend) GetWorkItemsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	items := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if item, exists := db.items[id]; exists {
+			items = append(items, item.listProjection())
+		}
+	}
+	return items, nil
+}
+
+// GetWorkItemDetailsByIDs returns the complete work items with the given IDs in the same order,
+// skipping deleted ones
+func (db *LocalBackend) GetWorkItemDetailsByIDs(ctx context.Context, ids []int) ([]WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	items := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if item, exists := db.items[id]; exists {
+			items = append(items, item.WorkItem)
+		}
+	}
+	return items, nil
+}
+
+// GetSprintWorkItemIDs returns the IDs of unfinished work items, most recently changed first,
+// optionally limited to a sprint folder
+func (db *LocalBackend) GetSprintWorkItemIDs(ctx context.Context, sprintPath string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.queryIDs(func(item *localItem) bool {
+		return sprintPath == "" || item.IterationPath == sprintPath
+	}, false)
+}
+
+// GetSprintWorkItemIDsInState returns the IDs of work items in a state, finished or not,
+// most recently changed first, optionally limited to a sprint folder
+func (db *LocalBackend) GetSprintWorkItemIDsInState(ctx context.Context, sprintPath string, state string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.queryAllIDs(func(item *localItem) bool {
+		return strings.EqualFold(item.State, state) && (sprintPath == "" || item.IterationPath == sprintPath)
+	}, false)
+}
+
+// queryIDs returns the IDs of unfinished items accepted by match, sorted by changed date
+// (most recent first unless oldestFirst) and then by ID. It fails while the files can't be read.
+func (db *LocalBackend) queryIDs(match func(item *localItem) bool, oldestFirst bool) ([]int, error) {
+	return db.queryAllIDs(func(item *localItem) bool {
+		return !db.isDone(item) && match(item)
+	}, oldestFirst)
+}
+
+// queryAllIDs is queryIDs without leaving out finished items
+func (db *LocalBackend) queryAllIDs(match func(item *localItem) bool, oldestFirst bool) ([]int, error) {
+	if db.loadErr != nil {
+		return nil, db.loadErr
+	}
+
+	var result []*localItem
+	for _, item := range db.items {
+		if !match(item) {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChangedDate != result[j].ChangedDate {
+			return (result[i].ChangedDate > result[j].ChangedDate) != oldestFirst
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	ids := make([]int, len(result))
+	for i, item := range result {
+		ids[i] = item.ID
+	}
+	return ids, nil
+}
+
+// UpdateWorkItemState updates the state of a work item
+func (db *LocalBackend) UpdateWorkItemState(ctx context.Context, workItemID int, newState string) error {
+	return db.UpdateWorkItem(ctx, workItemID, map[string]interface{}{"state": newState})
+}
+
+// UpdateWorkItem updates multiple fields of a work item and writes it to its file.
+// A new iteration path moves the file to that sprint folder.
+func (db *LocalBackend) UpdateWorkItem(ctx context.Context, workItemID int, updates map[string]interface{}) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	item, exists := db.items[workItemID]
+	if !exists {
+		return fmt.Errorf("work item %d not found", workItemID)
+	}
+
+	if newState, ok := updates["state"].(string); ok && db.stateCategory(newState) == "" {
+		return fmt.Errorf("unknown state %q (use %s)", newState, strings.Join(db.stateNames(), ", "))
+	}
+
+	updated := *item
+	updated.fields = maps.Clone(item.fields)
+	for key, value := range updates {
+		switch key {
+		case "title":
+			if v, ok := value.(string); ok {
+				updated.Title = v
+			}
+		case "description":
+			if v, ok := value.(string); ok {
+				updated.Description = v
+			}
+		case "state":
+			if v, ok := value.(string); ok {
+				updated.State = v
+			}
+		case "tags":
+			if v, ok := value.(string); ok {
+				updated.Tags = strings.Join(splitTags(v), "; ")
+			}
+		case "priority":
+			if v, ok := value.(int); ok {
+				updated.Priority = v
+			}
+		case "iterationPath":
+			if v, ok := value.(string); ok {
+				updated.IterationPath = v
+			}
+		case "comment":
+			if v, ok := value.(string); ok {
+				if updated.Comments != "" {
+					updated.Comments += "\n\n"
+				}
+				updated.Comments += v
+			}
+		default:
+			// Fields the app has no name for are kept by reference name, as other backends would
+			if !strings.Contains(key, ".") {
+				return fmt.Errorf("unknown field %q", key)
+			}
+			if updated.fields == nil {
+				updated.fields = make(map[string]string)
+			}
+			updated.fields[key] = fmt.Sprint(value)
+		}
+	}
+
+	updated.ChangedDate = time.Now().Format(localDateLayout)
+	return db.save(item, &updated)
+}
+
+// save writes the updated item to its file, moving the file if the sprint changed,
+// and records a revision
+func (db *LocalBackend) save(item, updated *localItem) error {
+	if updated.IterationPath != item.IterationPath {
+		folder, err := db.sprintFolder(updated.IterationPath)
+		if err != nil {
+			return err
+		}
+		updated.path = filepath.Join(folder, filepath.Base(item.path))
+		if _, err := os.Stat(updated.path); err == nil {
+			return fmt.Errorf("sprint %s already has a file named %s", updated.IterationPath, filepath.Base(item.path))
+		}
+	}
+
+	if err := writeLocalItem(updated); err != nil {
+		return err
+	}
+	if updated.path != item.path {
+		if err := os.Remove(item.path); err != nil {
+			return fmt.Errorf("failed to move work item %d: %w", item.ID, err)
+		}
+	}
+	*item = *updated
+	db.saved()
+	return db.recordRevision(item)
+}
+
+// sprintFolder returns the folder of a sprint, creating it and adding the sprint if it is new.
+// The empty sprint is the backlog, in the directory itself.
+func (db *LocalBackend) sprintFolder(sprintPath string) (string, error) {
+	if sprintPath == "" {
+		return db.dir, nil
+	}
+	if strings.ContainsAny(sprintPath, `/\`) || strings.HasPrefix(sprintPath, ".") {
+		return "", fmt.Errorf("invalid sprint name %q", sprintPath)
+	}
+
+	folder := filepath.Join(db.dir, sprintPath)
+	for _, sprint := range db.sprints {
+		if sprint.Path == sprintPath {
+			return folder, nil
+		}
+	}
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", fmt.Errorf("failed to create sprint %s: %w", sprintPath, err)
+	}
+	db.sprints = append(db.sprints, Sprint{Name: sprintPath, Path: sprintPath})
+	sortLocalSprints(db.sprints)
+	return folder, nil
+}
+
+// UpdateWorkItems updates the fields of each work item, collecting the items that failed
+func (db *LocalBackend) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	failed := make(map[int]error)
+	for _, id := range workItemIDs {
+		if err := db.UpdateWorkItem(ctx, id, updates); err != nil {
+			failed[id] = err
+		}
+	}
+	return failed, nil
+}
+
+// CreateWorkItem creates a work item as a Markdown file named after its ID and title,
+// in the folder of its sprint
+func (db *LocalBackend) CreateWorkItem(ctx context.Context, title string, workItemType string, iterationPath string, parentID *int, areaPath string) (*WorkItem, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	folder, err := db.sprintFolder(iterationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	id := db.nextID
+	now := time.Now().Format(localDateLayout)
+	name := fmt.Sprintf("%d-%s.md", id, slugify(title, maxBranchTitleLength))
+	item := &localItem{path: filepath.Join(folder, name), WorkItem: WorkItem{
+		ID:            id,
+		Title:         title,
+		State:         db.states[0].Name,
+		WorkItemType:  workItemType,
+		AssignedTo:    db.user,
+		IterationPath: iterationPath,
+		AreaPath:      filepath.Base(db.dir),
+		ParentID:      parentID,
+		CreatedDate:   now,
+		ChangedDate:   now,
+		Priority:      2,
+	}}
+
+	if err := writeLocalItem(item); err != nil {
+		return nil, err
+	}
+	db.nextID++
+	db.items[id] = item
+	db.saved()
+	if err := db.recordRevision(item); err != nil {
+		return nil, err
+	}
+
+	workItem := item.WorkItem
+	return &workItem, nil
+}
+
+// DeleteWorkItem deletes the file of a work item
+func (db *LocalBackend) DeleteWorkItem(ctx context.Context, workItemID int) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	item, exists := db.items[workItemID]
+	if !exists {
+		return fmt.Errorf("work item %d not found", workItemID)
+	}
+	if err := os.Remove(item.path); err != nil {
+		return fmt.Errorf("failed to delete work item %d: %w", workItemID, err)
+	}
+	delete(db.items, workItemID)
+	db.saved()
+	return nil
+}
+
+// MoveWorkItemToSprint moves the file of a work item to a sprint folder, or to the backlog
+func (db *LocalBackend) MoveWorkItemToSprint(ctx context.Context, workItemID int, iterationPath string) error {
+	return db.UpdateWorkItem(ctx, workItemID, map[string]interface{}{"iterationPath": iterationPath})
+}
+
+// GetWorkItemHistory returns the field changes of every revision of a work item, oldest first
+func (db *LocalBackend) GetWorkItemHistory(ctx context.Context, workItemID int) ([]WorkItemUpdate, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	revisions, exists := db.revisions[workItemID]
+	if !exists || db.items[workItemID] == nil {
+		return nil, fmt.Errorf("work item %d not found", workItemID)
+	}
+	return diffRevisions(revisions), nil
+}
+
+// GetWorkItemTypeStates returns the configured states, which every local item type shares
+func (db *LocalBackend) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	categories := make(map[string]string, len(db.states))
+	for _, state := range db.states {
+		categories[state.Name] = state.Category
+	}
+	return db.stateNames(), categories, nil
+}
+
+// GetStateCategories returns the category of every state
+func (db *LocalBackend) GetStateCategories(ctx context.Context) (map[string]string, error) {
+	_, categories, err := db.GetWorkItemTypeStates(ctx, "")
+	return categories, err
+}
+
+// GetWorkItemTypeTransitions allows moving between any two states; local items have no workflow rules
+func (db *LocalBackend) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	names := db.stateNames()
+	transitions := map[string][]string{"": names[:1]}
+	for _, from := range names {
+		for _, to := range names {
+			if to != from {
+				transitions[from] = append(transitions[from], to)
+			}
+		}
+	}
+	return transitions, nil
+}
+
+// stateNames returns the names of the configured states in process order
+func (db *LocalBackend) stateNames() []string {
+	names := make([]string, len(db.states))
+	for i, state := range db.states {
+		names[i] = state.Name
+	}
+	return names
+}
+
+// stateCategory returns the category of a configured state, or "" for other states
+func (db *LocalBackend) stateCategory(name string) string {
+	for _, state := range db.states {
+		if state.Name == name {
+			return state.Category
+		}
+	}
+	return ""
+}
+
+// isDone reports whether an item is in a Completed or Removed state
+func (db *LocalBackend) isDone(item *localItem) bool {
+	category := db.stateCategory(item.State)
+	return category == "Completed" || category == "Removed"
+}
+
+// GetRequiredFields returns no fields; any state can be set on its own
+func (db *LocalBackend) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	return nil, nil
+}
+
+// =============================================================================
+// ATTACHMENT OPERATIONS
+// =============================================================================
+
+// DownloadAttachment returns the content of an attachment
+func (db *LocalBackend) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	if attachment.ID == "" || attachment.ID != filepath.Base(attachment.ID) {
+		return nil, fmt.Errorf("invalid attachment ID %q", attachment.ID)
+	}
+	content, err := os.ReadFile(db.attachmentPath(attachment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %s: %w", attachment.Name, err)
+	}
+	return content, nil
+}
+
+// UploadAttachment stores the content in the attachments folder and returns a reference to it
+func (db *LocalBackend) UploadAttachment(ctx context.Context, fileName string, content []byte) (*Attachment, error) {
+	id := uuid.New().String() + "-" + filepath.Base(fileName)
+	path := db.attachmentPath(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	return &Attachment{
+		ID:   id,
+		Name: fileName,
+		URL:  "file://" + filepath.ToSlash(path),
+		Size: int64(len(content)),
+	}, nil
+}
+
+// AddAttachmentToWorkItem lists a stored attachment in the file of a work item
+func (db *LocalBackend) AddAttachmentToWorkItem(ctx context.Context, workItemID int, attachment Attachment, comment string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	item, exists := db.items[workItemID]
+	if !exists {
+		return fmt.Errorf("work item %d not found", workItemID)
+	}
+	if _, err := os.Stat(db.attachmentPath(attachment.ID)); err != nil {
+		return fmt.Errorf("attachment %s not found", attachment.ID)
+	}
+
+	updated := *item
+	attachment.Comment = comment
+	attachment.AddedDate = time.Now().Format(localDateLayout)
+	updated.Attachments = append(append([]Attachment(nil), item.Attachments...), attachment)
+	return db.save(item, &updated)
+}
+
+// =============================================================================
+// SPRINT OPERATIONS
+// =============================================================================
+
+// GetCurrentAndAdjacentSprints returns the sprint folder running today and the ones before and after it
+func (db *LocalBackend) GetCurrentAndAdjacentSprints(ctx context.Context) (prev *Sprint, curr *Sprint, next *Sprint, err error) {
+	sprints, err := db.GetAllSprints(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	prev, curr, next = adjacentSprints(sprints, time.Now().Format("2006-01-02"))
+	return prev, curr, next, nil
+}
+
+// GetAllSprints returns every sprint folder, in date order
+func (db *LocalBackend) GetAllSprints(ctx context.Context) ([]Sprint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.loadErr != nil {
+		return nil, db.loadErr
+	}
+	return append([]Sprint(nil), db.sprints...), nil
+}
+
+// GetSprintWorkItemRevisions returns the revision history of every work item that was ever in the sprint
+func (db *LocalBackend) GetSprintWorkItemRevisions(ctx context.Context, sprintPath string) (map[int][]WorkItemRevision, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	history := make(map[int][]WorkItemRevision)
+	for id, revisions := range db.revisions {
+		if db.items[id] == nil {
+			continue // Deleted
+		}
+		for _, rev := range revisions {
+			if rev.IterationPath == sprintPath {
+				history[id] = append([]WorkItemRevision{}, revisions...)
+				break
+			}
+		}
+	}
+	return history, nil
+}
+
+// =============================================================================
+// BACKLOG OPERATIONS
+// =============================================================================
+
+// GetRecentBacklogItemIDs returns the IDs of work items outside any sprint folder, recently updated
+func (db *LocalBackend) GetRecentBacklogItemIDs(ctx context.Context) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+	return db.queryIDs(func(item *localItem) bool {
+		if item.IterationPath != "" {
+			return false
+		}
+		changedDate, err := time.Parse(localDateLayout, item.ChangedDate)
+		return err == nil && !changedDate.Before(thirtyDaysAgo)
+	}, false)
+}
+
+// GetAbandonedWorkItemIDs returns the IDs of work items not updated in 14+ days, oldest first
+func (db *LocalBackend) GetAbandonedWorkItemIDs(ctx context.Context, currentSprintPath string) ([]int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	fourteenDaysAgo := time.Now().AddDate(0, 0, -14)
+	return db.queryIDs(func(item *localItem) bool {
+		if currentSprintPath != "" && item.IterationPath == currentSprintPath {
+			return false
+		}
+		changedDate, err := time.Parse(localDateLayout, item.ChangedDate)
+		return err == nil && !changedDate.After(fourteenDaysAgo)
+	}, true)
+}
+
+// GetCurrentUser returns the user new items are assigned to
+func (db *LocalBackend) GetCurrentUser(ctx context.Context) (string, error) {
+	return db.user, nil
+}
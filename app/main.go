@@ -32,9 +32,23 @@ func main() {
 	// 3. Check for dummy mode (flag or environment variable)
 	dummyMode := flags.DummyMode || os.Getenv("HIPPO_DUMMY_MODE") == "true"
 
-	// 4. If dummy mode, skip config and use dummy backend
+	// 4. Subcommands run without the TUI and exit with their own code
+	if flags.Command != "" {
+		os.Exit(runCLI(flags, dummyMode))
+	}
+
+	// 5. If dummy mode, skip config and use dummy backend
 	if dummyMode {
-		m := initialModelWithDummyBackend()
+		// Keybindings and theme still come from the config file, if there is one
+		var fileConfig *Config
+		if configPath, err := getConfigPath(flags); err == nil {
+			fileConfig, _ = loadConfigFile(configPath)
+		}
+		if err := validateUISettings(fileConfig); err != nil {
+			fmt.Printf("Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		m := initialModelWithDummyBackend(fileConfig)
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
 			fmt.Printf("Error: %v", err)
@@ -43,10 +57,10 @@ func main() {
 		return
 	}
 
-	// 5. Load and merge configuration from all sources
+	// 6. Load and merge configuration from all sources
 	config, configSource, err := LoadConfig(flags)
 
-	// 6. Determine if we need to run wizard
+	// 7. Determine if we need to run wizard
 	needsWizard := false
 	var existingConfig *Config
 	var existingConfigSource *ConfigSource
@@ -67,19 +81,30 @@ func main() {
 			os.Exit(1)
 		}
 	} else if err := ValidateConfig(config); err != nil {
+		// The wizard only sets up Azure DevOps
+		if !config.usesAzureDevOps() {
+			fmt.Printf("Configuration error: %v\n", err)
+			os.Exit(1)
+		}
 		needsWizard = true
 		existingConfig = config
 		existingConfigSource = configSource
 	}
 
-	// 7. If --init flag is set, force wizard mode
+	// Report keybinding, column and theme errors before the TUI takes over the screen
+	if err := validateUISettings(config); err != nil {
+		fmt.Printf("Configuration error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 8. If --init flag is set, force wizard mode
 	if flags.RunWizard {
 		needsWizard = true
 		existingConfig = config
 		existingConfigSource = configSource
 	}
 
-	// 8. Start TUI (either with wizard or normal mode)
+	// 9. Start TUI (either with wizard or normal mode)
 	var m model
 	if needsWizard {
 		m = initialModelWithWizard(existingConfig, existingConfigSource)
@@ -93,3 +118,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// validateUISettings checks the keybindings, list columns and theme of a config, which may be nil
+func validateUISettings(config *Config) error {
+	if config == nil {
+		return nil
+	}
+	if _, err := newKeymap(config.Keybindings); err != nil {
+		return err
+	}
+	if err := validateListColumns(config.ListColumns); err != nil {
+		return err
+	}
+	if config.PageSize < 0 || config.PageSize > maxPageSize {
+		return fmt.Errorf("page_size must be between 1 and %d", maxPageSize)
+	}
+	if config.RequestTimeout < 0 {
+		return fmt.Errorf("request_timeout must be a positive number of seconds")
+	}
+	// The background only matters for auto, which can't fail
+	if _, err := resolveTheme(config.Theme, config.Themes, func() bool { return true }); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,12 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -12,6 +18,8 @@ type tasksLoadedMsg struct {
 	err           error
 	client        Backend
 	totalCount    int
+	ids           []int // Every ID matched by the list's query, in order; set on initial loads
+	missingIDs    []int // Requested IDs whose items were deleted after the query ran
 	append        bool
 	forTab        *sprintTab  // Which sprint tab these tasks are for
 	forBacklogTab *backlogTab // Which backlog tab these tasks are for
@@ -30,6 +38,12 @@ type workItemRefreshedMsg struct {
 	err      error
 }
 
+type workItemDetailsLoadedMsg struct {
+	workItems []WorkItem
+	purpose   detailsPurpose // Which load the details answer
+	err       error
+}
+
 type workItemCreatedMsg struct {
 	workItem *WorkItem
 	err      error
@@ -45,12 +59,27 @@ type sprintUpdatedMsg struct {
 	err        error
 }
 
+// batchProgressMsg reports one finished item of a running batch
+type batchProgressMsg struct {
+	result  batchItemResult
+	results <-chan batchItemResult // Remaining results of the batch
+}
+
+// batchFinishedMsg reports that every item of the batch has finished
+type batchFinishedMsg struct{}
+
 type statesLoadedMsg struct {
 	states          []string
 	stateCategories map[string]string
 	err             error
 }
 
+type requiredFieldsLoadedMsg struct {
+	fields     []RequiredField
+	fieldTypes map[string][]string // Work item types requiring each field, keyed by reference name
+	err        error
+}
+
 type sprintsLoadedMsg struct {
 	previousSprint *Sprint
 	currentSprint  *Sprint
@@ -60,103 +89,236 @@ type sprintsLoadedMsg struct {
 	forceReload    bool // Force reload even if sprints already exist
 }
 
+type boardStatesLoadedMsg struct {
+	typeStates      map[string][]string
+	stateCategories map[string]string
+	err             error
+}
+
+type boardCardMovedMsg struct {
+	workItemID int
+	oldState   string
+	newState   string
+	err        error
+}
+
+type historyLoadedMsg struct {
+	workItemID int
+	updates    []WorkItemUpdate
+	err        error
+}
+
+type attachmentDownloadedMsg struct {
+	path string
+	size int
+	err  error
+}
+
+type attachmentUploadedMsg struct {
+	workItemID int
+	name       string
+	err        error
+}
+
+type branchCheckedOutMsg struct {
+	workItemID int
+	branch     string
+	created    bool
+	newState   string // State the item was moved to, empty if unchanged
+	err        error
+	stateErr   error // Checkout succeeded but the state change failed
+}
+
+type exportDoneMsg struct {
+	destination string
+	count       int
+	err         error
+}
+
+type yankDoneMsg struct {
+	format yankFormat
+	count  int
+	err    error
+}
+
+type importParsedMsg struct {
+	path  string
+	items []importItem
+	err   error
+}
+
+type importDoneMsg struct {
+	created []*WorkItem
+	total   int
+	err     error
+}
+
+type allSprintsLoadedMsg struct {
+	sprints []Sprint
+	err     error
+}
+
+type sprintHistoryLoadedMsg struct {
+	sprintPath string
+	history    map[int][]WorkItemRevision
+	err        error
+}
+
+type stateCategoriesLoadedMsg struct {
+	categories map[string]string
+	err        error
+}
+
+type currentUserLoadedMsg struct {
+	name string
+	err  error
+}
+
+// backendChangedMsg reports work items edited outside Hippo
+type backendChangedMsg struct{}
+
 // Command Functions
 // These functions return tea.Cmd that perform asynchronous operations and return messages
 
-func loadTasks(client Backend) tea.Cmd {
-	return loadTasksForSprint(client, nil, "", defaultLoadLimit, nil)
+func loadTasks(ctx context.Context, client Backend, pageSize int) tea.Cmd {
+	return loadTasksForSprint(ctx, client, "", pageSize, nil)
 }
 
-func loadTasksForSprint(client Backend, excludeIDs []int, sprintPath string, limit int, forTab *sprintTab) tea.Cmd {
+// loadTasksForSprint loads the first page of a sprint. List loads run under a context the model
+// cancels once their results would no longer be shown; a superseded load reports
+// context.Canceled even when its calls already finished.
+func loadTasksForSprint(ctx context.Context, client Backend, sprintPath string, pageSize int, forTab *sprintTab) tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := client.GetWorkItemsExcluding(excludeIDs, sprintPath, limit)
-		if err != nil {
-			return tasksLoadedMsg{err: err, append: len(excludeIDs) > 0, forTab: forTab}
-		}
-
-		totalCount, countErr := client.GetWorkItemsCountForSprint(sprintPath)
-		if countErr != nil {
-			// If count fails, just use the tasks we got
-			totalCount = len(tasks)
-		}
-
-		return tasksLoadedMsg{tasks: tasks, err: err, client: client, totalCount: totalCount, append: len(excludeIDs) > 0, forTab: forTab}
+		ids, err := client.GetSprintWorkItemIDs(ctx, sprintPath)
+		msg := firstPageMsg(ctx, client, ids, err, pageSize)
+		msg.forTab = forTab
+		return msg
 	}
 }
 
-func loadInitialTasksForSprint(client Backend, sprintPath string, tab sprintTab) tea.Cmd {
+func loadInitialTasksForSprint(ctx context.Context, client Backend, sprintPath string, tab sprintTab, pageSize int) tea.Cmd {
 	tabCopy := tab
-	return loadTasksForSprint(client, nil, sprintPath, defaultLoadLimit, &tabCopy)
+	return loadTasksForSprint(ctx, client, sprintPath, pageSize, &tabCopy)
 }
 
-func loadTasksForBacklogTab(client Backend, tab backlogTab, currentSprintPath string) tea.Cmd {
+func loadTasksForBacklogTab(ctx context.Context, client Backend, tab backlogTab, currentSprintPath string, pageSize int) tea.Cmd {
 	return func() tea.Msg {
-		var tasks []WorkItem
+		var ids []int
 		var err error
-		var totalCount int
 
 		switch tab {
 		case recentBacklog:
-			tasks, err = client.GetRecentBacklogItems(defaultLoadLimit)
-			if err == nil {
-				totalCount, _ = client.GetRecentBacklogItemsCount()
-			}
+			ids, err = client.GetRecentBacklogItemIDs(ctx)
 		case abandonedWork:
-			tasks, err = client.GetAbandonedWorkItems(currentSprintPath, defaultLoadLimit)
-			if err == nil {
-				totalCount, _ = client.GetAbandonedWorkItemsCount(currentSprintPath)
-			}
+			ids, err = client.GetAbandonedWorkItemIDs(ctx, currentSprintPath)
 		}
 
-		if err != nil {
-			return tasksLoadedMsg{err: err, append: false, forTab: nil, forBacklogTab: &tab}
-		}
+		msg := firstPageMsg(ctx, client, ids, err, pageSize)
+		msg.forBacklogTab = &tab
+		return msg
+	}
+}
 
-		if totalCount == 0 {
-			totalCount = len(tasks)
-		}
+// firstPageMsg fetches the details of the first page of a list's query results.
+// The full ID list travels with the message so later pages need no new query.
+func firstPageMsg(ctx context.Context, client Backend, ids []int, err error, pageSize int) tasksLoadedMsg {
+	if err != nil {
+		return tasksLoadedMsg{err: err}
+	}
 
-		tabCopy := tab
-		return tasksLoadedMsg{tasks: tasks, err: err, client: client, totalCount: totalCount, append: false, forTab: nil, forBacklogTab: &tabCopy}
+	pageIDs := slices.Clone(ids[:min(pageSize, len(ids))])
+	tasks, err := client.GetWorkItemsByIDs(ctx, pageIDs)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return tasksLoadedMsg{err: err}
 	}
+	missing := missingIDs(pageIDs, tasks)
+	return tasksLoadedMsg{tasks: tasks, client: client, totalCount: len(ids) - len(missing), ids: ids, missingIDs: missing}
 }
 
-func loadMoreBacklogItems(client Backend, tab backlogTab, currentSprintPath string, excludeIDs []int) tea.Cmd {
+// loadNextPage fetches the details of the next page of a list, from the IDs of its last query
+func loadNextPage(ctx context.Context, client Backend, pageIDs []int, totalCount int, forTab *sprintTab, forBacklogTab *backlogTab) tea.Cmd {
 	return func() tea.Msg {
-		var tasks []WorkItem
-		var err error
-		var totalCount int
-
-		switch tab {
-		case recentBacklog:
-			tasks, err = client.GetRecentBacklogItemsExcluding(excludeIDs, defaultLoadLimit)
-			if err == nil {
-				totalCount, _ = client.GetRecentBacklogItemsCount()
-			}
-		case abandonedWork:
-			tasks, err = client.GetAbandonedWorkItemsExcluding(excludeIDs, currentSprintPath, defaultLoadLimit)
-			if err == nil {
-				totalCount, _ = client.GetAbandonedWorkItemsCount(currentSprintPath)
-			}
+		tasks, err := client.GetWorkItemsByIDs(ctx, pageIDs)
+		if err == nil {
+			err = ctx.Err()
 		}
-
 		if err != nil {
-			return tasksLoadedMsg{err: err, append: true, forTab: nil, forBacklogTab: &tab}
+			return tasksLoadedMsg{err: err, append: true, forTab: forTab, forBacklogTab: forBacklogTab}
 		}
+		missing := missingIDs(pageIDs, tasks)
+		return tasksLoadedMsg{tasks: tasks, client: client, totalCount: totalCount - len(missing), missingIDs: missing, append: true, forTab: forTab, forBacklogTab: forBacklogTab}
+	}
+}
 
-		if totalCount == 0 {
-			totalCount = len(tasks)
+// loadWorkItemDetails fetches the fields list rows leave out, such as descriptions and comments
+func loadWorkItemDetails(ctx context.Context, client Backend, ids []int, purpose detailsPurpose) tea.Cmd {
+	return func() tea.Msg {
+		workItems, err := client.GetWorkItemDetailsByIDs(ctx, ids)
+		if err == nil {
+			err = ctx.Err()
 		}
+		return workItemDetailsLoadedMsg{workItems: workItems, purpose: purpose, err: err}
+	}
+}
 
-		tabCopy := tab
-		return tasksLoadedMsg{tasks: tasks, err: err, client: client, totalCount: totalCount, append: true, forTab: nil, forBacklogTab: &tabCopy}
+// loadWorkItemStates loads the states of each work item type among the items and offers only
+// the states every item may move to
+func loadWorkItemStates(ctx context.Context, client Backend, items []WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		var offered []string
+		categories := make(map[string]string)
+		for i, group := range groupByWorkItemType(items) {
+			states, typeCategories, err := client.GetWorkItemTypeStates(ctx, group.workItemType)
+			if err != nil {
+				return statesLoadedMsg{err: err}
+			}
+			for state, category := range typeCategories {
+				categories[state] = category
+			}
+			// A type without transition data offers every state and the server has the final say
+			transitions, err := client.GetWorkItemTypeTransitions(ctx, group.workItemType)
+			if err != nil {
+				return statesLoadedMsg{err: err}
+			}
+			allowed := allowedNextStates(states, transitions, group.fromStates())
+			if i == 0 {
+				offered = allowed
+				continue
+			}
+			offered = slices.DeleteFunc(offered, func(state string) bool { return !slices.Contains(allowed, state) })
+		}
+		// The transitions of every type are already applied to the offered states
+		return statesLoadedMsg{states: offered, stateCategories: categories}
 	}
 }
 
-func loadWorkItemStates(client Backend, workItemType string) tea.Cmd {
+// checkRequiredFields asks the backend which fields a state change needs before submitting it.
+// The rules depend on the type and the state an item moves from, so one item of each type
+// and current state is checked and their fields combined.
+func checkRequiredFields(ctx context.Context, client Backend, items []WorkItem, newState string) tea.Cmd {
 	return func() tea.Msg {
-		states, categories, err := client.GetWorkItemTypeStates(workItemType)
-		return statesLoadedMsg{states: states, stateCategories: categories, err: err}
+		var fields []RequiredField
+		fieldTypes := make(map[string][]string)
+		for _, group := range groupByWorkItemType(items) {
+			for _, item := range group.firstInEachState() {
+				typeFields, err := client.GetRequiredFields(ctx, item.ID, group.workItemType, newState)
+				if err != nil {
+					return requiredFieldsLoadedMsg{err: err}
+				}
+				for _, field := range typeFields {
+					if _, seen := fieldTypes[field.ReferenceName]; !seen {
+						fields = append(fields, field)
+					}
+					if !slices.Contains(fieldTypes[field.ReferenceName], group.workItemType) {
+						fieldTypes[field.ReferenceName] = append(fieldTypes[field.ReferenceName], group.workItemType)
+					}
+				}
+			}
+		}
+		return requiredFieldsLoadedMsg{fields: fields, fieldTypes: fieldTypes}
 	}
 }
 
@@ -164,9 +326,25 @@ func loadSprints(client Backend) tea.Cmd {
 	return loadSprintsWithReload(client, false)
 }
 
+// loadStateCategories loads the category of every state so finished items are recognized in any process
+func loadStateCategories(client Backend) tea.Cmd {
+	return func() tea.Msg {
+		categories, err := client.GetStateCategories(context.Background())
+		return stateCategoriesLoadedMsg{categories: categories, err: err}
+	}
+}
+
+// loadCurrentUser resolves the signed-in user for assignee:me filters
+func loadCurrentUser(client Backend) tea.Cmd {
+	return func() tea.Msg {
+		name, err := client.GetCurrentUser(context.Background())
+		return currentUserLoadedMsg{name: name, err: err}
+	}
+}
+
 func loadSprintsWithReload(client Backend, forceReload bool) tea.Cmd {
 	return func() tea.Msg {
-		prev, curr, next, err := client.GetCurrentAndAdjacentSprints()
+		prev, curr, next, err := client.GetCurrentAndAdjacentSprints(context.Background())
 
 		return sprintsLoadedMsg{
 			previousSprint: prev,
@@ -179,44 +357,212 @@ func loadSprintsWithReload(client Backend, forceReload bool) tea.Cmd {
 	}
 }
 
-func updateWorkItemState(client Backend, workItemID int, newState string) tea.Cmd {
+// waitForBackendChanges waits for the backend to notice work items edited outside Hippo.
+// It returns nil for backends that don't watch for edits.
+func waitForBackendChanges(client Backend) tea.Cmd {
+	watcher, ok := client.(changeWatcher)
+	if !ok {
+		return nil
+	}
+	changes := watcher.Changes()
+	if changes == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		<-changes
+		return backendChangedMsg{}
+	}
+}
+
+// loadBoardStates loads the states of every work item type shown on the board
+func loadBoardStates(client Backend, workItemTypes []string) tea.Cmd {
+	return func() tea.Msg {
+		typeStates := make(map[string][]string)
+		categories := make(map[string]string)
+		for _, workItemType := range workItemTypes {
+			states, stateCategories, err := client.GetWorkItemTypeStates(context.Background(), workItemType)
+			if err != nil {
+				return boardStatesLoadedMsg{err: err}
+			}
+			typeStates[workItemType] = states
+			for state, category := range stateCategories {
+				categories[state] = category
+			}
+		}
+		return boardStatesLoadedMsg{typeStates: typeStates, stateCategories: categories}
+	}
+}
+
+func moveBoardCard(client Backend, workItemID int, oldState, newState string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpdateWorkItemState(context.Background(), workItemID, newState)
+		return boardCardMovedMsg{workItemID: workItemID, oldState: oldState, newState: newState, err: err}
+	}
+}
+
+func loadWorkItemHistory(ctx context.Context, client Backend, workItemID int) tea.Cmd {
+	return func() tea.Msg {
+		updates, err := client.GetWorkItemHistory(ctx, workItemID)
+		return historyLoadedMsg{workItemID: workItemID, updates: updates, err: err}
+	}
+}
+
+func downloadAttachmentTo(client Backend, attachment Attachment, path string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := client.DownloadAttachment(context.Background(), attachment)
+		if err != nil {
+			return attachmentDownloadedMsg{path: path, err: err}
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return attachmentDownloadedMsg{path: path, err: fmt.Errorf("failed to save attachment: %w", err)}
+		}
+		return attachmentDownloadedMsg{path: path, size: len(content)}
+	}
+}
+
+func uploadAttachmentFrom(client Backend, workItemID int, path string) tea.Cmd {
+	return func() tea.Msg {
+		name := filepath.Base(path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return attachmentUploadedMsg{workItemID: workItemID, name: name, err: fmt.Errorf("failed to read file: %w", err)}
+		}
+		attachment, err := client.UploadAttachment(context.Background(), name, content)
+		if err != nil {
+			return attachmentUploadedMsg{workItemID: workItemID, name: name, err: err}
+		}
+		err = client.AddAttachmentToWorkItem(context.Background(), workItemID, *attachment, "")
+		return attachmentUploadedMsg{workItemID: workItemID, name: name, err: err}
+	}
+}
+
+func checkoutWorkItemBranch(client Backend, dir string, item WorkItem, branch string) tea.Cmd {
+	return func() tea.Msg {
+		created, err := checkoutBranch(dir, branch)
+		if err != nil {
+			return branchCheckedOutMsg{workItemID: item.ID, branch: branch, err: err}
+		}
+		msg := branchCheckedOutMsg{workItemID: item.ID, branch: branch, created: created}
+		if client == nil {
+			return msg
+		}
+
+		states, categories, err := client.GetWorkItemTypeStates(context.Background(), item.WorkItemType)
+		if err != nil {
+			msg.stateErr = err
+			return msg
+		}
+		if newState := inProgressState(item.State, states, categories); newState != "" {
+			if err := client.UpdateWorkItemState(context.Background(), item.ID, newState); err != nil {
+				msg.stateErr = err
+			} else {
+				msg.newState = newState
+			}
+		}
+		return msg
+	}
+}
+
+func writeExportFile(path, content string, count int) tea.Cmd {
+	return func() tea.Msg {
+		err := os.WriteFile(path, []byte(content), 0644)
+		return exportDoneMsg{destination: path, count: count, err: err}
+	}
+}
+
+func copyExportToClipboard(content string, count int) tea.Cmd {
+	return func() tea.Msg {
+		err := copyToClipboard(content)
+		return exportDoneMsg{destination: "clipboard", count: count, err: err}
+	}
+}
+
+func copyYank(format yankFormat, text string, count int) tea.Cmd {
+	return func() tea.Msg {
+		err := copyToClipboard(text)
+		return yankDoneMsg{format: format, count: count, err: err}
+	}
+}
+
+func parseImport(path string) tea.Cmd {
+	return func() tea.Msg {
+		file, err := os.Open(path)
+		if err != nil {
+			return importParsedMsg{path: path, err: err}
+		}
+		defer file.Close()
+		items, err := parseImportFile(path, file)
+		return importParsedMsg{path: path, items: items, err: err}
+	}
+}
+
+func runImport(ctx context.Context, client Backend, items []importItem, iterationPath, areaPath string) tea.Cmd {
+	return func() tea.Msg {
+		created, err := createImportedItems(ctx, client, items, iterationPath, areaPath)
+		return importDoneMsg{created: created, total: len(items), err: err}
+	}
+}
+
+func loadAllSprints(ctx context.Context, client Backend) tea.Cmd {
+	return func() tea.Msg {
+		sprints, err := client.GetAllSprints(ctx)
+		return allSprintsLoadedMsg{sprints: sprints, err: err}
+	}
+}
+
+func loadSprintHistory(ctx context.Context, client Backend, sprintPath string) tea.Cmd {
+	return func() tea.Msg {
+		history, err := client.GetSprintWorkItemRevisions(ctx, sprintPath)
+		return sprintHistoryLoadedMsg{sprintPath: sprintPath, history: history, err: err}
+	}
+}
+
+func updateWorkItemState(ctx context.Context, client Backend, workItemID int, newState string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpdateWorkItemState(ctx, workItemID, newState)
+		return stateUpdatedMsg{err: err}
+	}
+}
+
+// updateWorkItemStateWithFields changes the state together with the fields the process requires
+func updateWorkItemStateWithFields(ctx context.Context, client Backend, workItemID int, newState string, fieldValues map[string]string) tea.Cmd {
 	return func() tea.Msg {
-		err := client.UpdateWorkItemState(workItemID, newState)
+		err := client.UpdateWorkItem(ctx, workItemID, stateUpdates(newState, fieldValues))
 		return stateUpdatedMsg{err: err}
 	}
 }
 
 func updateWorkItem(client Backend, workItemID int, updates map[string]interface{}) tea.Cmd {
 	return func() tea.Msg {
-		err := client.UpdateWorkItem(workItemID, updates)
+		err := client.UpdateWorkItem(context.Background(), workItemID, updates)
 		return workItemUpdatedMsg{err: err}
 	}
 }
 
 func refreshWorkItem(client Backend, workItemID int) tea.Cmd {
 	return func() tea.Msg {
-		workItem, err := client.GetWorkItemByID(workItemID)
+		workItem, err := client.GetWorkItemByID(context.Background(), workItemID)
 		return workItemRefreshedMsg{workItem: workItem, err: err}
 	}
 }
 
-func createWorkItem(client Backend, title string, workItemType string, iterationPath string, parentID *int, areaPath string) tea.Cmd {
+func createWorkItem(ctx context.Context, client Backend, title string, workItemType string, iterationPath string, parentID *int, areaPath string) tea.Cmd {
 	return func() tea.Msg {
-		workItem, err := client.CreateWorkItem(title, workItemType, iterationPath, parentID, areaPath)
+		workItem, err := client.CreateWorkItem(ctx, title, workItemType, iterationPath, parentID, areaPath)
 		return workItemCreatedMsg{workItem: workItem, err: err}
 	}
 }
 
 func deleteWorkItem(client Backend, workItemID int) tea.Cmd {
 	return func() tea.Msg {
-		err := client.DeleteWorkItem(workItemID)
+		err := client.DeleteWorkItem(context.Background(), workItemID)
 		return workItemDeletedMsg{workItemID: workItemID, err: err}
 	}
 }
 
 func moveWorkItemToSprint(client Backend, workItemID int, iterationPath string) tea.Cmd {
 	return func() tea.Msg {
-		err := client.MoveWorkItemToSprint(workItemID, iterationPath)
+		err := client.MoveWorkItemToSprint(context.Background(), workItemID, iterationPath)
 		return sprintUpdatedMsg{workItemID: workItemID, err: err}
 	}
 }
@@ -61,28 +61,66 @@ func (m *model) setCurrentTasks(tasks []WorkItem) {
 // Filtering & Navigation
 // ============================================================================
 
-// filterSearch performs filtering on the current list based on filter input
+// filterSearch performs filtering on the current list based on the filter query.
+// Ancestors of matching items are kept as context so matches stay in their tree.
 func (m *model) filterSearch() {
-	query := strings.ToLower(m.filter.filterInput.Value())
+	input := strings.TrimSpace(m.filter.filterInput.Value())
 	list := m.getCurrentList()
 	if list == nil {
 		return
 	}
 
-	if query == "" {
+	if input == "" {
 		list.filteredTasks = nil
 		list.filterActive = false
 		// Also sync model-level filter state
 		m.filter.filteredTasks = nil
+		m.filter.contextIDs = nil
+		m.filter.err = nil
 		// Invalidate cache when filter is cleared
 		list.invalidateTreeCache()
 		return
 	}
 
+	query, err := parseFilterQuery(input)
+	if err != nil {
+		// Keep the previous results while the query is being typed
+		m.filter.err = err
+		return
+	}
+	m.filter.err = nil
+
+	ctx := filterContext{currentUser: m.currentUser, now: time.Now()}
+	byID := make(map[int]*WorkItem, len(list.tasks))
+	for i := range list.tasks {
+		byID[list.tasks[i].ID] = &list.tasks[i]
+	}
+
+	matched := make(map[int]bool)
+	ancestors := make(map[int]bool)
+	for i := range list.tasks {
+		task := &list.tasks[i]
+		if !query.matches(task, ctx) {
+			continue
+		}
+		matched[task.ID] = true
+		// Walk up the parent chain, stopping at items outside this list
+		for parentID := task.ParentID; parentID != nil; {
+			parent, ok := byID[*parentID]
+			if !ok || ancestors[parent.ID] {
+				break
+			}
+			ancestors[parent.ID] = true
+			parentID = parent.ParentID
+		}
+	}
+
 	var filtered []WorkItem
 	for _, task := range list.tasks {
-		if strings.Contains(strings.ToLower(task.Title), query) ||
-			strings.Contains(fmt.Sprintf("%d", task.ID), query) {
+		if matched[task.ID] {
+			filtered = append(filtered, task)
+			delete(ancestors, task.ID)
+		} else if ancestors[task.ID] {
 			filtered = append(filtered, task)
 		}
 	}
@@ -90,10 +128,16 @@ func (m *model) filterSearch() {
 	list.filterActive = true
 	// Also sync model-level filter state for backward compatibility
 	m.filter.filteredTasks = filtered
+	m.filter.contextIDs = ancestors
 	// Invalidate cache when filter changes
 	list.invalidateTreeCache()
 }
 
+// filterMatchCount returns the number of filtered items that matched the query itself
+func (m model) filterMatchCount() int {
+	return len(m.filter.filteredTasks) - len(m.filter.contextIDs)
+}
+
 // getVisibleTasks returns tasks that should be visible based on current filters and mode
 func (m model) getVisibleTasks() []WorkItem {
 	list := m.getCurrentList()
@@ -158,6 +202,14 @@ func (m model) getVisibleTreeItems() []TreeItem {
 	return list.treeCache
 }
 
+// pageSize returns the number of items fetched per page of a list
+func (m model) pageSize() int {
+	if m.config != nil && m.config.PageSize > 0 {
+		return m.config.PageSize
+	}
+	return defaultLoadLimit
+}
+
 // hasMoreItems returns true if there are more items to load from the server
 func (m model) hasMoreItems() bool {
 	if list := m.getCurrentList(); list != nil {
@@ -267,20 +319,13 @@ func (m *model) adjustScrollOffset() {
 // Display Helpers
 // ============================================================================
 
-// getStateCategory returns the category for a given state name
+// getStateCategory returns the category for a given state name, or Unknown for states
+// the backend hasn't described
 func (m model) getStateCategory(state string) string {
 	if category, ok := m.stateCategories[state]; ok {
 		return category
 	}
-	// Fallback: try to guess based on common patterns
-	stateLower := strings.ToLower(state)
-	if strings.Contains(stateLower, "closed") || strings.Contains(stateLower, "done") || strings.Contains(stateLower, "completed") {
-		return "Completed"
-	}
-	if strings.Contains(stateLower, "new") || strings.Contains(stateLower, "proposed") {
-		return "Proposed"
-	}
-	return "InProgress"
+	return "Unknown"
 }
 
 // isWorkItemCompleted returns true if the work item is in the Completed category
@@ -296,6 +341,14 @@ func (m model) getCurrentSprintPath() string {
 	return ""
 }
 
+// sprintTabCount returns the number of sprint tabs, including the custom sprint tab once a sprint is opened from the browser
+func (m model) sprintTabCount() sprintTab {
+	if m.sprints[customSprint] != nil {
+		return customSprint + 1
+	}
+	return nextSprint + 1
+}
+
 // getTabHint returns a descriptive hint for the current tab
 func (m model) getTabHint() string {
 	if m.currentMode == sprintMode {
@@ -402,6 +455,12 @@ func (m model) buildDetailContent() string {
 		cardContent.WriteString("\n")
 	}
 
+	// Description, comments and attachments are fetched when the item is opened
+	if task.Partial {
+		cardContent.WriteString("\n")
+		cardContent.WriteString(m.styles.Hint.Render("Loading details..."))
+	}
+
 	// Description Section
 	if task.Description != "" {
 		cardContent.WriteString("\n")
@@ -418,6 +477,17 @@ func (m model) buildDetailContent() string {
 		cardContent.WriteString(m.styles.Description.Render(task.Comments))
 	}
 
+	// Attachments Section
+	if len(task.Attachments) > 0 {
+		cardContent.WriteString("\n")
+		cardContent.WriteString(m.styles.Section.Render(fmt.Sprintf("Attachments (%d)", len(task.Attachments))))
+		cardContent.WriteString("\n")
+		for _, attachment := range task.Attachments {
+			cardContent.WriteString(m.styles.Value.Render(fmt.Sprintf("%s (%s)", attachment.Name, formatFileSize(attachment.Size))))
+			cardContent.WriteString("\n")
+		}
+	}
+
 	return cardStyle.Render(cardContent.String())
 }
 
@@ -5,13 +5,28 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
+// uiSettings returns the keymap and styles set up in config. Both were validated at startup,
+// so invalid settings fall back to the defaults.
+func uiSettings(config *Config) (Keymap, Styles) {
+	if config == nil {
+		config = &Config{}
+	}
+	keys, err := newKeymap(config.Keybindings)
+	if err != nil {
+		keys = defaultKeymap
+	}
+	theme, _ := themeFromConfig(config)
+	return keys, NewThemeStyles(theme)
+}
+
 func initialModel(config *Config, configSource *ConfigSource) model {
+	keys, styles := uiSettings(config)
+
 	s := spinner.New()
 	s.Spinner = spinner.Line
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = styles.Spinner
 
 	// Filter state inputs
 	filterInput := textinput.New()
@@ -49,6 +64,9 @@ func initialModel(config *Config, configSource *ConfigSource) model {
 		sprintLists:  make(map[sprintTab]*WorkItemList),
 		backlogLists: make(map[backlogTab]*WorkItemList),
 
+		// Preselect the work item of the current git branch
+		branchWorkItemID: branchWorkItemAtStartup(config.BranchTemplate),
+
 		// Core state fields
 		state:             loadingView,
 		loading:           true,
@@ -59,7 +77,8 @@ func initialModel(config *Config, configSource *ConfigSource) model {
 		currentTab:        currentSprint,
 		currentBacklogTab: recentBacklog,
 		sprints:           make(map[sprintTab]*Sprint),
-		styles:            NewStyles(),
+		styles:            styles,
+		keys:              keys,
 
 		// Grouped state initialization
 		ui: UIState{
@@ -76,7 +95,7 @@ func initialModel(config *Config, configSource *ConfigSource) model {
 			input: createInput,
 		},
 		batch: BatchState{
-			selectedItems: make(map[int]bool),
+			selectedItems: make(map[int]WorkItem),
 		},
 		filter: FilterState{
 			filteredTasks: []WorkItem{},
@@ -94,18 +113,18 @@ func (m model) Init() tea.Cmd {
 
 	// If client is already set (e.g., dummy mode), use it directly
 	if m.client != nil {
-		return tea.Batch(loadSprints(m.client), m.spinner.Tick)
+		return tea.Batch(loadSprints(m.client), m.spinner.Tick, waitForBackendChanges(m.client))
 	}
 
 	// Otherwise, initialize Azure DevOps client and load data
-	client, err := NewAzureDevOpsClient(m.config)
+	client, err := newBackend(m.config)
 	if err != nil {
 		return func() tea.Msg {
 			return tasksLoadedMsg{err: err}
 		}
 	}
 	// Only load sprint info initially, then load tasks for each sprint
-	return tea.Batch(loadSprints(client), m.spinner.Tick)
+	return tea.Batch(loadSprints(client), m.spinner.Tick, waitForBackendChanges(client))
 }
 
 // initialModelWithWizard creates a model that starts in the config wizard view
@@ -136,9 +155,11 @@ func initialModelWithWizard(existingConfig *Config, existingConfigSource *Config
 		teamInput.SetValue(existingConfig.Team)
 	}
 
+	keys, styles := uiSettings(existingConfig)
+
 	s := spinner.New()
 	s.Spinner = spinner.Line
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = styles.Spinner
 
 	return model{
 		// Configuration
@@ -159,7 +180,8 @@ func initialModelWithWizard(existingConfig *Config, existingConfigSource *Config
 		currentTab:        currentSprint,
 		currentBacklogTab: recentBacklog,
 		sprints:           make(map[sprintTab]*Sprint),
-		styles:            NewStyles(),
+		styles:            styles,
+		keys:              keys,
 
 		// Grouped state initialization
 		ui: UIState{
@@ -167,7 +189,7 @@ func initialModelWithWizard(existingConfig *Config, existingConfigSource *Config
 			scrollOffset: 0,
 		},
 		batch: BatchState{
-			selectedItems: make(map[int]bool),
+			selectedItems: make(map[int]WorkItem),
 		},
 		wizard: WizardState{
 			fieldCursor:  0,
@@ -180,10 +202,13 @@ func initialModelWithWizard(existingConfig *Config, existingConfigSource *Config
 }
 
 // initialModelWithDummyBackend creates a model with the dummy backend for development
-func initialModelWithDummyBackend() model {
+func initialModelWithDummyBackend(fileConfig *Config) model {
+	// Keybindings and theme still come from the config file, if there is one
+	keys, styles := uiSettings(fileConfig)
+
 	s := spinner.New()
 	s.Spinner = spinner.Line
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = styles.Spinner
 
 	// Filter state inputs
 	filterInput := textinput.New()
@@ -219,6 +244,9 @@ func initialModelWithDummyBackend() model {
 		Project:         "DemoProject",
 		Team:            "DemoTeam",
 	}
+	if fileConfig != nil {
+		dummyConfig.PageSize = fileConfig.PageSize
+	}
 	dummyConfigSource := &ConfigSource{
 		OrganizationURL: "dummy",
 		Project:         "dummy",
@@ -237,6 +265,9 @@ func initialModelWithDummyBackend() model {
 		sprintLists:  make(map[sprintTab]*WorkItemList),
 		backlogLists: make(map[backlogTab]*WorkItemList),
 
+		// Preselect the work item of the current git branch
+		branchWorkItemID: branchWorkItemAtStartup(dummyConfig.BranchTemplate),
+
 		// Core state fields
 		state:             loadingView,
 		loading:           true,
@@ -248,7 +279,8 @@ func initialModelWithDummyBackend() model {
 		currentTab:        currentSprint,
 		currentBacklogTab: recentBacklog,
 		sprints:           make(map[sprintTab]*Sprint),
-		styles:            NewStyles(),
+		styles:            styles,
+		keys:              keys,
 
 		// Grouped state initialization
 		ui: UIState{
@@ -265,7 +297,7 @@ func initialModelWithDummyBackend() model {
 			input: createInput,
 		},
 		batch: BatchState{
-			selectedItems: make(map[int]bool),
+			selectedItems: make(map[int]WorkItem),
 		},
 		filter: FilterState{
 			filteredTasks: []WorkItem{},
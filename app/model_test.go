@@ -728,29 +728,24 @@ func TestGetStateCategory(t *testing.T) {
 			expected: "InProgress", // Assuming Active maps to InProgress
 		},
 		{
-			name:     "Closed state - fallback",
-			state:    "Closed",
+			name:     "Known completed state",
+			state:    "Done",
 			expected: "Completed",
 		},
 		{
-			name:     "Done state - fallback",
-			state:    "Done",
-			expected: "Completed",
+			name:     "Closed is not guessed from its name",
+			state:    "Closed",
+			expected: "Unknown",
 		},
 		{
-			name:     "New state - fallback",
+			name:     "New is not guessed from its name",
 			state:    "New",
-			expected: "Proposed",
+			expected: "Unknown",
 		},
 		{
-			name:     "Unknown state - default to InProgress",
+			name:     "Unknown state",
 			state:    "UnknownState",
-			expected: "InProgress",
-		},
-		{
-			name:     "Case insensitive - CLOSED",
-			state:    "CLOSED",
-			expected: "Completed",
+			expected: "Unknown",
 		},
 	}
 
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createSprintBrowserModel creates a model with previous, current, and next sprints loaded
+func createSprintBrowserModel() model {
+	return model{
+		state:       listView,
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		sprints: map[sprintTab]*Sprint{
+			previousSprint: createTestSprint("Sprint 23", "P\\Sprint 23", "2024-01-01", "2024-01-14"),
+			currentSprint:  createTestSprint("Sprint 24", "P\\Sprint 24", "2024-01-15", "2024-01-28"),
+			nextSprint:     createTestSprint("Sprint 25", "P\\Sprint 25", "2024-01-29", "2024-02-11"),
+		},
+		sprintLists: make(map[sprintTab]*WorkItemList),
+		batch:       BatchState{selectedItems: make(map[int]WorkItem)},
+		ui:          UIState{height: 40},
+	}
+}
+
+func TestJumpToSprint(t *testing.T) {
+	tests := []struct {
+		name          string
+		sprint        Sprint
+		expectedTab   sprintTab
+		expectsCustom bool
+	}{
+		{
+			name:        "adjacent sprint switches to its tab",
+			sprint:      *createTestSprint("Sprint 23", "P\\Sprint 23", "2024-01-01", "2024-01-14"),
+			expectedTab: previousSprint,
+		},
+		{
+			name:          "far future sprint opens custom tab",
+			sprint:        *createTestSprint("Sprint 30", "P\\Sprint 30", "2024-04-08", "2024-04-21"),
+			expectedTab:   customSprint,
+			expectsCustom: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createSprintBrowserModel()
+			m.state = sprintBrowserView
+
+			m, _ = m.jumpToSprint(tt.sprint)
+
+			if m.state != listView {
+				t.Errorf("expected list view, got %v", m.state)
+			}
+			if m.currentTab != tt.expectedTab {
+				t.Errorf("currentTab = %v, want %v", m.currentTab, tt.expectedTab)
+			}
+			if got := m.sprints[customSprint] != nil; got != tt.expectsCustom {
+				t.Errorf("custom sprint set = %v, want %v", got, tt.expectsCustom)
+			}
+			if tt.expectsCustom && m.sprints[customSprint].Path != tt.sprint.Path {
+				t.Errorf("custom sprint path = %q, want %q", m.sprints[customSprint].Path, tt.sprint.Path)
+			}
+		})
+	}
+}
+
+func TestSprintTabCycling_WithCustomSprint(t *testing.T) {
+	m := createSprintBrowserModel()
+	m.currentTab = nextSprint
+
+	// Without a custom sprint, tab wraps from next to previous
+	m, _ = m.handleListViewNav(tea.KeyMsg{Type: tea.KeyTab})
+	if m.currentTab != previousSprint {
+		t.Errorf("expected wrap to previous sprint, got %v", m.currentTab)
+	}
+
+	// With a custom sprint, tab visits it before wrapping
+	m.sprints[customSprint] = createTestSprint("Sprint 30", "P\\Sprint 30", "2024-04-08", "2024-04-21")
+	m.sprintLists[customSprint] = &WorkItemList{attempted: true}
+	m.currentTab = nextSprint
+	m, _ = m.handleListViewNav(tea.KeyMsg{Type: tea.KeyTab})
+	if m.currentTab != customSprint {
+		t.Errorf("expected custom sprint tab, got %v", m.currentTab)
+	}
+	m, _ = m.handleListViewNav(tea.KeyMsg{Type: tea.KeyTab})
+	if m.currentTab != previousSprint {
+		t.Errorf("expected wrap to previous sprint, got %v", m.currentTab)
+	}
+}
+
+func TestSprintPickerOptions(t *testing.T) {
+	m := createSprintBrowserModel()
+
+	options := m.sprintPickerOptions()
+	if len(options) != 4 {
+		t.Fatalf("expected backlog + 3 sprints without a client, got %d", len(options))
+	}
+	for _, opt := range options {
+		if opt.browse {
+			t.Error("browse option requires a client")
+		}
+	}
+
+	m.client = NewDummyBackend()
+	m.sprints[customSprint] = createTestSprint("Sprint 30", "P\\Sprint 30", "2024-04-08", "2024-04-21")
+	options = m.sprintPickerOptions()
+	if len(options) != 6 {
+		t.Fatalf("expected 6 options, got %d", len(options))
+	}
+	if options[4].path != "P\\Sprint 30" {
+		t.Errorf("expected custom sprint option, got %+v", options[4])
+	}
+	if !options[5].browse {
+		t.Error("expected last option to open the sprint browser")
+	}
+}
+
+func TestHandleAllSprintsLoadedMsg_CursorOnCurrentTab(t *testing.T) {
+	m := createSprintBrowserModel()
+	m.state = sprintBrowserView
+	m.loading = true
+
+	sprints := []Sprint{
+		*createTestSprint("Sprint 22", "P\\Sprint 22", "2023-12-18", "2023-12-31"),
+		*m.sprints[previousSprint],
+		*m.sprints[currentSprint],
+		*m.sprints[nextSprint],
+	}
+	m, _ = m.handleAllSprintsLoadedMsg(allSprintsLoadedMsg{sprints: sprints})
+
+	if m.loading {
+		t.Error("expected loading to be cleared")
+	}
+	if m.browser.cursor != 2 {
+		t.Errorf("expected cursor on current sprint (2), got %d", m.browser.cursor)
+	}
+}
+
+func TestSprintBrowser_MoveReturnsToPicker(t *testing.T) {
+	m := createSprintBrowserModel()
+	m.state = sprintBrowserView
+	m.browser.forMove = true
+
+	m, _ = m.handleSprintBrowserView(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != sprintPickerView {
+		t.Errorf("expected sprint picker view, got %v", m.state)
+	}
+}
+
+func TestDummyBackend_GetAllSprints(t *testing.T) {
+	db := NewDummyBackend()
+
+	sprints, err := db.GetAllSprints(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sprints) <= 3 {
+		t.Fatalf("expected more than the adjacent sprints, got %d", len(sprints))
+	}
+
+	// Sprints are ordered by start date and include the current one
+	foundCurrent := false
+	for i, sprint := range sprints {
+		if sprint.Path == db.sprints.current.Path {
+			foundCurrent = true
+		}
+		if i > 0 && sprint.StartDate <= sprints[i-1].StartDate {
+			t.Errorf("sprint %s starts before %s", sprint.Name, sprints[i-1].Name)
+		}
+	}
+	if !foundCurrent {
+		t.Error("expected current sprint in the list")
+	}
+}
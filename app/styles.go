@@ -2,24 +2,6 @@ package main
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color palette constants
-const (
-	ColorPurple      = "62"
-	ColorWhite       = "230"
-	ColorGray        = "241"
-	ColorLightGray   = "251"
-	ColorDimGray     = "243"
-	ColorVeryDim     = "241"
-	ColorGreen       = "86"
-	ColorPink        = "212"
-	ColorPastelPink  = "218"
-	ColorBlue        = "39"
-	ColorBrightBlue  = "63"
-	ColorOrange      = "208"
-	ColorRed         = "196"
-	ColorBrightWhite = "255"
-)
-
 // Styles holds all the lipgloss styles used throughout the application
 type Styles struct {
 	// Selection and interaction styles
@@ -84,158 +66,171 @@ type Styles struct {
 	Key           lipgloss.Style
 	Desc          lipgloss.Style
 	SectionHeader lipgloss.Style
+
+	// Bars, splash screen and spinner
+	TitleBar lipgloss.Style
+	Banner   lipgloss.Style
+	Spinner  lipgloss.Style
+
+	// Theme the styles were built from
+	Theme Theme
 }
 
-// NewStyles creates and returns a Styles struct with all styles initialized
+// NewStyles creates and returns a Styles struct with all styles initialized for the default theme
 func NewStyles() Styles {
+	return NewThemeStyles(darkTheme)
+}
+
+// accented colors a style with the theme's accent, or reverses it when colors are off
+func (t Theme) accented(style lipgloss.Style) lipgloss.Style {
+	if t.NoColor {
+		return style.Reverse(true)
+	}
+	return style.Background(t.Accent).Foreground(t.AccentText)
+}
+
+// NewThemeStyles creates and returns a Styles struct with all styles initialized for a theme
+func NewThemeStyles(t Theme) Styles {
 	return Styles{
+		Theme: t,
+
 		// Selection and interaction
-		Selected: lipgloss.NewStyle().
-			Background(lipgloss.Color(ColorPurple)).
-			Foreground(lipgloss.Color(ColorWhite)).
+		Selected: t.accented(lipgloss.NewStyle()).
 			Bold(true),
 
 		Dim: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)),
+			Foreground(t.Muted),
 
 		// Tree structure
 		TreeEdge: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorBrightBlue)),
+			Foreground(t.TreeEdge),
 
-		TreeEdgeSelected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)),
+		TreeEdgeSelected: t.accented(lipgloss.NewStyle()),
 
 		Icon: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorPink)),
+			Foreground(t.Highlight),
 
-		IconSelected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)),
+		IconSelected: t.accented(lipgloss.NewStyle()),
 
 		// State styles
 		ProposedState: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorDimGray)),
+			Foreground(t.Proposed),
 
 		InProgressState: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)).
+			Foreground(t.InProgress).
 			Bold(true),
 
 		CompletedState: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorDimGray)).
+			Foreground(t.Completed).
 			Italic(true),
 
 		RemovedState: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorVeryDim)).
+			Foreground(t.Removed).
 			Italic(true),
 
 		// Item title styles
 		ItemTitleProposed: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorLightGray)),
+			Foreground(t.Subtle),
 
 		ItemTitleInProgress: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorBrightWhite)),
+			Foreground(t.Strong),
 
 		ItemTitleCompleted: lipgloss.NewStyle().
 			Strikethrough(true).
-			Foreground(lipgloss.Color(ColorDimGray)),
+			Foreground(t.Completed),
 
 		ItemTitleRemoved: lipgloss.NewStyle().
 			Strikethrough(true).
-			Foreground(lipgloss.Color(ColorVeryDim)),
+			Foreground(t.Removed),
 
 		// Mode and tab selectors
-		ActiveMode: lipgloss.NewStyle().
+		ActiveMode: t.accented(lipgloss.NewStyle()).
 			Bold(true).
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)).
 			Padding(0, 2).
 			MarginRight(1),
 
 		InactiveMode: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
+			Foreground(t.Secondary).
 			Padding(0, 2).
 			MarginRight(1),
 
-		ActiveTab: lipgloss.NewStyle().
+		ActiveTab: t.accented(lipgloss.NewStyle()).
 			Bold(true).
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)).
 			Padding(0, 2),
 
 		InactiveTab: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
+			Foreground(t.Secondary).
 			Padding(0, 2),
 
 		// UI elements
 		Hint: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorLightGray)).
+			Foreground(t.Subtle).
 			Italic(true),
 
 		StatusMsg: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)),
+			Foreground(t.Success),
 
 		Loader: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)).
+			Foreground(t.Success).
 			MarginLeft(2),
 
 		LoadMore: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)).
+			Foreground(t.Success).
 			Italic(true),
 
 		BatchIndicator: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorOrange)).
+			Foreground(t.Warning).
 			Bold(true),
 
 		// Help and messages
 		Help: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")),
+			Foreground(t.Secondary),
 
 		Separator: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")),
+			Foreground(t.Secondary),
 
 		Log: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
+			Foreground(t.Secondary).
 			Italic(true),
 
 		// Detail view
 		Card: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorPurple)).
+			BorderForeground(t.Accent).
 			Padding(1, 2),
 
 		Header: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color(ColorBlue)).
+			Foreground(t.Info).
 			MarginBottom(1),
 
 		Label: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)).
+			Foreground(t.Muted).
 			Width(15),
 
 		Value: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)),
+			Foreground(t.Text),
 
 		Section: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("205")).
+			Foreground(t.Section).
 			MarginTop(1).
 			MarginBottom(1),
 
 		Description: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorLightGray)).
+			Foreground(t.Subtle).
 			Italic(true).
 			MarginTop(1).
 			MarginBottom(1),
 
 		// Edit view
 		EditLabel: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)).
+			Foreground(t.Success).
 			Bold(true).
 			Width(15),
 
 		EditHelp: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGray)).
+			Foreground(t.Muted).
 			Italic(true),
 
 		EditSection: lipgloss.NewStyle().
@@ -244,43 +239,54 @@ func NewStyles() Styles {
 
 		// Error and warnings
 		Error: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorRed)).
+			Foreground(t.Error).
 			Bold(true).
 			MarginBottom(1),
 
 		Warning: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorRed)).
+			Foreground(t.Error).
 			Bold(true).
 			MarginBottom(1),
 
 		Detail: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)).
+			Foreground(t.Text).
 			PaddingLeft(2),
 
 		// Help view
 		Key: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorGreen)).
+			Foreground(t.Success).
 			Bold(true).
 			Width(20),
 
 		Desc: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)),
+			Foreground(t.Text),
 
 		SectionHeader: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color(ColorPurple)).
+			Foreground(t.Accent).
 			MarginTop(1).
 			MarginBottom(1),
+
+		// Bars, splash screen and spinner
+		TitleBar: lipgloss.NewStyle().
+			Background(t.Bar).
+			Foreground(t.AccentText).
+			Bold(true).
+			Padding(0, 1),
+
+		Banner: lipgloss.NewStyle().
+			Foreground(t.Highlight).
+			Bold(true),
+
+		Spinner: lipgloss.NewStyle().
+			Foreground(t.Section),
 	}
 }
 
 // GetItemTitleStyle returns the appropriate title style based on category and selection
 func (s Styles) GetItemTitleStyle(category string, isSelected bool, hasChildren bool) lipgloss.Style {
 	if isSelected {
-		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)).
-			Bold(true)
+		return s.Selected
 	}
 
 	var style lipgloss.Style
@@ -308,10 +314,7 @@ func (s Styles) GetItemTitleStyle(category string, isSelected bool, hasChildren
 // GetStateStyle returns the appropriate state style based on category and selection
 func (s Styles) GetStateStyle(category string, isSelected bool) lipgloss.Style {
 	if isSelected {
-		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)).
-			Bold(true)
+		return s.Selected
 	}
 
 	switch category {
@@ -330,9 +333,7 @@ func (s Styles) GetStateStyle(category string, isSelected bool) lipgloss.Style {
 
 // RenderTitleBar renders the title bar with the given title text and width
 func (s Styles) RenderTitleBar(title string, width int) string {
-	titleBarStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color(ColorPurple)).
-		Foreground(lipgloss.Color(ColorWhite)).
+	titleBarStyle := s.Theme.accented(lipgloss.NewStyle()).
 		Bold(true).
 		Width(width).
 		Padding(0, 1)
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is a color palette: UI roles and state categories mapped to colors
+// (ANSI-256 numbers like "62" or hex like "#5f5fd7")
+type Theme struct {
+	Name string
+
+	Accent     lipgloss.Color // Selection, active tabs, card borders, section headers
+	AccentText lipgloss.Color // Text on accent and bar backgrounds
+	Bar        lipgloss.Color // Title and config bar background
+	Text       lipgloss.Color // Values and descriptions
+	Strong     lipgloss.Color // Titles of items in progress
+	Subtle     lipgloss.Color // Titles of proposed items, hints
+	Muted      lipgloss.Color // Dimmed text and labels
+	Secondary  lipgloss.Color // Help, log and inactive tabs
+	Success    lipgloss.Color // Keys, status and loaders
+	Info       lipgloss.Color // Detail headers
+	TreeEdge   lipgloss.Color // Tree lines
+	Highlight  lipgloss.Color // Icons and the splash screen
+	Section    lipgloss.Color // Section titles and the spinner
+	Warning    lipgloss.Color // Batch selection and warnings
+	Error      lipgloss.Color // Errors
+
+	// State categories
+	Proposed   lipgloss.Color
+	InProgress lipgloss.Color
+	Completed  lipgloss.Color
+	Removed    lipgloss.Color
+
+	// NoColor renders without colors (NO_COLOR), using reverse video for selection
+	NoColor bool
+}
+
+// Built-in themes
+var (
+	darkTheme = Theme{
+		Name:       "dark",
+		Accent:     "62",
+		AccentText: "230",
+		Bar:        "241",
+		Text:       "230",
+		Strong:     "255",
+		Subtle:     "251",
+		Muted:      "241",
+		Secondary:  "245",
+		Success:    "86",
+		Info:       "39",
+		TreeEdge:   "63",
+		Highlight:  "212",
+		Section:    "205",
+		Warning:    "208",
+		Error:      "196",
+		Proposed:   "243",
+		InProgress: "86",
+		Completed:  "243",
+		Removed:    "241",
+	}
+
+	lightTheme = Theme{
+		Name:       "light",
+		Accent:     "62",
+		AccentText: "255",
+		Bar:        "245",
+		Text:       "235",
+		Strong:     "232",
+		Subtle:     "238",
+		Muted:      "244",
+		Secondary:  "242",
+		Success:    "28",
+		Info:       "25",
+		TreeEdge:   "62",
+		Highlight:  "162",
+		Section:    "161",
+		Warning:    "166",
+		Error:      "160",
+		Proposed:   "240",
+		InProgress: "28",
+		Completed:  "246",
+		Removed:    "248",
+	}
+
+	highContrastTheme = Theme{
+		Name:       "high-contrast",
+		Accent:     "21",
+		AccentText: "231",
+		Bar:        "236",
+		Text:       "231",
+		Strong:     "231",
+		Subtle:     "231",
+		Muted:      "250",
+		Secondary:  "252",
+		Success:    "46",
+		Info:       "51",
+		TreeEdge:   "51",
+		Highlight:  "201",
+		Section:    "226",
+		Warning:    "214",
+		Error:      "196",
+		Proposed:   "231",
+		InProgress: "46",
+		Completed:  "248",
+		Removed:    "245",
+	}
+)
+
+// builtinThemes are the themes selectable by name
+var builtinThemes = map[string]Theme{
+	darkTheme.Name:         darkTheme,
+	lightTheme.Name:        lightTheme,
+	highContrastTheme.Name: highContrastTheme,
+}
+
+// autoTheme picks dark or light from the terminal background
+const autoTheme = "auto"
+
+// ThemePalette is a user-defined theme in config.yaml: a base theme and the colors it changes
+type ThemePalette struct {
+	Base   string            `yaml:"base,omitempty"`
+	Colors map[string]string `yaml:"colors,omitempty"`
+}
+
+// themeRoles maps config color names to theme fields
+func themeRoles(t *Theme) map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"accent":      &t.Accent,
+		"accent_text": &t.AccentText,
+		"bar":         &t.Bar,
+		"text":        &t.Text,
+		"strong":      &t.Strong,
+		"subtle":      &t.Subtle,
+		"muted":       &t.Muted,
+		"secondary":   &t.Secondary,
+		"success":     &t.Success,
+		"info":        &t.Info,
+		"tree_edge":   &t.TreeEdge,
+		"highlight":   &t.Highlight,
+		"section":     &t.Section,
+		"warning":     &t.Warning,
+		"error":       &t.Error,
+		"proposed":    &t.Proposed,
+		"in_progress": &t.InProgress,
+		"completed":   &t.Completed,
+		"removed":     &t.Removed,
+	}
+}
+
+// hexColor matches #rgb and #rrggbb colors
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether a color is an ANSI-256 number or a hex color
+func validColor(color string) bool {
+	if hexColor.MatchString(color) {
+		return true
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// resolveTheme returns the named theme: a built-in, a palette from config, or "auto"
+// (also the default) for dark or light depending on the terminal background.
+// darkBackground is only called for auto, since detecting the background queries the terminal.
+func resolveTheme(name string, palettes map[string]ThemePalette, darkBackground func() bool) (Theme, error) {
+	return resolveNamedTheme(name, palettes, darkBackground, make(map[string]bool))
+}
+
+func resolveNamedTheme(name string, palettes map[string]ThemePalette, darkBackground func() bool, seen map[string]bool) (Theme, error) {
+	if name == "" || name == autoTheme {
+		if darkBackground() {
+			return darkTheme, nil
+		}
+		return lightTheme, nil
+	}
+
+	palette, ok := palettes[name]
+	if !ok || seen[name] {
+		// A palette named after a built-in extends that built-in
+		if theme, ok := builtinThemes[name]; ok {
+			return theme, nil
+		}
+		if seen[name] {
+			return Theme{}, fmt.Errorf("theme %q: base themes form a cycle", name)
+		}
+		return Theme{}, fmt.Errorf("unknown theme %q (built-in: auto, dark, light, high-contrast)", name)
+	}
+	seen[name] = true
+
+	base := palette.Base
+	if _, ok := builtinThemes[name]; ok && base == "" {
+		base = name
+	}
+	theme, err := resolveNamedTheme(base, palettes, darkBackground, seen)
+	if err != nil {
+		return Theme{}, err
+	}
+	theme.Name = name
+
+	roles := themeRoles(&theme)
+	keys := make([]string, 0, len(palette.Colors))
+	for key := range palette.Colors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		role, ok := roles[key]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %q: unknown color %q", name, key)
+		}
+		color := strings.TrimSpace(palette.Colors[key])
+		if !validColor(color) {
+			return Theme{}, fmt.Errorf("theme %q: invalid color %q for %s (use 0-255 or #rrggbb)", name, color, key)
+		}
+		*role = lipgloss.Color(color)
+	}
+	return theme, nil
+}
+
+// themeFromConfig resolves the configured theme for the current terminal.
+// NO_COLOR switches off colors whatever the theme.
+func themeFromConfig(config *Config) (Theme, error) {
+	var name string
+	var palettes map[string]ThemePalette
+	if config != nil {
+		name, palettes = config.Theme, config.Themes
+	}
+
+	theme, err := resolveTheme(name, palettes, termenv.HasDarkBackground)
+	if err != nil {
+		return darkTheme, err
+	}
+	if termenv.EnvNoColor() {
+		theme.NoColor = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	return theme, nil
+}
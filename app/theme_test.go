@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveTheme(t *testing.T) {
+	palettes := map[string]ThemePalette{
+		"ocean":   {Base: "light", Colors: map[string]string{"accent": "#005f87", "in_progress": "31"}},
+		"dark":    {Colors: map[string]string{"accent": "99"}},
+		"deep":    {Base: "ocean", Colors: map[string]string{"error": "124"}},
+		"loop":    {Base: "loop"},
+		"bad-key": {Colors: map[string]string{"background": "0"}},
+		"bad-hex": {Colors: map[string]string{"accent": "#12345"}},
+		"too-big": {Colors: map[string]string{"accent": "256"}},
+	}
+
+	tests := []struct {
+		name       string
+		dark       bool
+		wantName   string
+		wantAccent lipgloss.Color
+		wantErr    string
+	}{
+		{"", true, "dark", darkTheme.Accent, ""},
+		{"auto", false, "light", lightTheme.Accent, ""},
+		{"high-contrast", true, "high-contrast", highContrastTheme.Accent, ""},
+		{"ocean", true, "ocean", "#005f87", ""},
+		{"dark", true, "dark", "99", ""},
+		{"deep", true, "deep", "#005f87", ""},
+		{"solarized", true, "", "", `unknown theme "solarized"`},
+		{"loop", true, "", "", "cycle"},
+		{"bad-key", true, "", "", `unknown color "background"`},
+		{"bad-hex", true, "", "", `invalid color "#12345"`},
+		{"too-big", true, "", "", `invalid color "256"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := resolveTheme(tt.name, palettes, func() bool { return tt.dark })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if theme.Name != tt.wantName || theme.Accent != tt.wantAccent {
+				t.Errorf("got theme %q with accent %q, want %q with %q", theme.Name, theme.Accent, tt.wantName, tt.wantAccent)
+			}
+		})
+	}
+
+	// Palettes keep the colors of their base they don't change
+	deep, _ := resolveTheme("deep", palettes, func() bool { return true })
+	if deep.InProgress != "31" || deep.Error != "124" || deep.Text != lightTheme.Text {
+		t.Errorf("unexpected inherited colors: %+v", deep)
+	}
+}
+
+func TestResolveThemeOnlyDetectsBackgroundForAuto(t *testing.T) {
+	detect := func() bool {
+		t.Error("background detected for a named theme")
+		return true
+	}
+	if _, err := resolveTheme("light", nil, detect); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestThemeConfigFromYAML(t *testing.T) {
+	input := `theme: mine
+themes:
+  mine:
+    base: high-contrast
+    colors:
+      accent: "#ff8700"
+      completed: 240
+`
+	var config Config
+	if err := yaml.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateUISettings(&config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config.Themes["mine"].Colors["warning"] = "orange"
+	if err := validateUISettings(&config); err == nil || !strings.Contains(err.Error(), "warning") {
+		t.Errorf("expected invalid color to be reported, got %v", err)
+	}
+}
+
+func TestNewThemeStylesNoColor(t *testing.T) {
+	theme := darkTheme
+	theme.NoColor = true
+	styles := NewThemeStyles(theme)
+
+	if !styles.Selected.GetReverse() {
+		t.Error("expected selection to use reverse video without colors")
+	}
+	if _, ok := styles.Selected.GetBackground().(lipgloss.NoColor); !ok {
+		t.Errorf("expected no selection background, got %v", styles.Selected.GetBackground())
+	}
+
+	styles = NewThemeStyles(darkTheme)
+	if styles.Selected.GetReverse() || styles.Selected.GetBackground() != darkTheme.Accent {
+		t.Error("expected accent background with colors")
+	}
+}
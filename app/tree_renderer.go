@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // renderLoadMoreItem renders the "Load More" / spinner line for the list view.
@@ -64,38 +67,78 @@ func (m model) renderTreeItemList(treeItem TreeItem, isSelected bool, isBatchSel
 
 	// Category + styles
 	category := m.getStateCategory(treeItem.WorkItem.State)
-	stateStyle := m.styles.GetStateStyle(category, isSelected)
 	titleStyle := m.styles.GetItemTitleStyle(category, isSelected, len(treeItem.WorkItem.Children) > 0)
-	stateText := stateStyle.Render(treeItem.WorkItem.State)
-	titleText := titleStyle.Render(treeItem.WorkItem.Title)
+	if m.filter.active && m.filter.contextIDs[treeItem.WorkItem.ID] && !isSelected {
+		titleStyle = m.styles.Dim
+	}
+
+	// Columns and title share what's left of the line after the tree prefix; 0 when the width is unknown
+	available := 0
+	if m.ui.width > 0 {
+		// batch indicator, cursor, space, prefix, icon, space, and a margin against wrapping
+		available = max(0, m.ui.width-len([]rune(prefixRaw))-6-1)
+	}
+	columns := fitListColumns(m.listColumnNames(), available)
+
+	title := treeItem.WorkItem.Title
+	if available > 0 {
+		titleWidth := available
+		for _, column := range columns {
+			titleWidth -= column.width + 1
+		}
+		title = padText(title, titleWidth)
+	}
+	titleText := titleStyle.Render(title)
+
+	space := " "
+	if isSelected {
+		space = m.styles.Selected.Render(" ")
+	}
+	var columnsText strings.Builder
+	for _, column := range columns {
+		value := listColumns[column.name].value(treeItem.WorkItem)
+		if available > 0 {
+			value = padText(value, column.width)
+		} else if value == "" {
+			continue
+		}
+		columnsText.WriteString(space + m.listColumnStyle(column.name, category, isSelected).Render(value))
+	}
 
 	if isSelected {
 		// Apply background to cursor spacing for visual consistency
 		cursorStyled := m.styles.Selected.Render(cursor)
-		spacer := m.styles.Selected.Render(" ")
 		return fmt.Sprintf("%s%s%s%s%s%s%s%s",
 			batchIndicator,
 			cursorStyled,
-			spacer,
+			space,
 			treePrefix,
 			iconStyled,
-			spacer,
+			space,
 			titleText,
-			spacer+stateText,
+			columnsText.String(),
 		)
 	}
 
 	// Non-selected formatting
-	return fmt.Sprintf("%s%s %s%s %s %s",
+	return fmt.Sprintf("%s%s %s%s %s%s",
 		batchIndicator,
 		cursor,
 		treePrefix,
 		iconStyled,
 		titleText,
-		stateText,
+		columnsText.String(),
 	)
 }
 
+// listColumnStyle returns the style of a list column: the state follows its category, the rest is dimmed
+func (m model) listColumnStyle(name, category string, isSelected bool) lipgloss.Style {
+	if name == "state" || isSelected {
+		return m.styles.GetStateStyle(category, isSelected)
+	}
+	return m.styles.Dim
+}
+
 // renderTreeItemFilter renders a tree item line for the filter view (simpler – no batch indicator).
 func (m model) renderTreeItemFilter(treeItem TreeItem, isSelected bool) string {
 	cursor := "  "
@@ -122,6 +165,11 @@ func (m model) renderTreeItemFilter(treeItem TreeItem, isSelected bool) string {
 	category := m.getStateCategory(treeItem.WorkItem.State)
 	stateStyle := m.styles.GetStateStyle(category, isSelected)
 	titleStyle := m.styles.GetItemTitleStyle(category, isSelected, len(treeItem.WorkItem.Children) > 0)
+	// Ancestors that only provide tree context are dimmed
+	if m.filter.contextIDs[treeItem.WorkItem.ID] && !isSelected {
+		titleStyle = m.styles.Dim
+		stateStyle = m.styles.Dim
+	}
 	titleText := titleStyle.Render(treeItem.WorkItem.Title)
 	stateText := stateStyle.Render(treeItem.WorkItem.State)
 
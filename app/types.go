@@ -27,6 +27,17 @@ const (
 	sprintPickerView
 	moveChildrenConfirmView
 	configWizardView
+	burndownView
+	sprintBrowserView
+	boardView
+	historyView
+	attachmentsView
+	exportView
+	importView
+	importConfirmView
+	yankView
+	stateFieldsView
+	batchSummaryView
 )
 
 type appMode int
@@ -42,6 +53,7 @@ const (
 	previousSprint sprintTab = iota
 	currentSprint
 	nextSprint
+	customSprint // Any other iteration, opened from the sprint browser
 )
 
 type backlogTab int
@@ -51,6 +63,15 @@ const (
 	abandonedWork
 )
 
+// detailsPurpose is what a work item details load was started for
+type detailsPurpose int
+
+const (
+	detailsForSelected detailsPurpose = iota // The item opened in the detail view
+	detailsForFilter                         // Descriptions and comments searched by the filter
+	detailsForExport                         // Descriptions of the items being exported
+)
+
 type Sprint struct {
 	Name      string
 	Path      string
@@ -94,18 +115,42 @@ type DeleteState struct {
 
 // BatchState contains state for batch operations
 type BatchState struct {
-	selectedItems  map[int]bool // Set of selected work item IDs
-	operationCount int          // Track pending batch operations
+	selectedItems  map[int]WorkItem // Selected work items by ID, as they were when selected
+	operationCount int              // Track pending single-item operations
+	job            batchJob         // Operation of the running or last batch, kept to retry failures
+	running        bool             // A batch is in progress
+	total          int              // Items in the batch
+	done           int              // Items finished, successfully or not
+	failures       []batchFailure
 }
 
 // FilterState contains state for filtering and finding
 type FilterState struct {
 	filteredTasks []WorkItem
+	contextIDs    map[int]bool // Ancestors shown only to keep matches in their tree
+	err           error        // Parse error of the current filter query
 	active        bool
+	loadingDetail bool      // Fetching descriptions and comments the query searches
+	detailLoads   loadScope // Detail fetch for the query, superseded once the query no longer needs it
 	filterInput   textinput.Model
 	findInput     textinput.Model
 }
 
+// StateChangeState contains state for a state change and the fields it requires
+type StateChangeState struct {
+	fromStates  []string            // Current states of the items being changed
+	itemIDs     []int               // Items being changed
+	newState    string              // Target state
+	itemTypes   map[int]string      // Work item type of each item being changed
+	fields      []RequiredField     // Fields the process requires for the change
+	fieldTypes  map[string][]string // Work item types requiring each field, keyed by reference name
+	fieldIndex  int                 // Field currently being prompted for
+	valueCursor int                 // Selected allowed value of the current field
+	values      map[string]string   // Collected values keyed by field reference name
+	input       textinput.Model     // Input for fields without allowed values
+	err         error               // Rule violation or prompt error shown inline
+}
+
 // SprintMoveState contains state for sprint move operation
 type SprintMoveState struct {
 	targetPath      string     // Target sprint path
@@ -126,6 +171,77 @@ type WizardState struct {
 	err          string // Validation error message
 }
 
+// BurndownState contains state for the sprint burndown chart
+type BurndownState struct {
+	sprint  *Sprint                    // Sprint the chart is drawn for
+	history map[int][]WorkItemRevision // Revision history of the sprint's work items
+	metric  burndownMetric             // Which series is plotted
+}
+
+// SprintBrowserState contains state for browsing all team iterations
+type SprintBrowserState struct {
+	sprints      []Sprint // All team iterations, in team order
+	cursor       int
+	scrollOffset int
+	forMove      bool // true when picking a target sprint for the selected items
+}
+
+// BoardState contains state for the kanban board view
+type BoardState struct {
+	typeStates map[string][]string // Valid states per work item type on the board
+	column     int                 // Selected column
+	row        int                 // Selected card within the column
+	fromBoard  bool                // Detail view was opened from the board, so going back returns to it
+}
+
+// HistoryState contains state for the work item history pane
+type HistoryState struct {
+	workItemID int              // Work item the history belongs to
+	updates    []WorkItemUpdate // Revisions, oldest first
+}
+
+// attachmentsMode is what the attachments pane is currently doing
+type attachmentsMode int
+
+const (
+	browseAttachments attachmentsMode = iota
+	downloadAttachment
+	uploadAttachment
+)
+
+// AttachmentsState contains state for the work item attachments pane
+type AttachmentsState struct {
+	cursor      int             // Selected attachment
+	mode        attachmentsMode // Browsing, or prompting for a download/upload path
+	pathInput   textinput.Model // Path prompt for downloads and uploads
+	completions []string        // Candidates from the last tab completion
+}
+
+// ExportState contains state for the export menu
+type ExportState struct {
+	formatCursor int             // Selected entry of exportFormats
+	pathMode     bool            // Prompting for the file to write
+	pathInput    textinput.Model // Export file path
+	completions  []string        // Candidates from the last tab completion
+	loading      bool            // Fetching descriptions of the exported items
+}
+
+// ImportState contains state for importing an outline or CSV
+type ImportState struct {
+	pathInput     textinput.Model // Import file path
+	completions   []string        // Candidates from the last tab completion
+	path          string          // File the preview was parsed from
+	items         []importItem    // Parsed items awaiting confirmation
+	iterationPath string          // Sprint the items are created in, unless they name one
+}
+
+// YankState contains state for the yank (copy) menu
+type YankState struct {
+	cursor      int         // Selected entry of yankFormats
+	items       []*WorkItem // Items being copied: the batch selection or the current item
+	returnState viewState   // View to return to when the menu closes
+}
+
 type model struct {
 	// Configuration
 	config       *Config
@@ -155,18 +271,33 @@ type model struct {
 	currentTab        sprintTab
 	currentBacklogTab backlogTab
 	sprints           map[sprintTab]*Sprint
-	initialLoading    int // Count of initial sprint loads pending
+	initialLoading    int       // Count of initial sprint loads pending
+	branchWorkItemID  int       // Work item of the git branch at startup, selected once sprints load
+	currentUser       string    // Display name of the signed-in user, used by assignee:me filters
+	listLoads         loadScope // Loads of the lists on screen, superseded when the tab, mode or filter changes
+	viewLoads         loadScope // Loads of the open view (states, sprint history, item history), superseded when it closes
+	work              loadScope // Changes the user started (batches, imports, state changes), cancelled with esc
 
 	// Grouped state
-	ui         UIState
-	edit       EditState
-	create     CreateState
-	delete     DeleteState
-	batch      BatchState
-	filter     FilterState
-	sprintMove SprintMoveState
-	wizard     WizardState
+	ui          UIState
+	edit        EditState
+	create      CreateState
+	delete      DeleteState
+	batch       BatchState
+	filter      FilterState
+	stateChange StateChangeState
+	sprintMove  SprintMoveState
+	wizard      WizardState
+	burndown    BurndownState
+	browser     SprintBrowserState
+	board       BoardState
+	history     HistoryState
+	attachments AttachmentsState
+	export      ExportState
+	imports     ImportState
+	yank        YankState
 
 	// UI styles
 	styles Styles // Centralized styles for the application
+	keys   Keymap // Active keybindings
 }
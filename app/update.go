@@ -13,8 +13,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle quit when there's an error
 	if m.err != nil {
 		if msg, ok := msg.(tea.KeyMsg); ok {
-			switch msg.String() {
-			case "q", "ctrl+c":
+			if m.isQuitKey(msg) {
 				return m, tea.Quit
 			}
 		}
@@ -50,6 +49,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleFindView(msg)
 		case statePickerView:
 			return m.handleStatePickerView(msg)
+		case stateFieldsView:
+			return m.handleStateFieldsView(msg)
 		case sprintPickerView:
 			return m.handleSprintPickerView(msg)
 		case batchEditMenuView:
@@ -64,7 +65,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleMoveChildrenConfirmView(msg)
 		case configWizardView:
 			return m.handleConfigWizardView(msg)
+		case burndownView:
+			return m.handleBurndownView(msg)
+		case sprintBrowserView:
+			return m.handleSprintBrowserView(msg)
+		case boardView:
+			return m.handleBoardView(msg)
+		case historyView:
+			return m.handleHistoryView(msg)
+		case attachmentsView:
+			return m.handleAttachmentsView(msg)
+		case exportView:
+			return m.handleExportView(msg)
+		case importView:
+			return m.handleImportView(msg)
+		case importConfirmView:
+			return m.handleImportConfirmView(msg)
+		case yankView:
+			return m.handleYankView(msg)
+		case batchSummaryView:
+			return m.handleBatchSummaryView(msg)
 		case listView:
+			// esc cancels a running batch; items not sent yet are reported as cancelled
+			if m.batch.running && msg.String() == "esc" {
+				m.cancelWork()
+				return m, nil
+			}
 			// Try global hotkeys first
 			newModel, cmd, handled := m.handleGlobalHotkeys(msg)
 			if handled {
@@ -94,9 +120,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case statesLoadedMsg:
 		return m.handleStatesLoadedMsg(msg)
 
+	case requiredFieldsLoadedMsg:
+		return m.handleRequiredFieldsLoadedMsg(msg)
+
 	case workItemRefreshedMsg:
 		return m.handleWorkItemRefreshedMsg(msg)
 
+	case workItemDetailsLoadedMsg:
+		return m.handleWorkItemDetailsLoadedMsg(msg)
+
 	case workItemCreatedMsg:
 		return m.handleWorkItemCreatedMsg(msg)
 
@@ -106,9 +138,60 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case sprintUpdatedMsg:
 		return m.handleSprintUpdatedMsg(msg)
 
+	case batchProgressMsg:
+		return m.handleBatchProgressMsg(msg)
+
+	case batchFinishedMsg:
+		return m.handleBatchFinishedMsg(msg)
+
 	case sprintsLoadedMsg:
 		return m.handleSprintsLoadedMsg(msg)
 
+	case boardStatesLoadedMsg:
+		return m.handleBoardStatesLoadedMsg(msg)
+
+	case boardCardMovedMsg:
+		return m.handleBoardCardMovedMsg(msg)
+
+	case historyLoadedMsg:
+		return m.handleHistoryLoadedMsg(msg)
+
+	case exportDoneMsg:
+		return m.handleExportDoneMsg(msg)
+
+	case yankDoneMsg:
+		return m.handleYankDoneMsg(msg)
+
+	case importParsedMsg:
+		return m.handleImportParsedMsg(msg)
+
+	case importDoneMsg:
+		return m.handleImportDoneMsg(msg)
+
+	case branchCheckedOutMsg:
+		return m.handleBranchCheckedOutMsg(msg)
+
+	case attachmentDownloadedMsg:
+		return m.handleAttachmentDownloadedMsg(msg)
+
+	case attachmentUploadedMsg:
+		return m.handleAttachmentUploadedMsg(msg)
+
+	case allSprintsLoadedMsg:
+		return m.handleAllSprintsLoadedMsg(msg)
+
+	case sprintHistoryLoadedMsg:
+		return m.handleSprintHistoryLoadedMsg(msg)
+
+	case stateCategoriesLoadedMsg:
+		return m.handleStateCategoriesLoadedMsg(msg)
+
+	case currentUserLoadedMsg:
+		return m.handleCurrentUserLoadedMsg(msg)
+
+	case backendChangedMsg:
+		return m.handleBackendChangedMsg(msg)
+
 	case spinner.TickMsg:
 		if m.loading || m.loadingMore {
 			m.spinner, cmd = m.spinner.Update(msg)
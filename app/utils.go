@@ -23,12 +23,42 @@ func max(a, b int) int {
 	return b
 }
 
-// openInBrowser opens the work item in a browser
-func openInBrowser(orgURL, project string, workItemID int) error {
+// truncateText shortens text to at most width runes, ending with an ellipsis when cut
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 1 {
+		return string(runes[:max(0, width)])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// workItemURL returns the web URL of a work item
+func workItemURL(orgURL, project string, workItemID int) string {
 	// Clean up org URL
 	orgURL = strings.TrimSuffix(orgURL, "/")
 
-	url := fmt.Sprintf("%s/%s/_workitems/edit/%d", orgURL, project, workItemID)
+	return fmt.Sprintf("%s/%s/_workitems/edit/%d", orgURL, project, workItemID)
+}
+
+// itemWebURL returns the web URL of a work item in the backend the config selects
+func itemWebURL(config *Config, workItemID int) string {
+	if config == nil {
+		return workItemURL("", "", workItemID)
+	}
+	switch config.Backend {
+	case githubBackend:
+		return fmt.Sprintf("%s/%s/%s/issues/%d", githubWebURL(config.GitHub.APIURL), config.GitHub.Owner, config.GitHub.Repo, workItemID)
+	case localBackend:
+		return localItemURL(config.Local.Path, workItemID)
+	}
+	return workItemURL(config.OrganizationURL, config.Project, workItemID)
+}
+
+// openInBrowser opens a URL in the default browser
+func openInBrowser(url string) error {
 
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
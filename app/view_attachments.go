@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderAttachmentsView renders the attachments of the selected work item
+func (m model) renderAttachmentsView() string {
+	var content strings.Builder
+
+	titleText := "Attachments"
+	if m.selectedTask != nil {
+		titleText = fmt.Sprintf("Attachments #%d - %s", m.selectedTask.ID, m.selectedTask.Title)
+	}
+	content.WriteString(m.renderTitleBar(titleText))
+
+	var attachments []Attachment
+	if m.selectedTask != nil {
+		attachments = m.selectedTask.Attachments
+	}
+
+	if len(attachments) == 0 {
+		content.WriteString("  No attachments. Press u to attach a file.\n")
+	} else {
+		nameWidth := 0
+		for _, attachment := range attachments {
+			nameWidth = max(nameWidth, len([]rune(attachment.Name)))
+		}
+		nameWidth = min(nameWidth, max(20, m.ui.width-40))
+
+		for i, attachment := range attachments {
+			cursor := " "
+			if m.attachments.cursor == i {
+				cursor = ">"
+			}
+			name := truncateText(attachment.Name, nameWidth)
+			line := fmt.Sprintf("%s %-*s  %9s", cursor, nameWidth, name, formatFileSize(attachment.Size))
+			if attachment.AddedDate != "" {
+				line += "  " + formatDateTime(attachment.AddedDate)
+			}
+			if m.attachments.cursor == i {
+				line = m.styles.Selected.Render(line)
+			}
+			content.WriteString(line + "\n")
+
+			if attachment.Comment != "" {
+				content.WriteString(m.styles.Dim.Render("    "+truncateText(attachment.Comment, max(10, m.ui.width-6))) + "\n")
+			}
+		}
+	}
+
+	content.WriteString("\n")
+	switch m.attachments.mode {
+	case downloadAttachment:
+		content.WriteString("  Save to: " + m.attachments.pathInput.View() + "\n")
+	case uploadAttachment:
+		content.WriteString("  Attach file: " + m.attachments.pathInput.View() + "\n")
+	}
+	if len(m.attachments.completions) > 0 {
+		content.WriteString(m.styles.Dim.Render("  "+strings.Join(m.attachments.completions, "  ")) + "\n")
+	}
+
+	if m.loading {
+		content.WriteString(m.styles.Loader.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.statusMessage)) + "\n")
+	} else if m.statusMessage != "" {
+		content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	}
+
+	keybindings := "↑/↓ or j/k: navigate • enter/d: download • u: attach file • esc: back to details • q: quit"
+	if m.attachments.mode != browseAttachments {
+		keybindings = "tab: complete path • enter: confirm • esc: cancel"
+	}
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
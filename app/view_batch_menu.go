@@ -23,7 +23,7 @@ func (m model) renderBatchEditMenuView() string {
 	maxDisplay := 5 // Limit display to avoid cluttering the screen
 
 	for _, task := range tasks {
-		if m.batch.selectedItems[task.ID] {
+		if m.batch.isSelected(task.ID) {
 			selectedCount++
 			if selectedCount <= maxDisplay {
 				itemText := fmt.Sprintf("#%d: %s", task.ID, task.Title)
@@ -55,16 +55,13 @@ func (m model) renderBatchEditMenuView() string {
 
 	for i, opt := range options {
 		cursor := "  "
-		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorLightGray))
+		nameStyle := lipgloss.NewStyle().Foreground(m.styles.Theme.Subtle)
 		descStyle := m.styles.Dim
 
 		if i == m.stateCursor {
 			cursor = "→ "
 			// Highlight the selected option name with purple background
-			nameStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorWhite)).
-				Background(lipgloss.Color(ColorPurple)).
-				Bold(true)
+			nameStyle = m.styles.Selected
 		}
 
 		content.WriteString(fmt.Sprintf("%s%s\n",
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderBatchSummaryView renders the items a batch could not update and why
+func (m model) renderBatchSummaryView() string {
+	var content strings.Builder
+
+	failed := len(m.batch.failures)
+	content.WriteString(m.renderTitleBar(fmt.Sprintf("%s %d of %d items", m.batch.job.done, m.batch.total-failed, m.batch.total)))
+
+	content.WriteString(m.styles.Warning.Render(fmt.Sprintf("%d item(s) failed", failed)) + "\n\n")
+
+	titles := make(map[int]string)
+	for _, task := range m.getVisibleTasks() {
+		titles[task.ID] = task.Title
+	}
+
+	for _, failure := range m.batch.failures {
+		itemText := fmt.Sprintf("#%d", failure.workItemID)
+		if title := titles[failure.workItemID]; title != "" {
+			itemText += ": " + title
+		}
+		if len(itemText) > 60 {
+			itemText = itemText[:57] + "..."
+		}
+		content.WriteString("  • " + itemText + "\n")
+		content.WriteString(m.styles.Error.Render("    "+batchErrorText(failure.err)) + "\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString("  " + m.styles.Key.Render("[r]") + " Retry the failed items\n")
+	content.WriteString("  " + m.styles.Key.Render("[esc]") + " Back to the list\n")
+
+	content.WriteString(m.renderFooter("r: retry failed • esc: close"))
+
+	return content.String()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderBoardView renders the current list as a board with one column per state
+func (m model) renderBoardView() string {
+	var content strings.Builder
+
+	content.WriteString(m.renderTitleBar("Board"))
+	if hint := m.getTabHint(); hint != "" {
+		content.WriteString(m.styles.Hint.Render(hint) + "\n\n")
+	}
+
+	if m.loading {
+		content.WriteString(m.styles.Loader.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.statusMessage)) + "\n")
+	} else if m.statusMessage != "" {
+		content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	}
+
+	columns := m.getBoardColumns()
+	if len(columns) == 0 {
+		content.WriteString("  No tasks found.\n")
+	} else {
+		content.WriteString(m.renderBoardColumns(columns) + "\n")
+	}
+
+	keybindings := "←/→ or h/l: column • ↑/↓ or j/k: card • H/L: move card left/right\nenter: details • esc: back to list • q: quit"
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
+
+// renderBoardColumns lays out as many columns as fit the terminal width, scrolled to keep the selected column visible
+func (m model) renderBoardColumns(columns []boardColumn) string {
+	const minColumnWidth = 22
+	available := max(minColumnWidth, m.ui.width-4)
+
+	visible := max(1, min(len(columns), available/(minColumnWidth+1)))
+	colWidth := available/visible - 1
+
+	first := 0
+	if m.board.column >= visible {
+		first = m.board.column - visible + 1
+	}
+	last := min(len(columns), first+visible)
+
+	// Each card takes 3 lines (meta, title, spacing); title, hint, headers and footer take about 14
+	maxCards := max(1, (m.ui.height-14)/3)
+
+	var rendered []string
+	for i := first; i < last; i++ {
+		rendered = append(rendered, m.renderBoardColumn(columns[i], i == m.board.column, colWidth, maxCards))
+		if i < last-1 {
+			rendered = append(rendered, " ")
+		}
+	}
+
+	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	if first > 0 || last < len(columns) {
+		board += "\n" + m.styles.Dim.Render(fmt.Sprintf("  columns %d-%d of %d", first+1, last, len(columns)))
+	}
+	return "  " + strings.ReplaceAll(board, "\n", "\n  ")
+}
+
+// renderBoardColumn renders a single state column with its header and cards
+func (m model) renderBoardColumn(col boardColumn, isSelectedColumn bool, width, maxCards int) string {
+	var lines []string
+
+	header := truncateText(fmt.Sprintf("%s (%d)", col.state, len(col.cards)), width)
+	lines = append(lines, m.styles.GetStateStyle(col.category, false).Bold(true).Render(header))
+	lines = append(lines, m.styles.TreeEdge.Render(strings.Repeat("─", width)))
+
+	// Scroll the selected column so its selected card stays visible
+	start := 0
+	if isSelectedColumn && m.board.row >= maxCards {
+		start = m.board.row - maxCards + 1
+	}
+	end := min(len(col.cards), start+maxCards)
+
+	if start > 0 {
+		lines = append(lines, m.styles.Dim.Render(fmt.Sprintf("↑ %d more", start)))
+	}
+	for i := start; i < end; i++ {
+		card := col.cards[i]
+		isSelected := isSelectedColumn && i == m.board.row
+
+		meta := fmt.Sprintf("#%d", card.ID)
+		if card.Priority > 0 {
+			meta += fmt.Sprintf(" · P%d", card.Priority)
+		}
+		meta = truncateText(getWorkItemIcon(card.WorkItemType)+" "+meta, width)
+		title := truncateText(card.Title, width)
+
+		if isSelected {
+			lines = append(lines, m.styles.Selected.Width(width).Render(meta))
+			lines = append(lines, m.styles.Selected.Width(width).Render(title))
+		} else {
+			lines = append(lines, m.styles.Dim.Render(meta))
+			lines = append(lines, m.styles.GetItemTitleStyle(col.category, false, false).Render(title))
+		}
+		lines = append(lines, "")
+	}
+	if end < len(col.cards) {
+		lines = append(lines, m.styles.Dim.Render(fmt.Sprintf("↓ %d more", len(col.cards)-end)))
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
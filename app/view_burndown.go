@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// renderBurndownView renders the sprint burndown/burnup chart
+func (m model) renderBurndownView() string {
+	var content strings.Builder
+
+	sprint := m.burndown.sprint
+	titleText := "Sprint Burndown"
+	if sprint != nil {
+		titleText = fmt.Sprintf("Sprint Burndown - %s", sprint.Name)
+	}
+	content.WriteString(m.renderTitleBar(titleText))
+
+	// Metric selector
+	var tabs []string
+	for metric := burndownMetric(0); metric < burndownMetricCount; metric++ {
+		if metric == m.burndown.metric {
+			tabs = append(tabs, m.styles.ActiveTab.Render(metric.String()))
+		} else {
+			tabs = append(tabs, m.styles.InactiveTab.Render(metric.String()))
+		}
+	}
+	content.WriteString(strings.Join(tabs, " ") + "\n\n")
+
+	if m.loading {
+		content.WriteString(fmt.Sprintf("  %s %s\n", m.spinner.View(), m.statusMessage))
+	} else if m.burndown.history == nil {
+		if m.statusMessage != "" {
+			content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+		}
+	} else {
+		points, err := buildBurndownSeries(sprint, m.burndown.history, m.burndown.metric, m.getStateCategory, time.Now())
+		if err != nil {
+			content.WriteString(m.styles.Error.Render(fmt.Sprintf("  %v", err)) + "\n")
+		} else if len(points) == 0 {
+			content.WriteString(m.styles.Dim.Render("  Sprint hasn't started yet") + "\n")
+		} else {
+			// Title bar, tabs, axis, summary and footer take roughly 12 lines
+			chartHeight := m.ui.height - 12
+			if chartHeight < 5 {
+				chartHeight = 5
+			}
+			chartWidth := m.ui.width - 4
+			if chartWidth < 20 {
+				chartWidth = 20
+			}
+
+			chart := renderBurndownChart(points, sprintDayCount(sprint), m.burndown.metric, chartWidth, chartHeight)
+			for _, line := range strings.Split(chart, "\n") {
+				content.WriteString("  " + m.styles.InProgressState.Render(line) + "\n")
+			}
+			content.WriteString("\n")
+			content.WriteString(m.renderBurndownSummary(points, sprint) + "\n")
+		}
+	}
+
+	keybindings := "tab: switch metric • r: refresh • esc: back"
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
+
+// renderBurndownSummary renders the latest values of the chart and the days left in the sprint
+func (m model) renderBurndownSummary(points []burndownPoint, sprint *Sprint) string {
+	last := points[len(points)-1]
+	daysLeft := sprintDayCount(sprint) - len(points)
+
+	var parts []string
+	switch m.burndown.metric {
+	case remainingItemsMetric:
+		parts = append(parts, fmt.Sprintf("Remaining: %s of %s items", formatChartValue(last.Value), formatChartValue(last.Scope)))
+	case remainingWorkMetric:
+		parts = append(parts, fmt.Sprintf("Remaining: %sh of %sh", formatChartValue(last.Value), formatChartValue(last.Scope)))
+	case completedItemsMetric:
+		parts = append(parts, fmt.Sprintf("Completed: %s of %s items", formatChartValue(last.Value), formatChartValue(last.Scope)))
+	}
+	if scopeChange := last.Scope - points[0].Scope; scopeChange != 0 {
+		parts = append(parts, fmt.Sprintf("Scope change: %+g", scopeChange))
+	}
+	parts = append(parts, fmt.Sprintf("Days left: %d", daysLeft))
+
+	return m.styles.Label.Render("  " + strings.Join(parts, " • "))
+}
@@ -23,14 +23,14 @@ func (m model) renderConfigWizardView() string {
 	// Check if there's an existing config and show warning
 	if hasExistingConfig {
 		warningStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorOrange)).
+			Foreground(m.styles.Theme.Warning).
 			Bold(true)
 		content.WriteString(warningStyle.Render("⚠  Existing configuration will be overwritten") + "\n\n")
 	}
 
 	// Instructions
 	instructionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorLightGray)).
+		Foreground(m.styles.Theme.Subtle).
 		Italic(true)
 	content.WriteString(instructionStyle.Render("Configure your Azure DevOps connection") + "\n\n")
 
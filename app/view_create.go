@@ -58,6 +58,15 @@ func (m model) renderCreateView() string {
 		} else {
 			tabs = append(tabs, m.styles.InactiveTab.Render(nextLabel))
 		}
+
+		// Sprint opened from the sprint browser
+		if sprint := m.sprints[customSprint]; sprint != nil {
+			if m.currentTab == customSprint {
+				tabs = append(tabs, m.styles.ActiveTab.Render(sprint.Name))
+			} else {
+				tabs = append(tabs, m.styles.InactiveTab.Render(sprint.Name))
+			}
+		}
 	} else if m.currentMode == backlogMode {
 		if m.currentBacklogTab == recentBacklog {
 			tabs = append(tabs, m.styles.ActiveTab.Render("Recent Backlog"))
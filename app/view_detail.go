@@ -21,7 +21,7 @@ func (m model) renderDetailView() string {
 	content.WriteString("\n")
 
 	// Footer with keybindings
-	keybindings := "←/h/esc: back • r: refresh • e: edit • o: open in browser • s: change state • ?: help • q: quit"
+	keybindings := m.keys.hints(actionBack, actionRefresh, actionEdit, actionOpenInBrowser, actionYank, actionChangeState, actionHistory, actionAttachments, actionHelp, actionQuit)
 	content.WriteString(m.renderFooter(keybindings))
 
 	return content.String()
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderExportView renders the export menu: format choice and destination prompt
+func (m model) renderExportView() string {
+	var content strings.Builder
+
+	content.WriteString(m.renderTitleBar(fmt.Sprintf("Export %s (%d items)", m.exportTitle(), len(m.getVisibleTreeItems()))))
+
+	content.WriteString("  Format:\n\n")
+	for i, format := range exportFormats {
+		line := fmt.Sprintf("  %s", format)
+		if m.export.formatCursor == i {
+			line = m.styles.Selected.Render(fmt.Sprintf("> %s", format))
+		}
+		content.WriteString("  " + line + "\n")
+	}
+
+	if exportFormats[m.export.formatCursor] == csvExport {
+		columns := defaultExportColumns
+		if m.config != nil && len(m.config.ExportColumns) > 0 {
+			columns = m.config.ExportColumns
+		}
+		content.WriteString("\n" + m.styles.Dim.Render("  Columns: "+strings.Join(columns, ", ")) + "\n")
+	}
+
+	content.WriteString("\n")
+	if m.export.pathMode {
+		content.WriteString("  Save to: " + m.export.pathInput.View() + "\n")
+		if len(m.export.completions) > 0 {
+			content.WriteString(m.styles.Dim.Render("  "+strings.Join(m.export.completions, "  ")) + "\n")
+		}
+	}
+
+	if m.statusMessage != "" {
+		content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	}
+
+	keybindings := "↑/↓ or j/k: format • enter: save to file • c: copy to clipboard • esc: back"
+	if m.export.pathMode {
+		keybindings = "tab: complete path • enter: save • esc: cancel"
+	}
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
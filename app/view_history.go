@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// prepareHistoryViewport builds the history timeline and sets it in the viewport
+func (m model) prepareHistoryViewport() model {
+	// Title bar, item header, and footer take ~9 lines
+	height := m.ui.height - 9
+	if height < 10 {
+		height = 10
+	}
+
+	m.viewport.Width = m.ui.width
+	m.viewport.Height = height
+	m.viewport.SetContent(m.buildHistoryContent())
+	m.viewport.GotoTop()
+	return m
+}
+
+// buildHistoryContent renders the revisions newest first, one field change per line
+func (m model) buildHistoryContent() string {
+	if len(m.history.updates) == 0 {
+		return "  No history found.\n"
+	}
+
+	var content strings.Builder
+
+	if timeline := stateTimeline(m.history.updates); timeline != "" {
+		content.WriteString(m.styles.Label.Width(12).Render("  States:") + m.styles.Value.Render(timeline) + "\n")
+	}
+
+	// Field names like "Resolved Reason" don't fit the default label width
+	fieldLabel := m.styles.Label.Width(18)
+	valueWidth := max(10, (m.ui.width-30)/2)
+	for i := len(m.history.updates) - 1; i >= 0; i-- {
+		update := m.history.updates[i]
+		if len(update.Changes) == 0 {
+			continue
+		}
+
+		changedBy := update.ChangedBy
+		if changedBy == "" {
+			changedBy = "Unknown"
+		}
+		header := fmt.Sprintf("Rev %d · %s · %s %s", update.Rev, changedBy, formatDateTime(update.ChangedDate), getRelativeTime(update.ChangedDate))
+		content.WriteString("\n" + m.styles.SectionHeader.UnsetMargins().Render("  "+header) + "\n")
+
+		for _, change := range update.Changes {
+			oldValue := truncateText(strings.ReplaceAll(change.OldValue, "\n", " "), valueWidth)
+			newValue := truncateText(strings.ReplaceAll(change.NewValue, "\n", " "), valueWidth)
+
+			var diff string
+			switch {
+			case oldValue == "":
+				diff = m.styles.InProgressState.Render(newValue)
+			case newValue == "":
+				diff = m.styles.Dim.Render(oldValue) + " → " + m.styles.Dim.Render("(cleared)")
+			default:
+				diff = m.styles.Dim.Render(oldValue) + " → " + m.styles.InProgressState.Render(newValue)
+			}
+			content.WriteString("    " + fieldLabel.Render(change.Field+":") + diff + "\n")
+		}
+	}
+
+	return content.String()
+}
+
+// renderHistoryView renders the revision history of the selected work item
+func (m model) renderHistoryView() string {
+	var content strings.Builder
+
+	titleText := "Work Item History"
+	if m.selectedTask != nil {
+		titleText = fmt.Sprintf("History #%d - %s", m.selectedTask.ID, m.selectedTask.Title)
+	}
+	content.WriteString(m.renderTitleBar(titleText))
+
+	if m.loading {
+		content.WriteString(m.styles.Loader.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.statusMessage)) + "\n")
+	} else if m.statusMessage != "" {
+		content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	} else {
+		content.WriteString(m.viewport.View() + "\n")
+	}
+
+	keybindings := "↑/↓ or j/k: scroll • esc: back to details • q: quit"
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
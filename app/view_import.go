@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// renderImportView renders the file prompt of the import
+func (m model) renderImportView() string {
+	var content strings.Builder
+
+	content.WriteString(m.renderTitleBar("Import Work Items"))
+
+	content.WriteString("  Create work items from a Markdown outline (nested bullets) or a CSV with a header row.\n")
+	content.WriteString(m.styles.Dim.Render("  Outline: indent to nest, optional \"Bug: \" type prefix, trailing #tags") + "\n")
+	content.WriteString(m.styles.Dim.Render("  CSV: title, type, tags, parent_id, id, iteration_path, priority, description") + "\n\n")
+
+	content.WriteString("  File: " + m.imports.pathInput.View() + "\n")
+	if len(m.imports.completions) > 0 {
+		content.WriteString(m.styles.Dim.Render("  "+strings.Join(m.imports.completions, "  ")) + "\n")
+	}
+
+	if m.statusMessage != "" {
+		content.WriteString("\n" + m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	}
+
+	content.WriteString(m.renderFooter("tab: complete path • enter: preview • esc: cancel"))
+
+	return content.String()
+}
+
+// renderImportConfirmView renders the dry-run preview of the items an import will create
+func (m model) renderImportConfirmView() string {
+	var content strings.Builder
+
+	items := m.imports.items
+	content.WriteString(m.renderTitleBar(fmt.Sprintf("Import %s?", filepath.Base(m.imports.path))))
+
+	target := "the project's default iteration"
+	if m.imports.iterationPath != "" {
+		target = m.imports.iterationPath
+	}
+	content.WriteString(fmt.Sprintf("Creating %d work item(s) in %s\n\n", len(items), target))
+
+	content.WriteString(m.styles.Section.Render("Items to be created:") + "\n\n")
+	for _, treeItem := range importPreviewTree(items) {
+		content.WriteString(m.renderImportPreviewItem(treeItem, items[treeItem.WorkItem.ID-1]))
+	}
+	content.WriteString("\n")
+
+	if m.loading {
+		content.WriteString(m.styles.Loader.Render(m.spinner.View()+" "+m.statusMessage) + "\n\n")
+	} else {
+		content.WriteString("  " + m.styles.Key.Render("[y]") + " Create these items\n")
+		content.WriteString("  " + m.styles.Key.Render("[esc]") + " Choose another file\n\n")
+	}
+
+	if m.loading {
+		content.WriteString(m.renderFooter("esc: cancel"))
+	} else {
+		content.WriteString(m.renderFooter("y: create • esc: back"))
+	}
+
+	return content.String()
+}
+
+// renderImportPreviewItem renders one line of the preview tree with the type and details of the item
+func (m model) renderImportPreviewItem(treeItem TreeItem, item importItem) string {
+	title := item.Title
+	if len(title) > 60 {
+		title = title[:57] + "..."
+	}
+
+	var details []string
+	if item.ParentID != nil {
+		details = append(details, fmt.Sprintf("under #%d", *item.ParentID))
+	}
+	if item.Tags != "" {
+		details = append(details, "tags: "+item.Tags)
+	}
+	if item.IterationPath != "" {
+		details = append(details, item.IterationPath)
+	}
+	if item.Priority > 0 {
+		details = append(details, fmt.Sprintf("P%d", item.Priority))
+	}
+	detailText := ""
+	if len(details) > 0 {
+		detailText = " " + m.styles.Dim.Render("("+strings.Join(details, ", ")+")")
+	}
+
+	return fmt.Sprintf("  %s%s %s%s\n",
+		m.styles.TreeEdge.Render(getTreePrefix(treeItem)),
+		m.styles.Label.Render("["+item.WorkItemType+"]"),
+		title,
+		detailText,
+	)
+}
@@ -13,7 +13,7 @@ func (m model) renderListView() string {
 	// Title bar
 	title := "Hippo - ADO Task Management"
 	if m.filter.active {
-		title += fmt.Sprintf(" (filtered: %d results)", len(m.filter.filteredTasks))
+		title += fmt.Sprintf(" (filtered: %d results)", m.filterMatchCount())
 	}
 	content.WriteString(m.renderTitleBar(title))
 
@@ -61,6 +61,15 @@ func (m model) renderListView() string {
 		} else {
 			tabs = append(tabs, m.styles.InactiveTab.Render(nextLabel))
 		}
+
+		// Sprint opened from the sprint browser
+		if sprint := m.sprints[customSprint]; sprint != nil {
+			if m.currentTab == customSprint {
+				tabs = append(tabs, m.styles.ActiveTab.Render(sprint.Name))
+			} else {
+				tabs = append(tabs, m.styles.InactiveTab.Render(sprint.Name))
+			}
+		}
 	} else if m.currentMode == backlogMode {
 		// Backlog mode tabs
 		if m.currentBacklogTab == recentBacklog {
@@ -90,10 +99,18 @@ func (m model) renderListView() string {
 	// Show loader if loading (but not loadingMore and not initial loading)
 	// During initial loading (m.initialLoading > 0), we want to show the list view without a spinner
 	if m.loading && !m.loadingMore && m.initialLoading == 0 {
-		content.WriteString(m.styles.Loader.Render(fmt.Sprintf("%s %s", m.spinner.View(), m.statusMessage)) + "\n\n")
+		loaderText := m.statusMessage
+		if m.batch.running {
+			loaderText = m.batchProgressText()
+		}
+		content.WriteString(m.styles.Loader.Render(fmt.Sprintf("%s %s", m.spinner.View(), loaderText)) + "\n\n")
 
 		// Footer with keybindings
-		keybindings := "tab: cycle tabs • →/l: details • ↑/↓ or j/k: navigate\nenter: details • o: open in browser • /: search • f: filter • r: refresh • q: quit"
+		keybindings := m.keys.hints(actionNextTab, actionOpen, actionUp, actionDown) + "\n" +
+			m.keys.hints(actionOpenInBrowser, actionFilter, actionFind, actionRefresh, actionQuit)
+		if m.batch.running {
+			keybindings += " • esc: cancel"
+		}
 		content.WriteString(m.renderFooter(keybindings))
 
 		return content.String()
@@ -136,7 +153,7 @@ func (m model) renderListView() string {
 
 			treeItem := treeItems[i]
 			isSelected := m.ui.cursor == i
-			isBatchSelected := m.batch.selectedItems[treeItem.WorkItem.ID]
+			isBatchSelected := m.batch.isSelected(treeItem.WorkItem.ID)
 
 			line := m.renderTreeItemList(treeItem, isSelected, isBatchSelected)
 			content.WriteString(line + "\n")
@@ -148,7 +165,8 @@ func (m model) renderListView() string {
 	if len(m.batch.selectedItems) > 0 {
 		batchInfo = fmt.Sprintf(" • %d items selected", len(m.batch.selectedItems))
 	}
-	keybindings := fmt.Sprintf("tab: cycle tabs • space: select/deselect%s\ni: insert • d: delete • e: edit • enter: details • o: open • /: filter • f: find • r: refresh • ?: help • q: quit", batchInfo)
+	keybindings := m.keys.hints(actionNextTab, actionToggleSelect) + batchInfo + "\n" +
+		m.keys.hints(actionInsert, actionDelete, actionEdit, actionOpen, actionOpenInBrowser, actionYank, actionFilter, actionFind, actionRefresh, actionHelp, actionQuit)
 	content.WriteString(m.renderFooter(keybindings))
 
 	return content.String()
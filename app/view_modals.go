@@ -23,7 +23,7 @@ func (m model) renderStatePickerView() string {
 		maxDisplay := 3 // Limit display to avoid cluttering the screen
 
 		for _, task := range tasks {
-			if m.batch.selectedItems[task.ID] {
+			if m.batch.isSelected(task.ID) {
 				selectedCount++
 				if selectedCount <= maxDisplay {
 					itemText := fmt.Sprintf("#%d: %s", task.ID, task.Title)
@@ -44,7 +44,11 @@ func (m model) renderStatePickerView() string {
 		content.WriteString("\n")
 	}
 
-	content.WriteString("  Select new state:\n\n")
+	if len(m.stateChange.fromStates) > 0 {
+		content.WriteString(fmt.Sprintf("  Select new state (allowed from %s):\n\n", strings.Join(m.stateChange.fromStates, ", ")))
+	} else {
+		content.WriteString("  Select new state:\n\n")
+	}
 
 	for i, state := range m.availableStates {
 		cursor := " "
@@ -61,6 +65,10 @@ func (m model) renderStatePickerView() string {
 		content.WriteString(line + "\n")
 	}
 
+	if m.stateChange.err != nil {
+		content.WriteString("\n" + m.styles.Error.Render("  "+m.stateChange.err.Error()) + "\n")
+	}
+
 	// Footer with keybindings
 	keybindings := "↑/↓ or j/k: navigate • ctrl+u/d or pgup/pgdn: page up/down • enter: select • esc: cancel"
 	content.WriteString(m.renderFooter(keybindings))
@@ -74,15 +82,21 @@ func (m model) renderFilterView() string {
 	// Title bar
 	titleText := "Filter"
 	content.WriteString(m.renderTitleBar(titleText))
-	content.WriteString(m.filter.filterInput.View() + "\n\n")
+	content.WriteString(m.filter.filterInput.View() + "\n")
+	if m.filter.err != nil {
+		content.WriteString(m.styles.Error.Render("  "+m.filter.err.Error()) + "\n")
+	} else {
+		content.WriteString(m.styles.Dim.Render("  e.g. state:active tag:backend p:<=2 assignee:me \"login page\" -tag:blocked") + "\n")
+	}
+	content.WriteString("\n")
 
 	treeItems := m.getVisibleTreeItems()
 	resultCount := len(treeItems)
 
-	// Show result count
+	// Show result count; ancestors kept for context are not results
 	currentTasks := m.getCurrentTasks()
 	if m.filter.filterInput.Value() != "" {
-		content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  %d/%d", resultCount, len(currentTasks))) + "\n\n")
+		content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  %d/%d", m.filterMatchCount(), len(currentTasks))) + "\n\n")
 	} else {
 		content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  %d items", len(currentTasks))) + "\n\n")
 	}
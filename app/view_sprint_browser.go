@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// renderSprintBrowserView renders the scrollable list of all team sprints
+func (m model) renderSprintBrowserView() string {
+	var content strings.Builder
+
+	titleText := "All Sprints"
+	if m.browser.forMove {
+		titleText = fmt.Sprintf("Move to Sprint (%d items)", len(m.batch.selectedItems))
+	}
+	content.WriteString(m.renderTitleBar(titleText))
+
+	if m.loading {
+		content.WriteString(fmt.Sprintf("  %s %s\n", m.spinner.View(), m.statusMessage))
+	} else if m.statusMessage != "" {
+		content.WriteString(m.styles.Error.Render("  "+m.statusMessage) + "\n")
+	} else if len(m.browser.sprints) == 0 {
+		content.WriteString("  No sprints found for this team.\n")
+	} else {
+		// Width of the longest name, so dates line up
+		nameWidth := 0
+		for _, sprint := range m.browser.sprints {
+			nameWidth = max(nameWidth, len(sprint.Name))
+		}
+
+		today := time.Now().Format("2006-01-02")
+		start := m.browser.scrollOffset
+		end := min(len(m.browser.sprints), start+m.getSprintBrowserHeight())
+
+		if start > 0 {
+			content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+		}
+		for i := start; i < end; i++ {
+			sprint := m.browser.sprints[i]
+
+			cursor := " "
+			if m.browser.cursor == i {
+				cursor = ">"
+			}
+
+			dates := "no dates"
+			if sprint.StartDate != "" && sprint.EndDate != "" {
+				dates = fmt.Sprintf("%s to %s", sprint.StartDate, sprint.EndDate)
+			}
+			line := fmt.Sprintf("%s %-*s  %s", cursor, nameWidth, sprint.Name, dates)
+			if label := m.sprintTabLabel(sprint.Path); label != "" {
+				line += "  (" + label + ")"
+			}
+
+			switch {
+			case m.browser.cursor == i:
+				line = m.styles.Selected.Render(line)
+			case sprint.EndDate != "" && sprint.EndDate < today:
+				// Past sprints are dimmed
+				line = m.styles.Dim.Render(line)
+			}
+			content.WriteString(line + "\n")
+		}
+		if end < len(m.browser.sprints) {
+			content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  ↓ %d more", len(m.browser.sprints)-end)) + "\n")
+		}
+	}
+
+	keybindings := "↑/↓ or j/k: navigate • enter: open as tab • esc: back"
+	if m.browser.forMove {
+		keybindings = "↑/↓ or j/k: navigate • enter: move here • esc: back"
+	}
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
+
+// sprintTabLabel returns which sprint tab shows the given path, if any
+func (m model) sprintTabLabel(path string) string {
+	labels := map[sprintTab]string{
+		previousSprint: "previous",
+		currentSprint:  "current",
+		nextSprint:     "next",
+		customSprint:   "open",
+	}
+	for _, tab := range []sprintTab{previousSprint, currentSprint, nextSprint, customSprint} {
+		if sprint := m.sprints[tab]; sprint != nil && sprint.Path == path {
+			return labels[tab]
+		}
+	}
+	return ""
+}
@@ -24,7 +24,7 @@ func (m model) renderSprintPickerView() string {
 		maxDisplay := 3 // Limit display to avoid cluttering the screen
 
 		for _, task := range tasks {
-			if m.batch.selectedItems[task.ID] {
+			if m.batch.isSelected(task.ID) {
 				selectedCount++
 				if selectedCount <= maxDisplay {
 					itemText := fmt.Sprintf("#%d: %s", task.ID, task.Title)
@@ -56,45 +56,7 @@ func (m model) renderSprintPickerView() string {
 	content.WriteString("  Select target sprint:\n\n")
 
 	// Build sprint options list
-	options := []struct {
-		name string
-		path string
-	}{
-		{"Backlog (no sprint)", ""},
-	}
-
-	// Add previous sprint if available
-	if m.sprints[previousSprint] != nil {
-		options = append(options, struct {
-			name string
-			path string
-		}{
-			name: fmt.Sprintf("Previous Sprint - %s", m.sprints[previousSprint].Name),
-			path: m.sprints[previousSprint].Path,
-		})
-	}
-
-	// Add current sprint if available
-	if m.sprints[currentSprint] != nil {
-		options = append(options, struct {
-			name string
-			path string
-		}{
-			name: fmt.Sprintf("Current Sprint - %s", m.sprints[currentSprint].Name),
-			path: m.sprints[currentSprint].Path,
-		})
-	}
-
-	// Add next sprint if available
-	if m.sprints[nextSprint] != nil {
-		options = append(options, struct {
-			name string
-			path string
-		}{
-			name: fmt.Sprintf("Next Sprint - %s", m.sprints[nextSprint].Name),
-			path: m.sprints[nextSprint].Path,
-		})
-	}
+	options := m.sprintPickerOptions()
 
 	// Render options
 	for i, opt := range options {
@@ -107,6 +69,8 @@ func (m model) renderSprintPickerView() string {
 
 		if m.stateCursor == i {
 			line = m.styles.Selected.Render(line)
+		} else if opt.browse {
+			line = m.styles.Dim.Render(line)
 		}
 
 		content.WriteString(line + "\n")
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderStateFieldsView renders the prompts for fields a state change requires
+func (m model) renderStateFieldsView() string {
+	var content strings.Builder
+
+	change := m.stateChange
+	content.WriteString(m.renderTitleBar(fmt.Sprintf("Change State → %s", change.newState)))
+	content.WriteString(m.styles.Section.Render(fmt.Sprintf("%s requires:", change.newState)) + "\n\n")
+
+	for i, field := range change.fields {
+		switch {
+		case i < change.fieldIndex:
+			content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  ✓ %s: %s", field.Name, change.values[field.ReferenceName])) + "\n")
+		case i > change.fieldIndex:
+			content.WriteString(m.styles.Dim.Render("    "+field.Name) + "\n")
+		case len(field.AllowedValues) > 0:
+			content.WriteString("  " + m.styles.Key.Render(field.Name) + "\n")
+			for j, value := range field.AllowedValues {
+				line := "    " + value
+				if j == change.valueCursor {
+					line = m.styles.Selected.Render("  > " + value)
+				}
+				content.WriteString(line + "\n")
+			}
+		default:
+			content.WriteString("  " + m.styles.Key.Render(field.Name) + "\n")
+			content.WriteString("  " + change.input.View() + "\n")
+		}
+	}
+
+	if change.err != nil {
+		content.WriteString("\n" + m.styles.Error.Render("  "+change.err.Error()) + "\n")
+	}
+
+	keybindings := "enter: confirm • esc: back to states"
+	if field := change.fields[change.fieldIndex]; len(field.AllowedValues) > 0 {
+		keybindings = "↑/↓ or j/k: navigate • " + keybindings
+	}
+	content.WriteString(m.renderFooter(keybindings))
+
+	return content.String()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderYankView renders the yank menu with a preview of each format
+func (m model) renderYankView() string {
+	var content strings.Builder
+
+	title := fmt.Sprintf("Copy %d Selected Items", len(m.yank.items))
+	if len(m.yank.items) == 1 {
+		title = fmt.Sprintf("Copy #%d", m.yank.items[0].ID)
+	}
+	content.WriteString(m.renderTitleBar(title))
+
+	for i, format := range yankFormats {
+		// Preview the first line of what gets copied
+		preview, _, _ := strings.Cut(m.yankText(format), "\n")
+		if len(m.yank.items) > 1 {
+			preview += fmt.Sprintf(" (+%d more)", len(m.yank.items)-1)
+		}
+		preview = truncateText(preview, 70)
+
+		label := fmt.Sprintf("%s  %-14s", m.styles.Key.Render(format.key()), format)
+		line := fmt.Sprintf("  %s %s", label, m.styles.Dim.Render(preview))
+		if m.yank.cursor == i {
+			line = m.styles.Selected.Render("> ") + label + " " + preview
+		}
+		content.WriteString("  " + line + "\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString(m.renderFooter("↑/↓ or j/k: select • enter/y: copy • i/u/t/m: copy format • esc: back"))
+
+	return content.String()
+}
@@ -15,12 +15,12 @@ func (m model) View() string {
 		var content strings.Builder
 
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(m.styles.Theme.Error).
 			Bold(true).
 			Padding(1, 2)
 
 		hintStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(m.styles.Theme.Muted).
 			Padding(0, 2)
 
 		content.WriteString("\n")
@@ -41,6 +41,8 @@ func (m model) View() string {
 		return m.renderDetailView()
 	case statePickerView:
 		return m.renderStatePickerView()
+	case stateFieldsView:
+		return m.renderStateFieldsView()
 	case sprintPickerView:
 		return m.renderSprintPickerView()
 	case batchEditMenuView:
@@ -61,8 +63,28 @@ func (m model) View() string {
 		return m.renderDeleteConfirmView()
 	case moveChildrenConfirmView:
 		return m.renderMoveChildrenConfirmView()
+	case batchSummaryView:
+		return m.renderBatchSummaryView()
 	case configWizardView:
 		return m.renderConfigWizardView()
+	case burndownView:
+		return m.renderBurndownView()
+	case sprintBrowserView:
+		return m.renderSprintBrowserView()
+	case boardView:
+		return m.renderBoardView()
+	case historyView:
+		return m.renderHistoryView()
+	case attachmentsView:
+		return m.renderAttachmentsView()
+	case exportView:
+		return m.renderExportView()
+	case importView:
+		return m.renderImportView()
+	case importConfirmView:
+		return m.renderImportConfirmView()
+	case yankView:
+		return m.renderYankView()
 	default:
 		return m.renderListView()
 	}
@@ -110,15 +132,13 @@ func (m model) renderLoadingScreen() string {
 	}
 
 	// Style for text
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("212")).
-		Bold(true).
+	titleStyle := m.styles.Banner.
 		Width(m.ui.width).
 		Align(lipgloss.Center)
 
 	// Style the ASCII art
 	artStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("212")).
+		Foreground(m.styles.Theme.Highlight).
 		Width(m.ui.width).
 		Align(lipgloss.Center)
 
@@ -157,12 +177,8 @@ func (m model) renderLogLine() string {
 
 // renderTitleBar renders the title bar with the given title text
 func (m model) renderTitleBar(title string) string {
-	titleBarStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color(ColorGray)).
-		Foreground(lipgloss.Color(ColorWhite)).
-		Bold(true).
-		Width(m.ui.width).
-		Padding(0, 1)
+	titleBarStyle := m.styles.TitleBar.
+		Width(m.ui.width)
 
 	// Calculate padding to align version to the right
 	versionText := Version
@@ -227,6 +243,16 @@ func (m model) renderConfigBar() string {
 		parts = append(parts, fmt.Sprintf("Team:%s", m.config.Team))
 	}
 
+	// GitHub repository
+	if m.config.Backend == githubBackend {
+		parts = append(parts, fmt.Sprintf("Repo:%s/%s", m.config.GitHub.Owner, m.config.GitHub.Repo))
+	}
+
+	// Local work item directory
+	if m.config.Backend == localBackend {
+		parts = append(parts, fmt.Sprintf("Dir:%s", m.config.Local.Path))
+	}
+
 	// Source information
 	sourceInfo := buildSourceInfo(m.configSource)
 	if sourceInfo != "" {
@@ -239,11 +265,9 @@ func (m model) renderConfigBar() string {
 
 	configInfo := strings.Join(parts, " • ")
 
-	configBarStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color(ColorGray)).
-		Foreground(lipgloss.Color(ColorWhite)).
-		Width(m.ui.width).
-		Padding(0, 1)
+	configBarStyle := m.styles.TitleBar.
+		UnsetBold().
+		Width(m.ui.width)
 
 	return "\n" + configBarStyle.Render(configInfo)
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// WIQL BUILDER
+// =============================================================================
+
+// wiqlMacro is a WIQL macro such as @Me, written into queries without quoting
+type wiqlMacro string
+
+const (
+	wiqlMe               wiqlMacro = "@Me"
+	wiqlToday            wiqlMacro = "@Today"
+	wiqlProject          wiqlMacro = "@Project"
+	wiqlCurrentIteration wiqlMacro = "@CurrentIteration"
+)
+
+// wiqlDaysAgo returns the @Today macro offset by a number of days
+func wiqlDaysAgo(days int) wiqlMacro {
+	return wiqlMacro(fmt.Sprintf("%s - %d", wiqlToday, days))
+}
+
+// wiqlCondition is a single escaped condition of a WHERE clause; the zero value is skipped
+type wiqlCondition string
+
+// wiqlQuery builds a WIQL query from escaped conditions joined with AND
+type wiqlQuery struct {
+	fields     []string
+	conditions []wiqlCondition
+	ordering   []string
+}
+
+// newWIQLQuery starts a query selecting the given fields, System.Id by default
+func newWIQLQuery(fields ...string) *wiqlQuery {
+	if len(fields) == 0 {
+		fields = []string{"System.Id"}
+	}
+	return &wiqlQuery{fields: fields}
+}
+
+// where adds conditions that must all hold; empty conditions are ignored
+func (q *wiqlQuery) where(conditions ...wiqlCondition) *wiqlQuery {
+	for _, condition := range conditions {
+		if condition != "" {
+			q.conditions = append(q.conditions, condition)
+		}
+	}
+	return q
+}
+
+// orderBy sorts the results by a field
+func (q *wiqlQuery) orderBy(field string, descending bool) *wiqlQuery {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+	q.ordering = append(q.ordering, wiqlField(field)+" "+direction)
+	return q
+}
+
+// String renders the query
+func (q *wiqlQuery) String() string {
+	fields := make([]string, len(q.fields))
+	for i, field := range q.fields {
+		fields[i] = wiqlField(field)
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT " + strings.Join(fields, ", ") + "\nFROM WorkItems")
+	for i, condition := range q.conditions {
+		if i == 0 {
+			b.WriteString("\nWHERE ")
+		} else {
+			b.WriteString("\nAND ")
+		}
+		b.WriteString(string(condition))
+	}
+	if len(q.ordering) > 0 {
+		b.WriteString("\nORDER BY " + strings.Join(q.ordering, ", "))
+	}
+	return b.String()
+}
+
+// wiqlCompare compares a field with a value using a WIQL operator such as =, <>, >= or UNDER
+func wiqlCompare(field string, op string, value interface{}) wiqlCondition {
+	return wiqlCondition(wiqlField(field) + " " + op + " " + wiqlValue(value))
+}
+
+// wiqlEq matches items whose field equals the value
+func wiqlEq(field string, value interface{}) wiqlCondition {
+	return wiqlCompare(field, "=", value)
+}
+
+// wiqlNotEq matches items whose field differs from the value
+func wiqlNotEq(field string, value interface{}) wiqlCondition {
+	return wiqlCompare(field, "<>", value)
+}
+
+// wiqlEver matches items whose field equals the value in any revision, not only the latest
+func wiqlEver(field string, value interface{}) wiqlCondition {
+	return wiqlCondition("EVER " + string(wiqlEq(field, value)))
+}
+
+// wiqlNotIn matches items whose field is none of the values. An empty list excludes
+// nothing, so it yields no condition.
+func wiqlNotIn[T string | int](field string, values []T) wiqlCondition {
+	if len(values) == 0 {
+		return ""
+	}
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = wiqlValue(value)
+	}
+	return wiqlCondition(wiqlField(field) + " NOT IN (" + strings.Join(literals, ", ") + ")")
+}
+
+// wiqlAny matches items satisfying at least one of the conditions
+func wiqlAny(conditions ...wiqlCondition) wiqlCondition {
+	return wiqlGroup("OR", conditions)
+}
+
+// wiqlAll matches items satisfying every one of the conditions, grouped so it can be
+// combined with wiqlAny
+func wiqlAll(conditions ...wiqlCondition) wiqlCondition {
+	return wiqlGroup("AND", conditions)
+}
+
+// wiqlGroup joins the non-empty conditions with the operator in parentheses
+func wiqlGroup(op string, conditions []wiqlCondition) wiqlCondition {
+	var parts []string
+	for _, condition := range conditions {
+		if condition != "" {
+			parts = append(parts, string(condition))
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return wiqlCondition(parts[0])
+	}
+	return wiqlCondition("(" + strings.Join(parts, " "+op+" ") + ")")
+}
+
+// wiqlField brackets a field reference name
+func wiqlField(field string) string {
+	return "[" + field + "]"
+}
+
+// wiqlValue renders a value as a WIQL literal. Strings are single-quoted with embedded
+// quotes doubled, dates are written as quoted days and macros are left as they are.
+func wiqlValue(value interface{}) string {
+	switch v := value.(type) {
+	case wiqlMacro:
+		return string(v)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return wiqlString(v.Format("2006-01-02"))
+	case string:
+		return wiqlString(v)
+	}
+	return wiqlString(fmt.Sprint(value))
+}
+
+// wiqlString quotes a string literal, doubling embedded single quotes
+func wiqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWIQLValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"plain string", "Active", "'Active'"},
+		{"empty string", "", "''"},
+		{"apostrophes are doubled", "O'Brien's Sprint", "'O''Brien''s Sprint'"},
+		{"only a quote", "'", "''''"},
+		{"already doubled quotes", "it''s", "'it''''s'"},
+		{"backslashes are literal", `Project\Sprint 1`, `'Project\Sprint 1'`},
+		{"injection attempt stays inside the literal", "x' OR [System.Id] > '0", "'x'' OR [System.Id] > ''0'"},
+		{"integer", 42, "42"},
+		{"negative integer", -7, "-7"},
+		{"date", time.Date(2024, 3, 9, 15, 4, 5, 0, time.UTC), "'2024-03-09'"},
+		{"macro is not quoted", wiqlMe, "@Me"},
+		{"days ago macro", wiqlDaysAgo(14), "@Today - 14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wiqlValue(tt.value); got != tt.want {
+				t.Errorf("wiqlValue(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWIQLConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition wiqlCondition
+		want      string
+	}{
+		{"equals", wiqlEq("System.State", "Won't Fix"), "[System.State] = 'Won''t Fix'"},
+		{"not equals macro", wiqlNotEq("System.IterationPath", wiqlCurrentIteration), "[System.IterationPath] <> @CurrentIteration"},
+		{"ever", wiqlEver("System.IterationPath", `Project\Sprint 1`), `EVER [System.IterationPath] = 'Project\Sprint 1'`},
+		{"compare", wiqlCompare("System.AreaPath", "UNDER", `Team's Area\Web`), `[System.AreaPath] UNDER 'Team''s Area\Web'`},
+		{"not in strings", wiqlNotIn("System.State", []string{"Done", "Won't Fix"}), "[System.State] NOT IN ('Done', 'Won''t Fix')"},
+		{"not in ints", wiqlNotIn("System.Id", []int{1, 22, 333}), "[System.Id] NOT IN (1, 22, 333)"},
+		{"empty not in is skipped", wiqlNotIn("System.Id", []int{}), ""},
+		{"nil not in is skipped", wiqlNotIn[string]("System.State", nil), ""},
+		{"any groups with OR", wiqlAny(wiqlEq("System.Id", 1), wiqlEq("System.Id", 2)), "([System.Id] = 1 OR [System.Id] = 2)"},
+		{"any with one condition", wiqlAny("", wiqlEq("System.Id", 1)), "[System.Id] = 1"},
+		{"any with nothing", wiqlAny("", ""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if string(tt.condition) != tt.want {
+				t.Errorf("condition = %q, want %q", tt.condition, tt.want)
+			}
+		})
+	}
+}
+
+func TestWIQLQueryString(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *wiqlQuery
+		want  string
+	}{
+		{
+			name:  "no conditions",
+			query: newWIQLQuery(),
+			want:  "SELECT [System.Id]\nFROM WorkItems",
+		},
+		{
+			name: "conditions, skipped empties and ordering",
+			query: newWIQLQuery("System.Id", "System.Title").
+				where(
+					wiqlEq("System.TeamProject", "O'Brien"),
+					wiqlNotIn("System.Id", []int(nil)),
+					wiqlAny(
+						wiqlCompare("System.CreatedDate", ">=", wiqlDaysAgo(30)),
+						wiqlCompare("System.ChangedDate", ">=", wiqlDaysAgo(30)),
+					),
+				).
+				orderBy("System.ChangedDate", true).
+				orderBy("System.Id", false),
+			want: "SELECT [System.Id], [System.Title]\nFROM WorkItems\n" +
+				"WHERE [System.TeamProject] = 'O''Brien'\n" +
+				"AND ([System.CreatedDate] >= @Today - 30 OR [System.ChangedDate] >= @Today - 30)\n" +
+				"ORDER BY [System.ChangedDate] DESC, [System.Id] ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RequiredField is a field the process requires before it accepts a state change
+type RequiredField struct {
+	ReferenceName string   // Field reference name, e.g. Microsoft.VSTS.Common.ResolvedReason
+	Name          string   // Friendly name shown in prompts
+	AllowedValues []string // Values to choose from; empty when any text is accepted
+}
+
+// FieldViolation is a single field rejected by the process rules
+type FieldViolation struct {
+	ReferenceName string
+	Name          string
+	Problem       string // Readable problem, e.g. "is required"
+	NeedsValue    bool   // Whether setting a value would satisfy the rule
+}
+
+// RuleViolationError is returned when an update is rejected by the process rules
+type RuleViolationError struct {
+	Fields  []FieldViolation
+	Message string // Server message, used when no field details are available
+}
+
+func (e *RuleViolationError) Error() string {
+	if len(e.Fields) == 0 {
+		return "rule violation: " + e.Message
+	}
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = field.Name + " " + field.Problem
+	}
+	return "rule violation: " + strings.Join(problems, "; ")
+}
+
+// missingFields returns the violations that can be fixed by supplying a value.
+// ok is false when any violation cannot be fixed that way, such as an illegal transition.
+func (e *RuleViolationError) missingFields() (fields []FieldViolation, ok bool) {
+	for _, field := range e.Fields {
+		if !field.NeedsValue {
+			return nil, false
+		}
+		fields = append(fields, field)
+	}
+	return fields, len(fields) > 0
+}
+
+// allowedNextStates returns the states, in their original order, that every one of
+// the current states may move to. Current states missing from transitions don't restrict
+// the result, so processes without transition data offer every state.
+func allowedNextStates(states []string, transitions map[string][]string, current []string) []string {
+	var allowed []string
+	for _, state := range states {
+		ok := true
+		for _, from := range current {
+			next, known := transitions[from]
+			if !known {
+				continue
+			}
+			if state == from || !slices.Contains(next, state) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			allowed = append(allowed, state)
+		}
+	}
+	return allowed
+}
+
+// workItemTypeGroup is the items of one work item type taking part in a state change
+type workItemTypeGroup struct {
+	workItemType string
+	items        []WorkItem
+}
+
+// groupByWorkItemType splits the items by work item type, in order of first appearance
+func groupByWorkItemType(items []WorkItem) []workItemTypeGroup {
+	var groups []workItemTypeGroup
+	for _, item := range items {
+		i := slices.IndexFunc(groups, func(g workItemTypeGroup) bool { return g.workItemType == item.WorkItemType })
+		if i < 0 {
+			groups = append(groups, workItemTypeGroup{workItemType: item.WorkItemType})
+			i = len(groups) - 1
+		}
+		groups[i].items = append(groups[i].items, item)
+	}
+	return groups
+}
+
+// fromStates returns the distinct current states of the group's items
+func (g workItemTypeGroup) fromStates() []string {
+	var states []string
+	for _, item := range g.items {
+		if !slices.Contains(states, item.State) {
+			states = append(states, item.State)
+		}
+	}
+	return states
+}
+
+// firstInEachState returns the first item of the group in each of its current states
+func (g workItemTypeGroup) firstInEachState() []WorkItem {
+	var items []WorkItem
+	for _, item := range g.items {
+		if !slices.ContainsFunc(items, func(other WorkItem) bool { return other.State == item.State }) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// typeFieldValues splits the collected field values by the work item types that require them
+func typeFieldValues(values map[string]string, fieldTypes map[string][]string) map[string]map[string]string {
+	byType := make(map[string]map[string]string)
+	for referenceName, value := range values {
+		for _, workItemType := range fieldTypes[referenceName] {
+			if byType[workItemType] == nil {
+				byType[workItemType] = make(map[string]string)
+			}
+			byType[workItemType][referenceName] = value
+		}
+	}
+	return byType
+}
+
+// stateUpdates builds the UpdateWorkItem fields for a state change with its required fields
+func stateUpdates(newState string, fieldValues map[string]string) map[string]interface{} {
+	updates := map[string]interface{}{"state": newState}
+	for referenceName, value := range fieldValues {
+		updates[referenceName] = value
+	}
+	return updates
+}
+
+// describeFieldStatus turns the server's field status flags into a readable problem
+func describeFieldStatus(referenceName, flags string) (problem string, needsValue bool) {
+	flags = strings.ToLower(flags)
+	switch {
+	case strings.Contains(flags, "invalidempty"):
+		return "is required", true
+	case strings.Contains(flags, "invalidlistvalue") && referenceName == "System.State":
+		return "cannot move to that state from the current one", false
+	case strings.Contains(flags, "invalidlistvalue"):
+		return "has a value that is not allowed", true
+	case strings.Contains(flags, "invalidnotempty"), strings.Contains(flags, "invalidnotoldvalue"),
+		strings.Contains(flags, "readonly"):
+		return "cannot be changed", false
+	}
+	return fmt.Sprintf("was rejected (%s)", flags), false
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+func TestAllowedNextStates(t *testing.T) {
+	states := []string{"New", "Active", "Resolved", "Closed", "Removed"}
+	transitions := map[string][]string{
+		"New":      {"Active", "Removed"},
+		"Active":   {"New", "Resolved", "Removed"},
+		"Resolved": {"Active", "Closed"},
+	}
+
+	tests := []struct {
+		name        string
+		transitions map[string][]string
+		current     []string
+		want        []string
+	}{
+		{"single state", transitions, []string{"New"}, []string{"Active", "Removed"}},
+		{"keeps state order", transitions, []string{"Active"}, []string{"New", "Resolved", "Removed"}},
+		{"intersection of batch states", transitions, []string{"New", "Active"}, []string{"Removed"}},
+		{"unknown state does not restrict", transitions, []string{"Closed"}, states},
+		{"no transition data", nil, []string{"New"}, states},
+		{"shared target state", transitions, []string{"New", "Resolved"}, []string{"Active"}},
+		{"nothing in common", transitions, []string{"Active", "Resolved"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allowedNextStates(states, tt.transitions, tt.current)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("allowedNextStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleViolationFromError(t *testing.T) {
+	typeKey := "RuleValidationException"
+	message := "TF401320: Rule Error for field Resolved Reason. Error code: Required, HasValues, LimitedToValues, InvalidEmpty."
+	properties := map[string]interface{}{
+		"RuleValidationErrors": []interface{}{
+			map[string]interface{}{
+				"fieldReferenceName": "Microsoft.VSTS.Common.ResolvedReason",
+				"fieldStatusFlags":   "required, hasValues, limitedToValues, invalidEmpty",
+				"errorMessage":       message,
+			},
+			map[string]interface{}{
+				"fieldReferenceName": "System.State",
+				"fieldStatusFlags":   "required, hasValues, limitedToValues, invalidListValue",
+				"errorMessage":       "TF401320: Rule Error for field State. Error code: InvalidListValue.",
+			},
+		},
+	}
+	serverErr := azuredevops.WrappedError{TypeKey: &typeKey, Message: &message, CustomProperties: &properties}
+
+	err := ruleViolationFromError(fmt.Errorf("request failed: %w", serverErr))
+	var violation *RuleViolationError
+	if !errors.As(err, &violation) {
+		t.Fatalf("ruleViolationFromError() = %T, want *RuleViolationError", err)
+	}
+	want := "rule violation: Resolved Reason is required; State cannot move to that state from the current one"
+	if violation.Error() != want {
+		t.Errorf("Error() = %q, want %q", violation.Error(), want)
+	}
+	if _, ok := violation.missingFields(); ok {
+		t.Error("missingFields() should fail when the transition itself is illegal")
+	}
+
+	// Pointers are unwrapped too, and other errors pass through unchanged
+	if !errors.As(ruleViolationFromError(&serverErr), &violation) {
+		t.Error("pointer WrappedError should be converted")
+	}
+	plain := errors.New("connection refused")
+	if ruleViolationFromError(plain) != plain {
+		t.Error("non-rule errors should be returned unchanged")
+	}
+}
+
+func TestDummyBackendRequiresReasonToRemove(t *testing.T) {
+	db := NewDummyBackend()
+	item, err := db.CreateWorkItem(context.Background(), "Stale task", "Task", "", nil, "")
+	if err != nil {
+		t.Fatalf("CreateWorkItem() error: %v", err)
+	}
+
+	err = db.UpdateWorkItemState(context.Background(), item.ID, "Removed")
+	var violation *RuleViolationError
+	if !errors.As(err, &violation) {
+		t.Fatalf("UpdateWorkItemState() error = %v, want a rule violation", err)
+	}
+
+	if err := db.UpdateWorkItem(context.Background(), item.ID, stateUpdates("Removed", map[string]string{"System.Reason": "Obsolete"})); err != nil {
+		t.Fatalf("UpdateWorkItem() with reason error: %v", err)
+	}
+	if item.State != "Removed" {
+		t.Errorf("State = %q, want Removed", item.State)
+	}
+}
+
+func TestStateChangePromptsForRequiredFields(t *testing.T) {
+	db := NewDummyBackend()
+	item, _ := db.CreateWorkItem(context.Background(), "Stale task", "Task", "", nil, "")
+	selected := *item
+
+	m := model{
+		client:       db,
+		selectedTask: &selected,
+		state:        detailView,
+		sprintLists:  make(map[sprintTab]*WorkItemList),
+		batch:        BatchState{selectedItems: make(map[int]WorkItem)},
+	}
+
+	// Only transitions allowed from New are offered
+	m, _ = m.handleStatesLoadedMsg(loadWorkItemStates(context.Background(), db, []WorkItem{selected})().(statesLoadedMsg))
+	if strings.Join(m.availableStates, ",") != "Active,Closed,Removed" {
+		t.Fatalf("availableStates = %v, want transitions from New", m.availableStates)
+	}
+
+	// Choosing Removed asks the backend, which requires a reason
+	m.stateCursor = 2
+	m, cmd := m.handleStatePickerView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.loading {
+		t.Fatal("expected a required fields check")
+	}
+	m, _ = m.handleRequiredFieldsLoadedMsg(checkRequiredFields(context.Background(), db, []WorkItem{*item}, "Removed")().(requiredFieldsLoadedMsg))
+	if m.state != stateFieldsView {
+		t.Fatalf("state = %v, want stateFieldsView", m.state)
+	}
+
+	// Pick the second reason and submit
+	m, _ = m.handleStateFieldsView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, cmd = m.handleStateFieldsView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.batch.operationCount != 1 {
+		t.Fatal("expected the state change to be submitted")
+	}
+	if got := m.stateChange.values["System.Reason"]; got != "Obsolete" {
+		t.Errorf("Reason = %q, want Obsolete", got)
+	}
+
+	msg := updateWorkItemStateWithFields(context.Background(), db, item.ID, "Removed", m.stateChange.values)()
+	if updated, ok := msg.(stateUpdatedMsg); !ok || updated.err != nil {
+		t.Fatalf("update message = %#v, want success", msg)
+	}
+	if item.State != "Removed" {
+		t.Errorf("State = %q, want Removed", item.State)
+	}
+}
+
+func TestStateUpdatedMsgShowsRuleViolation(t *testing.T) {
+	violation := &RuleViolationError{Fields: []FieldViolation{{Name: "Resolved Reason", Problem: "is required", NeedsValue: true}}}
+	m := model{loading: true, state: statePickerView}
+
+	m, _ = m.handleStateUpdatedMsg(stateUpdatedMsg{err: fmt.Errorf("failed to update work item state: %w", violation)})
+	if m.statusMessage != "Error: rule violation: Resolved Reason is required" {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+}
+
+// bugProcessBackend is a dummy backend whose bugs are resolved before they close and need a
+// resolved reason to close
+type bugProcessBackend struct {
+	*DummyBackend
+	mu      sync.Mutex
+	updates map[string]map[string]interface{} // Last updates sent, keyed by work item type
+}
+
+func (b *bugProcessBackend) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	if workItemType == "Bug" {
+		return map[string][]string{"New": {"Active"}, "Active": {"Resolved", "Closed"}, "Resolved": {"Closed"}}, nil
+	}
+	return b.DummyBackend.GetWorkItemTypeTransitions(ctx, workItemType)
+}
+
+func (b *bugProcessBackend) GetWorkItemTypeStates(ctx context.Context, workItemType string) ([]string, map[string]string, error) {
+	states, categories, err := b.DummyBackend.GetWorkItemTypeStates(ctx, workItemType)
+	if workItemType == "Bug" {
+		states = []string{"New", "Active", "Resolved", "Closed"}
+		categories["Resolved"] = "Resolved"
+	}
+	return states, categories, err
+}
+
+func (b *bugProcessBackend) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	if workItemType == "Bug" && newState == "Closed" {
+		return []RequiredField{{ReferenceName: "Microsoft.VSTS.Common.ResolvedReason", Name: "Resolved Reason"}}, nil
+	}
+	return b.DummyBackend.GetRequiredFields(ctx, workItemID, workItemType, newState)
+}
+
+func (b *bugProcessBackend) UpdateWorkItems(ctx context.Context, workItemIDs []int, updates map[string]interface{}) (map[int]error, error) {
+	items, _ := b.DummyBackend.GetWorkItemDetailsByIDs(ctx, workItemIDs[:1])
+	b.mu.Lock()
+	b.updates[items[0].WorkItemType] = updates
+	b.mu.Unlock()
+	return b.DummyBackend.UpdateWorkItems(ctx, workItemIDs, updates)
+}
+
+// activeReasonBackend is a dummy backend that asks for a reason only when an item leaves Active
+type activeReasonBackend struct {
+	*DummyBackend
+	transitionsErr error
+}
+
+func (b *activeReasonBackend) GetWorkItemTypeTransitions(ctx context.Context, workItemType string) (map[string][]string, error) {
+	if b.transitionsErr != nil {
+		return nil, b.transitionsErr
+	}
+	return b.DummyBackend.GetWorkItemTypeTransitions(ctx, workItemType)
+}
+
+func (b *activeReasonBackend) GetRequiredFields(ctx context.Context, workItemID int, workItemType string, newState string) ([]RequiredField, error) {
+	item, err := b.GetWorkItemByID(ctx, workItemID)
+	if err != nil || item.State != "Active" {
+		return nil, err
+	}
+	return []RequiredField{{ReferenceName: "Custom.Reason", Name: "Reason"}}, nil
+}
+
+func TestRequiredFieldsCheckedPerState(t *testing.T) {
+	db := &activeReasonBackend{DummyBackend: NewDummyBackend()}
+	fresh, _ := db.CreateWorkItem(context.Background(), "Not started", "Task", "", nil, "")
+	started, _ := db.CreateWorkItem(context.Background(), "Half done", "Task", "", nil, "")
+	if err := db.UpdateWorkItem(context.Background(), started.ID, map[string]interface{}{"state": "Active"}); err != nil {
+		t.Fatalf("UpdateWorkItem() error: %v", err)
+	}
+	items := mustGetWorkItems(t, db, []int{fresh.ID, started.ID})
+
+	// The task that is not Active comes first but must not hide the other's rule
+	msg := checkRequiredFields(context.Background(), db, items, "Removed")().(requiredFieldsLoadedMsg)
+	if msg.err != nil || len(msg.fields) != 1 || !slices.Equal(msg.fieldTypes["Custom.Reason"], []string{"Task"}) {
+		t.Errorf("fields = %v, field types = %v, err = %v; want the reason once for tasks", msg.fields, msg.fieldTypes, msg.err)
+	}
+}
+
+func TestStatesLoadFailsWithoutTransitions(t *testing.T) {
+	db := &activeReasonBackend{DummyBackend: NewDummyBackend(), transitionsErr: &TimeoutError{Timeout: time.Second, Err: context.DeadlineExceeded}}
+	item, _ := db.CreateWorkItem(context.Background(), "Tidy up", "Task", "", nil, "")
+
+	msg := loadWorkItemStates(context.Background(), db, []WorkItem{*item})().(statesLoadedMsg)
+	var timeout *TimeoutError
+	if !errors.As(msg.err, &timeout) || msg.states != nil {
+		t.Errorf("states = %v, err = %v; want the timeout rather than unfiltered states", msg.states, msg.err)
+	}
+}
+
+func TestMixedTypeStateChange(t *testing.T) {
+	db := &bugProcessBackend{DummyBackend: NewDummyBackend(), updates: make(map[string]map[string]interface{})}
+	task, _ := db.CreateWorkItem(context.Background(), "Tidy up", "Task", "", nil, "")
+	bug, _ := db.CreateWorkItem(context.Background(), "Crash on save", "Bug", "", nil, "")
+	for _, item := range []*WorkItem{task, bug} {
+		if err := db.UpdateWorkItem(context.Background(), item.ID, map[string]interface{}{"state": "Active"}); err != nil {
+			t.Fatalf("UpdateWorkItem() error: %v", err)
+		}
+	}
+	items := mustGetWorkItems(t, db, []int{task.ID, bug.ID})
+
+	// The bug was selected in another list, so only the selection knows its type
+	m := model{
+		client:      db,
+		state:       listView,
+		sprintLists: map[sprintTab]*WorkItemList{currentSprint: {tasks: items[:1]}},
+		currentMode: sprintMode,
+		currentTab:  currentSprint,
+		batch:       BatchState{selectedItems: map[int]WorkItem{task.ID: items[0], bug.ID: items[1]}},
+	}
+
+	// Only Closed is reachable from Active for both a task and a bug
+	m, _ = m.handleStatesLoadedMsg(loadWorkItemStates(context.Background(), db, m.stateChangeItems())().(statesLoadedMsg))
+	if strings.Join(m.availableStates, ",") != "Closed" {
+		t.Fatalf("availableStates = %v, want the states both types allow", m.availableStates)
+	}
+
+	// Closing asks for the bug's resolved reason even though the task comes first
+	m, _ = m.startStateChange("Closed")
+	m, _ = m.handleRequiredFieldsLoadedMsg(checkRequiredFields(context.Background(), db, m.stateChangeItems(), "Closed")().(requiredFieldsLoadedMsg))
+	if m.state != stateFieldsView || len(m.stateChange.fields) != 1 {
+		t.Fatalf("fields = %v, want the bug's resolved reason", m.stateChange.fields)
+	}
+	m.stateChange.input.SetValue("Fixed")
+	m, cmd := m.handleStateFieldsView(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.batch.running {
+		t.Fatal("expected the batch to start")
+	}
+	m = drainBatch(t, m, runBatch(context.Background(), db, m.batch.job, m.stateChange.itemIDs))
+	if len(m.batch.failures) != 0 {
+		t.Fatalf("failures = %v, want none", m.batch.failures)
+	}
+
+	// The reason is only sent for the bug
+	if _, ok := db.updates["Task"]["Microsoft.VSTS.Common.ResolvedReason"]; ok {
+		t.Errorf("task updates = %v, want no resolved reason", db.updates["Task"])
+	}
+	if got := db.updates["Bug"]["Microsoft.VSTS.Common.ResolvedReason"]; got != "Fixed" {
+		t.Errorf("bug resolved reason = %v, want Fixed", got)
+	}
+}
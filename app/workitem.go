@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -16,6 +17,7 @@ type WorkItem struct {
 	Description   string
 	Tags          string
 	Priority      int
+	RemainingWork float64
 	CreatedDate   string
 	ChangedDate   string
 	IterationPath string
@@ -23,6 +25,57 @@ type WorkItem struct {
 	ParentID      *int
 	Children      []*WorkItem
 	Comments      string // Discussion/History
+	Attachments   []Attachment
+	Partial       bool `json:"-"` // Only the list fields were fetched; the rest loads when the item is opened
+}
+
+// listProjection returns the item as list loads fetch it, without the fields only the detail view shows
+func (w WorkItem) listProjection() WorkItem {
+	w.Description = ""
+	w.Comments = ""
+	w.Attachments = nil
+	w.Children = nil
+	w.Partial = true
+	return w
+}
+
+// Attachment is a file attached to a work item
+type Attachment struct {
+	ID        string // Attachment GUID
+	Name      string
+	URL       string
+	Size      int64
+	AddedDate string
+	Comment   string
+}
+
+// WorkItemRevision is a snapshot of the tracked fields of a work item at a given revision
+type WorkItemRevision struct {
+	Rev           int
+	ChangedBy     string
+	ChangedDate   string
+	State         string
+	IterationPath string
+	RemainingWork float64
+	Title         string
+	AssignedTo    string
+	Priority      int
+	Tags          string
+}
+
+// WorkItemFieldChange is a single field changed by a work item update
+type WorkItemFieldChange struct {
+	Field    string // Display name of the field (e.g. "State")
+	OldValue string
+	NewValue string
+}
+
+// WorkItemUpdate is one revision of a work item and the fields it changed
+type WorkItemUpdate struct {
+	Rev         int
+	ChangedBy   string
+	ChangedDate string
+	Changes     []WorkItemFieldChange
 }
 
 // TreeItem represents a flattened tree view item with depth information
@@ -39,6 +92,7 @@ type WorkItemList struct {
 	scrollOffset  int        // Scroll offset for this list
 	filterActive  bool       // Whether filter is active for this list
 	filteredTasks []WorkItem // Filtered tasks for this list
+	ids           []int      // Every ID matched by the list's query, in order
 	loaded        int        // Number of items loaded so far
 	totalCount    int        // Total count from server
 	attempted     bool       // Whether we've attempted to load this list
@@ -197,6 +251,47 @@ func (wl *WorkItemList) invalidateTreeCache() {
 	wl.treeCache = nil
 }
 
+// setTaskState updates the state of a loaded task in place, returning false if it isn't in the list
+func (wl *WorkItemList) setTaskState(workItemID int, state string) bool {
+	found := false
+	for i := range wl.tasks {
+		if wl.tasks[i].ID == workItemID {
+			wl.tasks[i].State = state
+			found = true
+		}
+	}
+	for i := range wl.filteredTasks {
+		if wl.filteredTasks[i].ID == workItemID {
+			wl.filteredTasks[i].State = state
+		}
+	}
+	if found {
+		wl.invalidateTreeCache()
+	}
+	return found
+}
+
+// mergeDetails replaces partial tasks with their complete versions, keeping the tree links,
+// and returns false if none of them are in the list
+func (wl *WorkItemList) mergeDetails(details map[int]WorkItem) bool {
+	found := false
+	merge := func(tasks []WorkItem) {
+		for i := range tasks {
+			if detail, ok := details[tasks[i].ID]; ok {
+				detail.Children = tasks[i].Children
+				tasks[i] = detail
+				found = true
+			}
+		}
+	}
+	merge(wl.tasks)
+	merge(wl.filteredTasks)
+	if found {
+		wl.invalidateTreeCache()
+	}
+	return found
+}
+
 // appendTasks adds new tasks to the list and updates loaded count
 func (wl *WorkItemList) appendTasks(tasks []WorkItem) {
 	wl.tasks = append(wl.tasks, tasks...)
@@ -213,6 +308,40 @@ func (wl *WorkItemList) replaceTasks(tasks []WorkItem, totalCount int) {
 	wl.invalidateTreeCache()
 }
 
+// nextPageIDs returns the IDs of the next page of items to fetch
+func (wl *WorkItemList) nextPageIDs(pageSize int) []int {
+	if wl.loaded >= len(wl.ids) {
+		return nil
+	}
+	return slices.Clone(wl.ids[wl.loaded:min(wl.loaded+pageSize, len(wl.ids))])
+}
+
+// forgetIDs removes IDs from the list's query results, keeping paging aligned with the loaded tasks
+func (wl *WorkItemList) forgetIDs(ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+	wl.ids = slices.DeleteFunc(wl.ids, func(id int) bool {
+		return slices.Contains(ids, id)
+	})
+}
+
+// missingIDs returns the requested IDs that have no task, because the items were deleted
+// after the query ran
+func missingIDs(requested []int, tasks []WorkItem) []int {
+	fetched := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		fetched[task.ID] = true
+	}
+	var missing []int
+	for _, id := range requested {
+		if !fetched[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
 // Date/Time Formatting Functions
 
 // formatDateTime formats a datetime string into a human-readable format
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -331,6 +332,54 @@ func TestWorkItemList_replaceTasks(t *testing.T) {
 	}
 }
 
+// TestWorkItemList_nextPageIDs tests taking the next page from the query's IDs
+func TestWorkItemList_nextPageIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int
+		loaded   int
+		pageSize int
+		want     []int
+	}{
+		{"First page", []int{5, 3, 9, 1, 7}, 0, 2, []int{5, 3}},
+		{"Middle page", []int{5, 3, 9, 1, 7}, 2, 2, []int{9, 1}},
+		{"Short last page", []int{5, 3, 9, 1, 7}, 4, 2, []int{7}},
+		{"Everything loaded", []int{5, 3, 9}, 3, 2, nil},
+		{"No query IDs", nil, 0, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl := &WorkItemList{ids: tt.ids, loaded: tt.loaded}
+			got := wl.nextPageIDs(tt.pageSize)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("nextPageIDs(%d) = %v, want %v", tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWorkItemList_forgetMissingIDs tests dropping IDs of items deleted after the query
+func TestWorkItemList_forgetMissingIDs(t *testing.T) {
+	wl := &WorkItemList{ids: []int{5, 3, 9, 1}}
+	page := wl.nextPageIDs(3)
+	tasks := []WorkItem{createTestWorkItem(5, "Five", nil), createTestWorkItem(9, "Nine", nil)}
+
+	missing := missingIDs(page, tasks)
+	if !slices.Equal(missing, []int{3}) {
+		t.Fatalf("missingIDs() = %v, want [3]", missing)
+	}
+
+	wl.appendTasks(tasks)
+	wl.forgetIDs(missing)
+	if !slices.Equal(wl.ids, []int{5, 9, 1}) {
+		t.Errorf("ids = %v, want [5 9 1]", wl.ids)
+	}
+	if got := wl.nextPageIDs(3); !slices.Equal(got, []int{1}) {
+		t.Errorf("nextPageIDs() after forgetting = %v, want [1]", got)
+	}
+}
+
 // TestWorkItemList_invalidateTreeCache tests cache invalidation
 func TestWorkItemList_invalidateTreeCache(t *testing.T) {
 	wl := &WorkItemList{
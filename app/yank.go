@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// yankFormat is a way of copying a work item reference
+type yankFormat int
+
+const (
+	yankID           yankFormat = iota // #1234
+	yankURL                            // Web URL of the item
+	yankIDTitle                        // 1234: Title
+	yankMarkdownLink                   // [#1234: Title](url)
+)
+
+// yankFormats lists the yank menu entries in display order
+var yankFormats = []yankFormat{yankID, yankURL, yankIDTitle, yankMarkdownLink}
+
+func (f yankFormat) String() string {
+	switch f {
+	case yankURL:
+		return "URL"
+	case yankIDTitle:
+		return "ID: Title"
+	case yankMarkdownLink:
+		return "Markdown link"
+	default:
+		return "#ID"
+	}
+}
+
+// key is the shortcut that copies the format directly from the yank menu
+func (f yankFormat) key() string {
+	switch f {
+	case yankURL:
+		return "u"
+	case yankIDTitle:
+		return "t"
+	case yankMarkdownLink:
+		return "m"
+	default:
+		return "i"
+	}
+}
+
+// action is the yank menu action that copies the format
+func (f yankFormat) action() keyAction {
+	switch f {
+	case yankURL:
+		return "copy_url"
+	case yankIDTitle:
+		return "copy_id_title"
+	case yankMarkdownLink:
+		return "copy_markdown_link"
+	default:
+		return "copy_id"
+	}
+}
+
+// formatYank renders the items in the format, one per line
+func formatYank(format yankFormat, items []*WorkItem, config *Config) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		switch format {
+		case yankURL:
+			lines[i] = itemWebURL(config, item.ID)
+		case yankIDTitle:
+			lines[i] = fmt.Sprintf("%d: %s", item.ID, item.Title)
+		case yankMarkdownLink:
+			// Brackets in the title would end the link text early
+			title := strings.NewReplacer("[", "\\[", "]", "\\]").Replace(item.Title)
+			lines[i] = fmt.Sprintf("[#%d: %s](%s)", item.ID, title, itemWebURL(config, item.ID))
+		default:
+			lines[i] = fmt.Sprintf("#%d", item.ID)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clipboardOutput is where OSC-52 sequences are written; the TUI owns stdout, stderr is the same terminal
+var clipboardOutput io.Writer = os.Stderr
+
+// copyToClipboard copies text with an OSC-52 escape sequence, so it reaches the local
+// clipboard over SSH, and with the system clipboard when one is available.
+// It only fails when neither works.
+func copyToClipboard(text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, oscErr := seq.WriteTo(clipboardOutput)
+
+	if err := clipboard.WriteAll(text); err != nil && oscErr != nil {
+		return fmt.Errorf("no clipboard available: %w", err)
+	}
+	return nil
+}
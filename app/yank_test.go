@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatYank(t *testing.T) {
+	items := []*WorkItem{
+		{ID: 1234, Title: "Fix [beta] login"},
+		{ID: 7, Title: "Docs"},
+	}
+	config := &Config{OrganizationURL: "https://dev.azure.com/acme/", Project: "Web"}
+
+	tests := []struct {
+		format yankFormat
+		want   string
+	}{
+		{yankID, "#1234\n#7"},
+		{yankURL, "https://dev.azure.com/acme/Web/_workitems/edit/1234\nhttps://dev.azure.com/acme/Web/_workitems/edit/7"},
+		{yankIDTitle, "1234: Fix [beta] login\n7: Docs"},
+		{yankMarkdownLink, "[#1234: Fix \\[beta\\] login](https://dev.azure.com/acme/Web/_workitems/edit/1234)\n[#7: Docs](https://dev.azure.com/acme/Web/_workitems/edit/7)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			if got := formatYank(tt.format, items, config); got != tt.want {
+				t.Errorf("formatYank() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyToClipboardWritesOSC52(t *testing.T) {
+	var out bytes.Buffer
+	original := clipboardOutput
+	clipboardOutput = &out
+	defer func() { clipboardOutput = original }()
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	// The system clipboard may be missing in CI; OSC-52 alone is enough
+	if err := copyToClipboard("#42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out.String(); got != "\x1b]52;c;IzQy\x07" {
+		t.Errorf("unexpected OSC-52 sequence %q", got)
+	}
+}
+
+func TestYankTargets(t *testing.T) {
+	tasks := []WorkItem{
+		createTestWorkItem(1, "One", nil),
+		createTestWorkItem(2, "Two", nil),
+		createTestWorkItem(3, "Three", nil),
+	}
+	newModel := func() model {
+		return model{
+			state:       listView,
+			currentMode: sprintMode,
+			currentTab:  currentSprint,
+			sprintLists: map[sprintTab]*WorkItemList{currentSprint: createTestList(tasks)},
+			batch:       BatchState{selectedItems: map[int]WorkItem{}},
+		}
+	}
+
+	m := newModel()
+	m.ui.cursor = 1
+	if items := m.yankTargets(); len(items) != 1 || items[0].ID != 2 {
+		t.Errorf("expected the cursor item, got %+v", items)
+	}
+
+	m.batch.selectedItems = map[int]WorkItem{3: tasks[2], 1: tasks[0]}
+	items := m.yankTargets()
+	if len(items) != 2 || items[0].ID != 1 || items[1].ID != 3 {
+		t.Errorf("expected selected items in list order, got %+v", items)
+	}
+
+	m = newModel()
+	m.state = detailView
+	m.selectedTask = &tasks[2]
+	if items := m.yankTargets(); len(items) != 1 || items[0].ID != 3 {
+		t.Errorf("expected the detail item, got %+v", items)
+	}
+}
+
+func TestYankMenu(t *testing.T) {
+	m := model{
+		state:        detailView,
+		config:       &Config{OrganizationURL: "https://dev.azure.com/acme", Project: "Web"},
+		selectedTask: &WorkItem{ID: 5, Title: "Five"},
+		batch:        BatchState{selectedItems: map[int]WorkItem{}},
+	}
+
+	m, _, handled := m.handleGlobalHotkeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if !handled || m.state != yankView {
+		t.Fatalf("expected yank menu, got %v", m.state)
+	}
+	if view := m.renderYankView(); !strings.Contains(view, "[#5: Five](https://dev.azure.com/acme/Web/_workitems/edit/5)") {
+		t.Errorf("expected markdown preview in menu, got:\n%s", view)
+	}
+
+	m, cmd := m.handleYankView(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if m.state != detailView || cmd == nil {
+		t.Fatalf("expected copy and return to detail view, got %v", m.state)
+	}
+
+	m, _ = m.handleYankDoneMsg(yankDoneMsg{format: yankIDTitle, count: 1})
+	if m.lastActionLog != "Copied 5: Five to clipboard" {
+		t.Errorf("unexpected action log %q", m.lastActionLog)
+	}
+}